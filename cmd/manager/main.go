@@ -17,14 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
 
+	admissionregistrationv1beta1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators/cluster"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators/machine"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
 	clusterapis "sigs.k8s.io/cluster-api/pkg/apis"
 	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
@@ -41,8 +50,97 @@ func main() {
 	flag.Set("logtostderr", "true")
 	watchNamespace := flag.String("namespace", "",
 		"Namespace that the controller watches to reconcile cluster-api objects. If unspecified, the controller watches for cluster-api objects across all namespaces.")
+	serializeControlPlaneJoins := flag.Bool("serialize-control-plane-joins", false,
+		"Join control plane machines to the cluster one at a time, to avoid etcd membership churn.")
+	allowedComplianceScopes := flag.String("allowed-compliance-scopes", "",
+		"Comma-separated list of compliance scopes machines are allowed to set. If unspecified, any compliance scope is allowed.")
+	validateControlPlaneHealth := flag.Bool("validate-control-plane-health", false,
+		"Wait for the control plane /healthz endpoint to report healthy before joining new machines to the cluster.")
+	probeControlPlaneConnectivity := flag.Bool("probe-control-plane-connectivity", false,
+		"Check that the control plane endpoint accepts TCP connections from the controller before joining new machines to the cluster.")
+	waitForHealthyAPIServerELB := flag.Bool("wait-for-healthy-apiserver-elb", false,
+		"Wait for at least one control plane instance behind the API server load balancer to be healthy before joining new machines to the cluster.")
+	skipTagReconcile := flag.Bool("skip-tag-reconcile", false,
+		"Only tag machine instances when launching them instead of reconciling their tags on every update, to reduce AWS API calls in large clusters.")
+	setMachinePhase := flag.Bool("set-machine-phase", false,
+		"Set the phase of machines from the state of their instance, one of Provisioning, Running, Deleting or Failed.")
+	recordAccountID := flag.Bool("record-account-id", false,
+		"Record the ID of the AWS account instances are launched in on the machine status.")
+	recordConsoleOutput := flag.Bool("record-console-output", false,
+		"Record the tail of the console output of instances that exceed their launch timeout on the machine status.")
+	annotatePlacement := flag.Bool("annotate-placement", false,
+		"Annotate machines with the subnet and availability zone their instance was launched in.")
+	workloadClusterCABundle := flag.String("workload-cluster-ca-bundle", "",
+		"Path to a PEM encoded CA bundle the control plane certificate of workload clusters is also verified against, in addition to the CA of their kubeconfig.")
+	workloadClusterInsecureFallback := flag.Bool("workload-cluster-insecure-fallback", false,
+		"Skip TLS verification when connecting to the control plane of a workload cluster whose certificate can't be verified. Verification is always attempted first.")
+	clusterInfrastructureReadyRequeue := flag.Duration("cluster-infrastructure-ready-requeue", 0,
+		"How long to wait before reconciling a machine again while the cluster infrastructure isn't ready. If unspecified, defaults to 15s.")
+	controlPlaneMachineExistenceRequeue := flag.Duration("control-plane-machine-existence-requeue", 0,
+		"How long to wait before reconciling a node machine again while no control plane machine exists. If unspecified, defaults to 5s.")
+	controlPlaneMachineExistenceTimeout := flag.Duration("control-plane-machine-existence-timeout", 0,
+		"How long a node machine waits for a control plane machine to exist before it is marked as failed. If unspecified, node machines wait indefinitely.")
+	controlPlaneReadyRequeue := flag.Duration("control-plane-ready-requeue", 0,
+		"How long to wait before reconciling a control plane machine again while another one initializes the cluster. If unspecified, defaults to 5s.")
+	tagThrottlingRequeue := flag.Duration("tag-throttling-requeue", 0,
+		"How long to wait before reconciling a machine again when tagging its instance was throttled by AWS. If unspecified, defaults to 30s.")
+	tagBatchInterval := flag.Duration("tag-batch-interval", 0,
+		"How long tag updates of resources are collected for before being applied with a single AWS API call per tag set, to reduce AWS API calls in large clusters. If unspecified, every resource is tagged with its own call.")
+	bootstrapTokenTTL := flag.Duration("bootstrap-token-ttl", 0,
+		"Lifetime of the bootstrap tokens machines join the cluster with. If unspecified, defaults to 10m.")
+	requeueJitter := flag.Float64("requeue-jitter", 0,
+		"Fraction by which requeue intervals of machines waiting on the cluster are randomly varied, e.g. 0.1 for +/-10%. If unspecified, intervals aren't varied.")
+	providerIDFormat := flag.String("provider-id-format", string(machine.ProviderIDFormatZone),
+		"Format of the provider ID set on machines, either NoZone (aws:////<instance-id>) or Zone (aws:///<availability-zone>/<instance-id>). Provider IDs of existing machines are migrated to it.")
+	awsMaxRetries := flag.Int("aws-max-retries", actuators.UseDefaultMaxRetries,
+		fmt.Sprintf("Number of times AWS API calls that were throttled or failed with a transient error are retried, 0 to not retry them. If unspecified, defaults to %d.", actuators.DefaultMaxRetries))
+	awsMinRetryDelay := flag.Duration("aws-min-retry-delay", actuators.DefaultMinRetryDelay,
+		"Delay before the first retry of an AWS API call, doubled on every retry.")
+	awsMaxRetryDelay := flag.Duration("aws-max-retry-delay", actuators.DefaultMaxRetryDelay,
+		"Cap on the delay between retries of an AWS API call.")
+	webhookPort := flag.Int("webhook-port", 0,
+		"Port the validating webhook rejecting changes of immutable machine fields is served on. If unspecified, the webhook isn't served.")
+	webhookCertDir := flag.String("webhook-cert-dir", "/tmp/cert",
+		"Directory holding the tls.crt and tls.key files the validating webhook is served with. If they don't exist and --webhook-service is set, a self-signed certificate is written to it.")
+	webhookService := flag.String("webhook-service", "",
+		"Namespace and name of the Service fronting the validating webhook, e.g. aws-provider-system/aws-provider-webhook-service, to issue a self-signed serving certificate for. If unspecified, the certificate has to be provided in --webhook-cert-dir.")
+	webhookConfigName := flag.String("webhook-config-name", "",
+		"Name of the ValidatingWebhookConfiguration of the validating webhook, whose CA bundle is set to the CA of the self-signed serving certificate.")
+	awsProfile := flag.String("aws-profile", "",
+		"Named profile of the AWS shared config and credentials files to use, e.g. for local testing. If unspecified, the default credential chain is used.")
 	flag.Parse()
 
+	switch machine.ProviderIDFormat(*providerIDFormat) {
+	case machine.ProviderIDFormatNoZone, machine.ProviderIDFormatZone:
+	default:
+		klog.Fatalf("Invalid provider ID format %q", *providerIDFormat)
+	}
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "aws-max-retries" && *awsMaxRetries < 0 {
+			klog.Fatalf("Invalid number of AWS API call retries %d, must not be negative", *awsMaxRetries)
+		}
+	})
+
+	actuators.SetRetryer(actuators.NewRetryer(actuators.RetryerParams{
+		MaxRetries: *awsMaxRetries,
+		MinDelay:   *awsMinRetryDelay,
+		MaxDelay:   *awsMaxRetryDelay,
+	}))
+	actuators.SetProfile(*awsProfile)
+	if *tagBatchInterval > 0 {
+		ec2.SetTagBatcher(ec2.NewTagBatcher(*tagBatchInterval))
+	}
+
+	var caBundle []byte
+	if *workloadClusterCABundle != "" {
+		var err error
+		caBundle, err = ioutil.ReadFile(*workloadClusterCABundle)
+		if err != nil {
+			klog.Fatalf("Failed to read workload cluster CA bundle: %v", err)
+		}
+	}
+
 	cfg := config.GetConfigOrDie()
 
 	// Setup a Manager
@@ -83,9 +181,32 @@ func main() {
 
 	// Initialize machine actuator.
 	machineActuator := machine.NewActuator(machine.ActuatorParams{
-		CoreClient:     coreClient,
-		ClusterClient:  cs.ClusterV1alpha1(),
-		LoggingContext: "[machine-actuator]",
+		CoreClient:                 coreClient,
+		ClusterClient:              cs.ClusterV1alpha1(),
+		LoggingContext:             "[machine-actuator]",
+		SerializeControlPlaneJoins: *serializeControlPlaneJoins,
+		AllowedComplianceScopes:    splitList(*allowedComplianceScopes),
+		ValidateControlPlaneHealth: *validateControlPlaneHealth,
+		WaitForHealthyAPIServerELB: *waitForHealthyAPIServerELB,
+		SkipTagReconcile:           *skipTagReconcile,
+		SetMachinePhase:            *setMachinePhase,
+		RecordAccountID:            *recordAccountID,
+		RecordConsoleOutput:        *recordConsoleOutput,
+		AnnotatePlacement:          *annotatePlacement,
+
+		ProbeControlPlaneConnectivity: *probeControlPlaneConnectivity,
+
+		WorkloadClusterCABundle:         caBundle,
+		WorkloadClusterInsecureFallback: *workloadClusterInsecureFallback,
+
+		ClusterInfrastructureReadyRequeue:   *clusterInfrastructureReadyRequeue,
+		ControlPlaneMachineExistenceRequeue: *controlPlaneMachineExistenceRequeue,
+		ControlPlaneMachineExistenceTimeout: *controlPlaneMachineExistenceTimeout,
+		ControlPlaneReadyRequeue:            *controlPlaneReadyRequeue,
+		TagThrottlingRequeue:                *tagThrottlingRequeue,
+		BootstrapTokenTTL:                   *bootstrapTokenTTL,
+		RequeueJitter:                       *requeueJitter,
+		ProviderIDFormat:                    machine.ProviderIDFormat(*providerIDFormat),
 	})
 
 	// Register our cluster deployer (the interface is in clusterctl and we define the Deployer interface on the actuator)
@@ -102,7 +223,74 @@ func main() {
 	capimachine.AddWithActuator(mgr, machineActuator)
 	capicluster.AddWithActuator(mgr, clusterActuator)
 
+	if *webhookPort > 0 {
+		if *webhookService != "" {
+			parts := strings.Split(*webhookService, "/")
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				klog.Fatalf("Invalid webhook service %q, must be <namespace>/<name>", *webhookService)
+			}
+
+			webhookCABundle, err := machine.ProvisionWebhookCertificate(*webhookCertDir, parts[0], parts[1])
+			if err != nil {
+				klog.Fatalf("Failed to provision webhook certificate: %v", err)
+			}
+
+			if *webhookConfigName != "" && webhookCABundle != nil {
+				admissionClient, err := admissionregistrationv1beta1.NewForConfig(cfg)
+				if err != nil {
+					klog.Fatalf("Failed to create admissionregistration client from configuration: %v", err)
+				}
+				if err := machine.InjectWebhookCABundle(admissionClient.ValidatingWebhookConfigurations(), *webhookConfigName, webhookCABundle); err != nil {
+					klog.Fatalf("Failed to set webhook CA bundle: %v", err)
+				}
+			}
+		}
+
+		if err := mgr.Add(webhookServer(*webhookPort, *webhookCertDir)); err != nil {
+			klog.Fatalf("Failed to set up webhook server: %v", err)
+		}
+	}
+
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
 		klog.Fatalf("Failed to run manager: %v", err)
 	}
 }
+
+// webhookServer returns a runnable serving the validating webhook of machines
+// over TLS until the manager stops.
+func webhookServer(port int, certDir string) manager.Runnable {
+	wh := machine.NewValidatingWebhook()
+	if err := wh.Validate(); err != nil {
+		klog.Fatalf("Invalid webhook: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(wh.GetPath(), wh.Handler())
+	srv := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		errs := make(chan error, 1)
+		go func() {
+			klog.Infof("Serving validating webhook on port %d", port)
+			errs <- srv.ListenAndServeTLS(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+		}()
+
+		select {
+		case err := <-errs:
+			return err
+		case <-stop:
+			return srv.Shutdown(context.Background())
+		}
+	})
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -40,9 +40,33 @@ type AWSClusterProviderSpec struct {
 	// The AWS Region the cluster lives in.
 	Region string `json:"region,omitempty"`
 
+	// Partition is the AWS partition the region belongs to, e.g. "aws-us-gov"
+	// for GovCloud or "aws-cn" for China. Service endpoints are resolved in it.
+	// Defaults to the partition the region is known to belong to.
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints of AWS services, e.g. to use
+	// VPC endpoints or a local AWS emulator such as localstack.
+	// +optional
+	ServiceEndpoints []ServiceEndpoint `json:"serviceEndpoints,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the bastion host.
 	SSHKeyName string `json:"sshKeyName,omitempty"`
 
+	// SSHPublicKey is the public key material in OpenSSH format. When a machine's
+	// SSH key pair doesn't exist in the region, it is imported from this key.
+	// +optional
+	SSHPublicKey string `json:"sshPublicKey,omitempty"`
+
+	// CreateSSHKeyPairs creates a machine's SSH key pair when it doesn't exist in
+	// the region and SSHPublicKey is not set, instead of failing the machine. The
+	// private key is stored in a secret of type kubernetes.io/ssh-auth named
+	// <cluster name>-ssh-key-<key pair name>, and the key pairs created this way
+	// are deleted with the cluster.
+	// +optional
+	CreateSSHKeyPairs *bool `json:"createSSHKeyPairs,omitempty"`
+
 	// CAKeyPair is the key pair for ca certs.
 	CAKeyPair KeyPair `json:"caKeyPair,omitempty"`
 
@@ -62,6 +86,99 @@ type AWSClusterProviderSpec struct {
 	// AdditionalUserDataFiles specifies extra files to be passed to all Machines' user_data upon creation.
 	// +optional
 	AdditionalUserDataFiles []userdata.Files `json:"additionalUserDataFiles,omitempty"`
+
+	// AdditionalUserDataParts specifies extra cloud-init parts to be passed to all
+	// Machines' user_data upon creation, after the bootstrap user data.
+	// +optional
+	AdditionalUserDataParts []userdata.Part `json:"additionalUserDataParts,omitempty"`
+
+	// ControlPlaneLoadBalancer is optional configuration for the load balancer
+	// fronting the control plane machines.
+	// +optional
+	ControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"controlPlaneLoadBalancer,omitempty"`
+
+	// APIServerPort is the port the kube-apiserver of the control plane
+	// machines binds to and the API server load balancer listens on. Defaults
+	// to 6443. The listener of the load balancer follows changes, but control
+	// plane machines created before have to be replaced to bind to a new port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	APIServerPort int64 `json:"apiServerPort,omitempty"`
+
+	// APIServerDNS is optional configuration for a Route53 record pointing at
+	// the API server load balancer, to give clients a stable name for the
+	// control plane endpoint.
+	// +optional
+	APIServerDNS *APIServerDNSSpec `json:"apiServerDNS,omitempty"`
+
+	// MaintenanceWindow is a recurring window during which no new instances are
+	// launched for the machines of the cluster, e.g. in change-controlled
+	// environments. Machines created during it are requeued until it ends.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// AWSLoadBalancerSpec defines the desired state of an AWS load balancer.
+type AWSLoadBalancerSpec struct {
+	// Scheme sets the scheme of the load balancer. An internal load balancer
+	// is placed in the private subnets and is only reachable from within the VPC.
+	// Defaults to Internet-facing. It cannot be changed once the load balancer is created.
+	// +optional
+	Scheme *ClassicELBScheme `json:"scheme,omitempty"`
+
+	// CertificateARN is the ARN of an ACM or IAM certificate. When set, the load
+	// balancer terminates TLS with this certificate instead of passing TCP through
+	// to the API servers.
+	// +optional
+	CertificateARN *string `json:"certificateARN,omitempty"`
+
+	// ListenerProtocol is the protocol of the listener that terminates TLS,
+	// either SSL or HTTPS. Defaults to SSL. Requires CertificateARN.
+	// +optional
+	ListenerProtocol *ClassicELBProtocol `json:"listenerProtocol,omitempty"`
+
+	// HealthCheck replaces the default health check of the load balancer,
+	// which probes the API server port with the protocol the load balancer
+	// forwards. Changes are applied to existing load balancers.
+	// +optional
+	HealthCheck *ClassicELBHealthCheck `json:"healthCheck,omitempty"`
+
+	// CrossZoneLoadBalancing distributes traffic evenly across the control plane
+	// instances in all availability zones. Defaults to true for new load
+	// balancers, existing ones are only changed when it's set.
+	// +optional
+	CrossZoneLoadBalancing *bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// ConnectionDraining lets in-flight requests complete before a control plane
+	// instance is removed from the load balancer. Defaults to enabled with a
+	// 300 second timeout.
+	// +optional
+	ConnectionDraining *ClassicELBConnectionDraining `json:"connectionDraining,omitempty"`
+}
+
+// APIServerDNSSpec defines a Route53 record for the control plane endpoint.
+type APIServerDNSSpec struct {
+	// HostedZoneID is the ID of the Route53 hosted zone the record is created in.
+	HostedZoneID string `json:"hostedZoneID"`
+
+	// RecordName is the fully qualified name of the record, e.g.
+	// api.my-cluster.example.com. It must be within the hosted zone.
+	RecordName string `json:"recordName"`
+}
+
+// MaintenanceWindow is a recurring window of time, in UTC.
+type MaintenanceWindow struct {
+	// Start is the time of day the window starts at, in the HH:MM format.
+	Start string `json:"start"`
+
+	// Duration is how long the window lasts, e.g. 4h.
+	Duration metav1.Duration `json:"duration"`
+
+	// Days are the days of the week the window starts on, e.g. Saturday. If
+	// unspecified, the window starts every day.
+	// +optional
+	Days []string `json:"days,omitempty"`
 }
 
 // KeyPair is how operators can supply custom keypairs for kubeadm to use.
@@ -36,8 +36,16 @@ type AWSMachineProviderSpec struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// AMI is the reference to the AMI from which to create the machine instance.
+	// When ID is not set, Filters can be used to look the image up by name, owner-id,
+	// tags or any other DescribeImages filter; the most recent matching image is used.
 	AMI AWSResourceReference `json:"ami,omitempty"`
 
+	// AMISSMParameter is the name of an SSM parameter, such as one of the public
+	// parameters published by AWS, whose value is the ID of the AMI to use.
+	// It is only used when AMI.ID is not set.
+	// +optional
+	AMISSMParameter string `json:"amiSSMParameter,omitempty"`
+
 	// ImageLookupOrg is the AWS Organization ID to use for image lookup if AMI is not set.
 	ImageLookupOrg string `json:"imageLookupOrg,omitempty"`
 
@@ -51,26 +59,170 @@ type AWSMachineProviderSpec struct {
 	// +optional
 	AdditionalTags map[string]string `json:"additionalTags,omitempty"`
 
-	// IAMInstanceProfile is a name of an IAM instance profile to assign to the instance
+	// ComplianceScope is the compliance framework scope (e.g. PCI or HIPAA)
+	// the instance belongs to. It is applied as an instance tag so that
+	// compliance tooling can identify in-scope nodes.
+	// +optional
+	ComplianceScope *string `json:"complianceScope,omitempty"`
+
+	// IAMInstanceProfile is the name or the ARN of an IAM instance profile to assign to the instance.
+	// An ARN is required for instance profiles created under a path other than "/".
+	// Clearing it doesn't disassociate the instance profile from an existing
+	// instance, which is left unchanged.
 	// +optional
 	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
 
+	// CapacityReservationID is the ID of a capacity reservation to launch the
+	// instance into. The capacity reservation must be in the availability zone
+	// of the instance's subnet.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// CapacityReservationResourceGroupARN is the ARN of a capacity reservation
+	// resource group to launch the instance into, drawing from any of the
+	// capacity reservations in the group. It cannot be set together with
+	// CapacityReservationID.
+	// +optional
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupARN,omitempty"`
+
+	// CapacityReservationPreference is the capacity reservation preference of
+	// the instance when no capacity reservation or capacity reservation
+	// resource group is targeted: "open" to run in any
+	// open capacity reservation with matching attributes, or "none" to avoid them.
+	// +optional
+	CapacityReservationPreference *string `json:"capacityReservationPreference,omitempty"`
+
+	// HostResourceGroup is the name of a group of dedicated hosts, tagged with
+	// "sigs.k8s.io/cluster-api-provider-aws/host-resource-group", to launch the
+	// instance on. The available host with the most capacity for the instance
+	// type is picked; if none has capacity, the instance is launched on a dedicated
+	// host using auto-placement.
+	// +optional
+	HostResourceGroup *string `json:"hostResourceGroup,omitempty"`
+
+	// Tenancy is the tenancy of the instance: "default", "dedicated" to run on
+	// single-tenant hardware, or "host" to run on a dedicated host. It cannot be
+	// changed once the instance is launched.
+	// +optional
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// HostID is the ID of the dedicated host to launch the instance on. It can
+	// only be set when Tenancy is "host".
+	// +optional
+	HostID *string `json:"hostID,omitempty"`
+
+	// Affinity is the affinity of the instance with its dedicated host:
+	// "default" to let it move to another host when restarted, or "host" to
+	// keep it on the same one. It can only be set when Tenancy is "host".
+	// +optional
+	Affinity string `json:"affinity,omitempty"`
+
+	// InstanceInitiatedShutdownBehavior is what happens to the instance when it
+	// is shut down from the operating system: "stop" or "terminate". Deleting
+	// the machine always terminates the instance. Defaults to "terminate".
+	// +optional
+	InstanceInitiatedShutdownBehavior *string `json:"instanceInitiatedShutdownBehavior,omitempty"`
+
+	// HibernationOptions enables hibernation of the instance, so it can be
+	// hibernated and resumed with the "aws.cluster.sigs.k8s.io/hibernate"
+	// annotation. The root volume must be encrypted, either through
+	// RootVolumeEncrypted or RootVolumeKMSKeyID, or by the AMI. It cannot be
+	// changed once the instance is launched.
+	// +optional
+	HibernationOptions *HibernationOptions `json:"hibernationOptions,omitempty"`
+
+	// StartStoppedInstance makes the actuator start the instance again if it is
+	// found stopped, rather than considering the machine gone and launching a
+	// replacement. Instances of machines annotated for hibernation are left
+	// stopped. Off by default, as instances may be stopped on purpose.
+	// +optional
+	StartStoppedInstance *bool `json:"startStoppedInstance,omitempty"`
+
+	// CreditSpecification is the credit option for CPU usage of burstable
+	// performance (T-series) instances, either "standard" or "unlimited".
+	// It can be changed on a running instance, and must not be set for other
+	// instance families.
+	// +optional
+	CreditSpecification *string `json:"creditSpecification,omitempty"`
+
+	// Monitoring specifies whether detailed CloudWatch monitoring, which provides
+	// metrics in 1-minute periods, is enabled for the instance. It can be changed
+	// on a running instance.
+	// +optional
+	Monitoring *bool `json:"monitoring,omitempty"`
+
+	// SourceDestCheck specifies whether the instance only sends and receives
+	// traffic addressed to itself. It must be disabled for instances that route
+	// traffic, like NAT instances or some CNI setups. It can be changed on a
+	// running instance. Defaults to the AWS default, enabled.
+	// +optional
+	SourceDestCheck *bool `json:"sourceDestCheck,omitempty"`
+
+	// InstanceMetadataOptions configures the instance metadata service of the
+	// instance. Requiring session tokens (IMDSv2) is applied to running
+	// instances that were launched with optional tokens, without replacing
+	// them.
+	// +optional
+	InstanceMetadataOptions *InstanceMetadataOptions `json:"instanceMetadataOptions,omitempty"`
+
+	// SecondaryPrivateIPCount is the number of secondary private IPv4 addresses
+	// to assign to the primary network interface of the instance at launch. The
+	// addresses are picked by AWS from the subnet of the instance.
+	// +optional
+	SecondaryPrivateIPCount *int64 `json:"secondaryPrivateIPCount,omitempty"`
+
+	// SecondaryPrivateIPs are explicit secondary private IPv4 addresses to
+	// assign to the primary network interface of the instance at launch, for
+	// example for pod IPs or virtual IPs. They must be within the CIDR block of
+	// the subnet of the instance. Mutually exclusive with SecondaryPrivateIPCount.
+	// +optional
+	SecondaryPrivateIPs []string `json:"secondaryPrivateIPs,omitempty"`
+
 	// PublicIP specifies whether the instance should get a public IP.
 	// Precedence for this setting is as follows:
 	// 1. This field if set
 	// 2. Cluster/flavor setting
 	// 3. Subnet default
+	// Instances requesting a public IP are only launched in subnets routing to
+	// an internet gateway.
 	// +optional
 	PublicIP *bool `json:"publicIP,omitempty"`
 
 	// AdditionalSecurityGroups is an array of references to security groups that should be applied to the
 	// instance. These security groups would be set in addition to any security groups defined
-	// at the cluster level or in the actuator.
+	// at the cluster level or in the actuator. Each reference may use an ID, an ARN or filters.
+	// Security groups removed from this list are detached from the instance, while
+	// security groups attached out of band are left alone.
 	// +optional
 	AdditionalSecurityGroups []AWSResourceReference `json:"additionalSecurityGroups,omitempty"`
 
+	// TargetGroupARNs are the ARNs of network or application load balancer
+	// target groups of type "instance" to register the instance with, e.g. to
+	// serve ingress traffic from worker machines. The instance is registered on
+	// the port of each target group, deregistered from target groups that are
+	// removed from the list, and deregistered from all of them when the machine
+	// is deleted.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// AdditionalNetworkInterfaces is a list of network interfaces to create and
+	// attach to the instance at launch, in addition to the primary one. Their
+	// subnets must be in the availability zone of the instance.
+	// +optional
+	AdditionalNetworkInterfaces []NetworkInterfaceSpec `json:"additionalNetworkInterfaces,omitempty"`
+
+	// PrimaryNetworkInterfaceDeleteOnTermination specifies whether the primary
+	// network interface of the instance is deleted when the instance is
+	// terminated, it defaults to true. Setting it to false keeps the network
+	// interface, e.g. to reuse it, when the instance is terminated outside of
+	// the actuator; it is still deleted once the machine is deleted.
+	// +optional
+	PrimaryNetworkInterfaceDeleteOnTermination *bool `json:"primaryNetworkInterfaceDeleteOnTermination,omitempty"`
+
 	// AvailabilityZone is references the AWS availability zone to use for this instance.
 	// If multiple subnets are matched for the availability zone, the first one return is picked.
+	// It can be a local zone, e.g. "us-west-2-lax-1a", whose subnets are not picked otherwise.
+	// Instances launched in a local zone get a gp2 root volume.
 	// +optional
 	AvailabilityZone *string `json:"availabilityZone,omitempty"`
 
@@ -79,14 +231,81 @@ type AWSMachineProviderSpec struct {
 	// +optional
 	Subnet *AWSResourceReference `json:"subnet,omitempty"`
 
+	// Subnets is a list of references to subnets to spread machines across, for
+	// example the replicas of a machine set. Each machine is placed in one of
+	// them by a hash of its name, and stays in the subnet recorded in its status
+	// while it is listed. Only subnet IDs are supported, and it cannot be set
+	// together with Subnet.
+	// +optional
+	Subnets []AWSResourceReference `json:"subnets,omitempty"`
+
 	// KeyName is the name of the SSH key to install on the instance.
 	// +optional
 	KeyName string `json:"keyName,omitempty"`
 
-	// RootDeviceSize is the size of the root volume.
+	// RootDeviceSize is the size of the root volume. It applies to the root
+	// device of the AMI, e.g. /dev/xvda or /dev/sda1, as described by EC2.
 	// +optional
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
 
+	// RootVolumeEncrypted sets whether the root volume is encrypted. When the
+	// root volume snapshot of the AMI is encrypted, the root volume inherits its
+	// encryption and can't be launched unencrypted.
+	// +optional
+	RootVolumeEncrypted *bool `json:"rootVolumeEncrypted,omitempty"`
+
+	// RootVolumeKMSKeyID is the ID or ARN of the KMS key to encrypt the root
+	// volume with. Implies RootVolumeEncrypted. Defaults to the key of the root
+	// volume snapshot of the AMI if it's encrypted, or else the default EBS key.
+	// +optional
+	RootVolumeKMSKeyID *string `json:"rootVolumeKMSKeyID,omitempty"`
+
+	// EphemeralVolumes maps instance store volumes of the instance type to
+	// devices at launch, e.g. for scratch space. Instance store volumes are
+	// ephemeral, so changes only apply to instances launched afterwards and
+	// existing machines aren't considered outdated.
+	// +optional
+	EphemeralVolumes []EphemeralVolume `json:"ephemeralVolumes,omitempty"`
+
+	// ElasticInferenceAccelerators are attached to the instance at launch to
+	// accelerate inference workloads. They can't be attached to accelerated
+	// computing instances, such as GPU or Inferentia ones, or bare metal
+	// instances.
+	// +optional
+	ElasticInferenceAccelerators []ElasticInferenceAccelerator `json:"elasticInferenceAccelerators,omitempty"`
+
+	// LaunchTimeout is the maximum amount of time the instance can remain pending
+	// after it has been launched. Once exceeded, the machine is marked as failed.
+	// If not set, the instance can remain pending indefinitely.
+	// +optional
+	LaunchTimeout *metav1.Duration `json:"launchTimeout,omitempty"`
+
+	// ResourceCreationTimeout bounds how long the actuator waits for the instance
+	// to be running after launching it, independently of the controller's
+	// reconcile timeout. When the wait expires, the machine is requeued until the
+	// instance is running. If not set, the actuator waits up to a minute and
+	// carries on.
+	// +optional
+	ResourceCreationTimeout *metav1.Duration `json:"resourceCreationTimeout,omitempty"`
+
+	// ValidateLaunch makes the actuator validate the launch parameters with a
+	// dry run before launching the instance. If validation fails, the error is
+	// returned without attempting the launch.
+	// +optional
+	ValidateLaunch *bool `json:"validateLaunch,omitempty"`
+
+	// LifecycleHook references an Auto Scaling lifecycle hook whose lifecycle
+	// action is completed once the instance has bootstrapped and its node has
+	// joined the cluster, letting the Auto Scaling group carry on with it.
+	// +optional
+	LifecycleHook *LifecycleHook `json:"lifecycleHook,omitempty"`
+
+	// NodeNameSource selects what the kubelet node name is derived from.
+	// The name must match what the cloud controller manager expects for the
+	// cluster's DNS setup. Defaults to PrivateDNS.
+	// +optional
+	NodeNameSource NodeNameSource `json:"nodeNameSource,omitempty"`
+
 	// KubeadmConfiguration holds the kubeadm configuration options
 	// +optional
 	KubeadmConfiguration KubeadmConfiguration `json:"kubeadmConfiguration,omitempty"`
@@ -94,6 +313,13 @@ type AWSMachineProviderSpec struct {
 	// AdditionalUserDataFiles specifies extra files to be passed to user_data upon creation.
 	// +optional
 	AdditionalUserDataFiles []userdata.Files `json:"additionalUserDataFiles,omitempty"`
+
+	// AdditionalUserDataParts specifies extra cloud-init parts, e.g. scripts or
+	// cloud-config documents, to run after the bootstrap user data. When set,
+	// the user data is assembled into a MIME multipart document with the
+	// bootstrap user data first, then the parts of the cluster, then these.
+	// +optional
+	AdditionalUserDataParts []userdata.Part `json:"additionalUserDataParts,omitempty"`
 }
 
 // KubeadmConfiguration holds the various configurations that kubeadm uses
@@ -37,10 +37,70 @@ type AWSMachineProviderStatus struct {
 	// +optional
 	InstanceState *InstanceState `json:"instanceState,omitempty"`
 
+	// AMIID is the ID of the AMI the instance was launched from. It is recorded
+	// once the AMI has been resolved so that later lookups stay stable.
+	// +optional
+	AMIID *string `json:"amiID,omitempty"`
+
+	// SubnetID is the ID of the subnet the instance was launched in. It keeps
+	// the subnet picked from the Subnets of the machine configuration stable.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
+
+	// LaunchTime is the time the instance for this machine was launched.
+	// +optional
+	LaunchTime *metav1.Time `json:"launchTime,omitempty"`
+
+	// LaunchGeneration is bumped whenever the machine's instance has to be
+	// launched anew, e.g. after it was terminated. It is part of the client
+	// token the instance is launched with, so that a new launch isn't taken
+	// for a retry of the previous one.
+	// +optional
+	LaunchGeneration int64 `json:"launchGeneration,omitempty"`
+
+	// NetworkInterfaceIDs are the IDs of the network interfaces attached to the
+	// instance, ordered by device index.
+	// +optional
+	NetworkInterfaceIDs []string `json:"networkInterfaceIDs,omitempty"`
+
+	// TargetGroupARNs are the ARNs of the target groups the instance has been
+	// registered with.
+	// +optional
+	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
+
+	// MonitoringState is the state of detailed monitoring for the instance.
+	// +optional
+	MonitoringState *string `json:"monitoringState,omitempty"`
+
+	// HTTPTokens is whether requests to the instance metadata service of the
+	// instance must use session tokens, as last reported by EC2.
+	// +optional
+	HTTPTokens *HTTPTokensState `json:"httpTokens,omitempty"`
+
+	// AccountID is the ID of the AWS account the instance was launched in, as
+	// reported by the credentials of the controller.
+	// +optional
+	AccountID *string `json:"accountID,omitempty"`
+
+	// ConsoleOutput is the tail of the console output of the instance, recorded
+	// when it exceeded its launch timeout if the controller is configured to.
+	// +optional
+	ConsoleOutput *string `json:"consoleOutput,omitempty"`
+
 	// Conditions is a set of conditions associated with the Machine to indicate
 	// errors or other status
 	// +optional
 	Conditions []AWSMachineProviderCondition `json:"conditions,omitempty"`
+
+	// FailureReason is set when the machine has a terminal problem reconciling
+	// the instance, and can be used to programmatically interpret the failure.
+	// +optional
+	FailureReason *MachineFailureReason `json:"failureReason,omitempty"`
+
+	// FailureMessage is a human readable description of the terminal problem
+	// reconciling the instance, set along with FailureReason.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -97,6 +97,31 @@ const (
 	// dedicated to this cluster api provider implementation.
 	NameAWSClusterAPIRole = NameAWSProviderPrefix + "role"
 
+	// NameAWSComplianceScope is the tag name we use to mark instances with the
+	// compliance scope (e.g. PCI or HIPAA) they belong to.
+	NameAWSComplianceScope = NameAWSProviderPrefix + "compliance-scope"
+
+	// NameAWSHostResourceGroup is the tag name used on dedicated hosts to group
+	// them into host resource groups instances can be placed on.
+	NameAWSHostResourceGroup = NameAWSProviderPrefix + "host-resource-group"
+
+	// NameAWSVolumeEncrypted is the tag name we use to record on volumes
+	// whether they are encrypted, for compliance audits.
+	NameAWSVolumeEncrypted = NameAWSProviderPrefix + "encrypted"
+
+	// NameAWSVolumeKMSKeyID is the tag name we use to record on encrypted
+	// volumes the KMS key they are encrypted with.
+	NameAWSVolumeKMSKeyID = NameAWSProviderPrefix + "kms-key-id"
+
+	// NameAWSProviderSpecHash is the tag name we use to record on instances
+	// the hash of the machine provider spec they were launched with.
+	NameAWSProviderSpecHash = NameAWSProviderPrefix + "spec-hash"
+
+	// NameAWSClusterAPIContract is the tag name we use to record on instances
+	// the cluster-api contract version, e.g. "cluster.k8s.io/v1alpha1", of the
+	// provider that launched them.
+	NameAWSClusterAPIContract = NameAWSProviderPrefix + "contract-version"
+
 	// APIServerRoleTagValue describes the value for the apiserver role
 	APIServerRoleTagValue = "apiserver"
 
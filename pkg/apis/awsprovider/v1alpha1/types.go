@@ -53,6 +53,89 @@ type Filter struct {
 	Values []string `json:"values"`
 }
 
+// NetworkInterfaceSpec defines a network interface to attach to an instance at launch.
+type NetworkInterfaceSpec struct {
+	// DeviceIndex is the position of the network interface in the attachment order.
+	// The primary network interface has index 0, so it must be at least 1.
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// SubnetID is the ID of the subnet to create the network interface in.
+	SubnetID string `json:"subnetID"`
+
+	// SecurityGroupIDs are the IDs of the security groups of the network interface.
+	// If empty, the default security group of the VPC is used.
+	// +optional
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	// DeleteOnTermination specifies whether the network interface is deleted when
+	// the instance is terminated, it defaults to true. Network interfaces that
+	// aren't are deleted by the actuator once the instance is gone.
+	// +optional
+	DeleteOnTermination *bool `json:"deleteOnTermination,omitempty"`
+}
+
+// ServiceEndpoint overrides the endpoint of an AWS service.
+type ServiceEndpoint struct {
+	// ServiceID is the ID the endpoint of the service is looked up with,
+	// one of "ec2", "elasticloadbalancing", "autoscaling" or "ssm".
+	ServiceID string `json:"serviceID"`
+
+	// URL is the URL of the endpoint, e.g. "http://localhost:4566".
+	URL string `json:"url"`
+
+	// SigningRegion is the region requests to the endpoint are signed for.
+	// Defaults to the region of the cluster.
+	// +optional
+	SigningRegion string `json:"signingRegion,omitempty"`
+}
+
+// EphemeralVolume maps an instance store volume of the instance to a device.
+type EphemeralVolume struct {
+	// VirtualName is the name of the instance store volume, from "ephemeral0"
+	// up to "ephemeral23", numbered in the order the instance type provides them.
+	VirtualName string `json:"virtualName"`
+
+	// DeviceName is the name of the device the volume is mapped to, e.g. "/dev/sdb".
+	// If not set, the first device from "/dev/sdb" onwards that is neither mapped
+	// by the AMI nor by another volume is used, in the order the volumes are listed.
+	// +optional
+	DeviceName string `json:"deviceName,omitempty"`
+}
+
+// ElasticInferenceAccelerator requests Elastic Inference accelerators to
+// attach to the instance at launch.
+type ElasticInferenceAccelerator struct {
+	// Type is the type of the accelerators, e.g. "eia1.medium".
+	Type string `json:"type"`
+
+	// Count is the number of accelerators of the type to attach, it defaults to 1.
+	// +optional
+	Count int64 `json:"count,omitempty"`
+}
+
+// LifecycleHook references an Auto Scaling lifecycle hook that holds the
+// instance of a machine in a wait state until the actuator completes its
+// lifecycle action.
+type LifecycleHook struct {
+	// AutoScalingGroupName is the name of the Auto Scaling group of the hook.
+	AutoScalingGroupName string `json:"autoScalingGroupName"`
+
+	// Name is the name of the lifecycle hook.
+	Name string `json:"name"`
+
+	// Result is the action the Auto Scaling group takes once the lifecycle
+	// action is completed, either "CONTINUE" or "ABANDON". Defaults to "CONTINUE".
+	// +optional
+	Result string `json:"result,omitempty"`
+}
+
+// HibernationOptions configures hibernation of the instance of a machine.
+type HibernationOptions struct {
+	// Configured enables hibernation of the instance. It requires an encrypted
+	// root volume, and an instance type and AMI that support hibernation.
+	Configured bool `json:"configured"`
+}
+
 // AWSMachineProviderConditionType is a valid value for AWSMachineProviderCondition.Type
 type AWSMachineProviderConditionType string
 
@@ -61,6 +144,42 @@ const (
 	// MachineCreated indicates whether the machine has been created or not. If not,
 	// it should include a reason and message for the failure.
 	MachineCreated AWSMachineProviderConditionType = "MachineCreated"
+
+	// MachineSpecDrifted indicates whether the machine spec has changed since the
+	// instance was launched in a way that requires the instance to be replaced.
+	MachineSpecDrifted AWSMachineProviderConditionType = "MachineSpecDrifted"
+
+	// MachineImmutableFieldsChanged indicates whether the machine spec attempts to
+	// change fields of the instance that cannot be mutated. If so, its message
+	// lists the violated fields.
+	MachineImmutableFieldsChanged AWSMachineProviderConditionType = "MachineImmutableFieldsChanged"
+)
+
+// MachineFailureReason is a valid value for AWSMachineProviderStatus.FailureReason
+type MachineFailureReason string
+
+// Valid failure reasons for an AWS machine instance
+const (
+	// CreateErrorMachineFailure indicates that AWS rejected the request to create the instance.
+	CreateErrorMachineFailure MachineFailureReason = "CreateError"
+
+	// InsufficientCapacityMachineFailure indicates that AWS did not have enough capacity,
+	// or the account reached its limits, to create the instance.
+	InsufficientCapacityMachineFailure MachineFailureReason = "InsufficientCapacity"
+
+	// UnauthorizedMachineFailure indicates that the controller is not authorized to create the instance.
+	UnauthorizedMachineFailure MachineFailureReason = "Unauthorized"
+
+	// CapacityReservationMachineFailure indicates that the instance could not be launched
+	// into the targeted capacity reservation, e.g. because it is full or in another zone.
+	CapacityReservationMachineFailure MachineFailureReason = "CapacityReservation"
+
+	// NoSubnetsMachineFailure indicates that no subnet could be found to launch the instance in.
+	NoSubnetsMachineFailure MachineFailureReason = "NoSubnets"
+
+	// NoControlPlaneMachineFailure indicates that no control plane machine was
+	// created for the cluster within the maximum wait.
+	NoControlPlaneMachineFailure MachineFailureReason = "NoControlPlane"
 )
 
 // AWSMachineProviderCondition is a condition in a AWSMachineProviderStatus
@@ -105,6 +224,42 @@ var (
 	ClassicELBSchemeInternal = ClassicELBScheme("internal")
 )
 
+// NodeNameSource defines what the kubelet node name of a machine is derived from.
+type NodeNameSource string
+
+var (
+	// NodeNameSourcePrivateDNS names the node after the private DNS name
+	// reported by the instance metadata service.
+	NodeNameSourcePrivateDNS = NodeNameSource("PrivateDNS")
+
+	// NodeNameSourceInstanceID names the node after the instance ID, the
+	// last segment of the node's provider ID.
+	NodeNameSourceInstanceID = NodeNameSource("InstanceID")
+)
+
+// HTTPTokensState defines whether requests to the instance metadata service
+// must use session tokens.
+type HTTPTokensState string
+
+var (
+	// HTTPTokensStateOptional allows requests to the instance metadata service
+	// with or without session tokens, i.e. IMDSv1 and IMDSv2.
+	HTTPTokensStateOptional = HTTPTokensState("optional")
+
+	// HTTPTokensStateRequired only allows requests to the instance metadata
+	// service with session tokens, i.e. IMDSv2.
+	HTTPTokensStateRequired = HTTPTokensState("required")
+)
+
+// InstanceMetadataOptions describes the options of the instance metadata service
+// of an instance.
+type InstanceMetadataOptions struct {
+	// HTTPTokens defines whether requests to the instance metadata service must
+	// use session tokens, either optional or required. Defaults to optional.
+	// +optional
+	HTTPTokens HTTPTokensState `json:"httpTokens,omitempty"`
+}
+
 // ClassicELBProtocol defines listener protocols for a classic load balancer.
 type ClassicELBProtocol string
 
@@ -131,6 +286,10 @@ type ClassicELB struct {
 	// DNSName is the dns name of the load balancer.
 	DNSName string `json:"dnsName,omitempty"`
 
+	// CanonicalHostedZoneID is the ID of the Route53 hosted zone of the load
+	// balancer, which alias records pointing at it refer to.
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneID,omitempty"`
+
 	// Scheme is the load balancer scheme, either internet-facing or private.
 	Scheme ClassicELBScheme `json:"scheme,omitempty"`
 
@@ -158,6 +317,23 @@ type ClassicELBAttributes struct {
 	// IdleTimeout is time that the connection is allowed to be idle (no data
 	// has been sent over the connection) before it is closed by the load balancer.
 	IdleTimeout time.Duration `json:"idleTimeout,omitempty"`
+
+	// CrossZoneLoadBalancing enables the classic ELB cross availability zone balancing.
+	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// ConnectionDraining keeps existing connections open to instances that are
+	// deregistered or become unhealthy.
+	ConnectionDraining ClassicELBConnectionDraining `json:"connectionDraining,omitempty"`
+}
+
+// ClassicELBConnectionDraining defines the connection draining settings of a classic load balancer.
+type ClassicELBConnectionDraining struct {
+	// Enabled turns connection draining on.
+	Enabled bool `json:"enabled"`
+
+	// Timeout is the maximum time to keep existing connections open before
+	// deregistering an instance.
+	Timeout time.Duration `json:"timeout,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -166,6 +342,9 @@ type ClassicELBListener struct {
 	Port             int64              `json:"port"`
 	InstanceProtocol ClassicELBProtocol `json:"instanceProtocol"`
 	InstancePort     int64              `json:"instancePort"`
+
+	// SSLCertificateID is the ARN of the certificate used by SSL and HTTPS listeners.
+	SSLCertificateID string `json:"sslCertificateId,omitempty"`
 }
 
 // ClassicELBHealthCheck defines an AWS classic load balancer health check.
@@ -395,6 +574,20 @@ var (
 	InstanceStateStopped = InstanceState("stopped")
 )
 
+// Volume describes an EBS volume attached to an instance.
+type Volume struct {
+	ID string `json:"id"`
+
+	// Encrypted is true if the volume is encrypted.
+	Encrypted bool `json:"encrypted"`
+
+	// KMSKeyID is the ARN of the KMS key the volume is encrypted with, if any.
+	KMSKeyID *string `json:"kmsKeyId,omitempty"`
+
+	// The tags associated with the volume.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
 // Instance describes an AWS instance.
 type Instance struct {
 	ID string `json:"id"`
@@ -408,6 +601,9 @@ type Instance struct {
 	// The ID of the subnet of the instance.
 	SubnetID string `json:"subnetId,omitempty"`
 
+	// The availability zone of the instance.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
 	// The ID of the AMI used to launch the instance.
 	ImageID string `json:"imageId,omitempty"`
 
@@ -427,6 +623,32 @@ type Instance struct {
 	// The private IPv4 address assigned to the instance.
 	PrivateIP *string `json:"privateIp,omitempty"`
 
+	// The number of secondary private IPv4 addresses to assign to the primary
+	// network interface of the instance.
+	SecondaryPrivateIPCount *int64 `json:"secondaryPrivateIPCount,omitempty"`
+
+	// The secondary private IPv4 addresses assigned to the primary network
+	// interface of the instance, if any.
+	SecondaryPrivateIPs []string `json:"secondaryPrivateIPs,omitempty"`
+
+	// The network interfaces to attach to the instance at launch, in addition
+	// to the primary one.
+	AdditionalNetworkInterfaces []NetworkInterfaceSpec `json:"additionalNetworkInterfaces,omitempty"`
+
+	// Whether the primary network interface is deleted when the instance is
+	// terminated, if set at launch.
+	PrimaryNetworkInterfaceDeleteOnTermination *bool `json:"primaryNetworkInterfaceDeleteOnTermination,omitempty"`
+
+	// The instance store volumes to map at launch.
+	EphemeralVolumes []EphemeralVolume `json:"ephemeralVolumes,omitempty"`
+
+	// The Elastic Inference accelerators to attach at launch.
+	ElasticInferenceAccelerators []ElasticInferenceAccelerator `json:"elasticInferenceAccelerators,omitempty"`
+
+	// The IDs of the network interfaces attached to the instance, ordered by
+	// device index.
+	NetworkInterfaceIDs []string `json:"networkInterfaceIDs,omitempty"`
+
 	// The public IPv4 address assigned to the instance, if applicable.
 	PublicIP *string `json:"publicIp,omitempty"`
 
@@ -439,12 +661,114 @@ type Instance struct {
 	// Specifies size (in Gi) of the root storage device
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
 
+	// Specifies the volume type of the root storage device, when not the
+	// default of the image.
+	RootDeviceType string `json:"rootDeviceType,omitempty"`
+
+	// Specifies whether the root storage device is encrypted, when not
+	// inherited from the image.
+	RootDeviceEncrypted *bool `json:"rootDeviceEncrypted,omitempty"`
+
+	// Specifies the KMS key the root storage device is encrypted with, when not
+	// inherited from the image.
+	RootDeviceKMSKeyID *string `json:"rootDeviceKMSKeyID,omitempty"`
+
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// The time the instance was launched.
+	LaunchTime *metav1.Time `json:"launchTime,omitempty"`
+
+	// The credit option for CPU usage of a burstable performance instance.
+	CPUCredits *string `json:"cpuCredits,omitempty"`
+
+	// Whether requests to the instance metadata service must use session
+	// tokens.
+	HTTPTokens HTTPTokensState `json:"httpTokens,omitempty"`
+
+	// The ID of the capacity reservation the instance is launched into, if applicable.
+	CapacityReservationID *string `json:"capacityReservationID,omitempty"`
+
+	// The ARN of the capacity reservation resource group the instance is launched
+	// into, if applicable.
+	CapacityReservationResourceGroupARN *string `json:"capacityReservationResourceGroupARN,omitempty"`
+
+	// The capacity reservation preference of the instance, either "open" or "none".
+	CapacityReservationPreference *string `json:"capacityReservationPreference,omitempty"`
+
+	// The tenancy of the instance: "default", "dedicated" or "host".
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// What happens to the instance when it is shut down from the operating
+	// system, either "stop" or "terminate".
+	InstanceInitiatedShutdownBehavior *string `json:"instanceInitiatedShutdownBehavior,omitempty"`
+
+	// Specifies whether the instance is enabled for hibernation.
+	HibernationConfigured bool `json:"hibernationConfigured,omitempty"`
+
+	// The ID of the dedicated host the instance is on, if applicable.
+	HostID *string `json:"hostID,omitempty"`
+
+	// The affinity of the instance with its dedicated host, "default" or "host".
+	Affinity string `json:"affinity,omitempty"`
+
+	// Indicates whether detailed monitoring is enabled.
+	Monitoring *bool `json:"monitoring,omitempty"`
+
+	// The state of detailed monitoring for the instance.
+	MonitoringState string `json:"monitoringState,omitempty"`
+
+	// Indicates whether source/destination checking is enabled.
+	SourceDestCheck *bool `json:"sourceDestCheck,omitempty"`
 }
 
 const (
 	AnnotationClusterInfrastructureReady = "aws.cluster.sigs.k8s.io/infrastructure-ready"
 	ValueReady                           = "true"
 	AnnotationControlPlaneReady          = "aws.cluster.sigs.k8s.io/control-plane-ready"
+
+	// AnnotationClientToken can be set on a machine to supply the client token used to
+	// launch its instance idempotently. When absent, a token derived from the machine's
+	// UID and namespaced name is used; an empty value launches without a token. See
+	// https://docs.aws.amazon.com/AWSEC2/latest/APIReference/Run_Instance_Idempotency.html
+	AnnotationClientToken = "aws.cluster.sigs.k8s.io/client-token"
+
+	// AnnotationInstanceID can be set on a machine to import the pre-existing instance
+	// with that ID instead of launching a new one. The instance must be running in the
+	// cluster VPC and must not be owned by a cluster already.
+	AnnotationInstanceID = "cluster-api-provider-aws/instance-id"
+
+	// AnnotationAdoptInstance can be set to "true" on a machine to adopt a pre-existing
+	// instance instead of launching a new one: the instance of the machine's provider ID
+	// or else the only running instance in the cluster VPC whose Name tag is the machine's
+	// name. The instance must not be owned by a cluster already.
+	AnnotationAdoptInstance = "aws.cluster.sigs.k8s.io/adopt-instance"
+
+	// AnnotationLifecycleActionCompleted is set on a machine to the ID of its instance
+	// once the lifecycle action of the machine's lifecycle hook has been completed for it.
+	AnnotationLifecycleActionCompleted = "aws.cluster.sigs.k8s.io/lifecycle-action-completed"
+
+	// AnnotationHibernate can be set to "true" on a machine whose instance has
+	// hibernation configured to hibernate the instance. The instance is resumed
+	// once the annotation is removed or set to any other value.
+	AnnotationHibernate = "aws.cluster.sigs.k8s.io/hibernate"
+
+	// AnnotationSubnetID and AnnotationAvailabilityZone are set on a machine to
+	// the subnet and availability zone its instance was launched in, if the
+	// machine actuator is configured to annotate machines with their placement.
+	AnnotationSubnetID         = "aws.cluster.sigs.k8s.io/subnet-id"
+	AnnotationAvailabilityZone = "aws.cluster.sigs.k8s.io/availability-zone"
+
+	// AnnotationPreTerminateHookPrefix is the prefix of the machine annotations
+	// holding off the termination of a deleted machine's instance. External
+	// controllers set one, named after themselves, to run their cleanup before
+	// the instance goes away, and remove it once done: the instance is only
+	// deregistered and terminated when no annotation with the prefix is left.
+	AnnotationPreTerminateHookPrefix = "pre-terminate.delete.hook.machine.aws.cluster.sigs.k8s.io/"
+
+	// MachineFinalizer is added to machines before their instance is launched,
+	// and to machines launched without it on their next update.
+	// It is only removed once the machine's instances are confirmed to be gone,
+	// so a machine deleted while the controller is down doesn't leak them.
+	MachineFinalizer = "machine.aws.cluster.sigs.k8s.io"
 )
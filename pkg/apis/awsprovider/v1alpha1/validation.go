@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	tenancyDefault   = "default"
+	tenancyDedicated = "dedicated"
+	tenancyHost      = "host"
+
+	affinityDefault = "default"
+	affinityHost    = "host"
+)
+
+// Validate returns the errors of the machine provider spec. Only the placement
+// of the instance is validated for now: the affinity and a dedicated host ID
+// require tenancy "host", which a host resource group implies if no tenancy is
+// set, and a host ID and a host resource group are mutually exclusive. The
+// subnets to spread machines across must be given by ID, without a subnet.
+// A capacity reservation and a capacity reservation resource group are
+// mutually exclusive too, and the instance metadata HTTP tokens must be
+// optional or required.
+func (s *AWSMachineProviderSpec) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	switch s.Tenancy {
+	case "", tenancyDefault, tenancyDedicated, tenancyHost:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("tenancy"), s.Tenancy, []string{tenancyDefault, tenancyDedicated, tenancyHost}))
+	}
+
+	// A host resource group implies tenancy "host" if no tenancy is set.
+	hostTenancy := s.Tenancy == tenancyHost || (s.Tenancy == "" && s.HostResourceGroup != nil)
+
+	switch s.Affinity {
+	case "":
+	case affinityDefault, affinityHost:
+		if !hostTenancy {
+			errs = append(errs, field.Invalid(field.NewPath("affinity"), s.Affinity, "can only be set with tenancy \"host\""))
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("affinity"), s.Affinity, []string{affinityDefault, affinityHost}))
+	}
+
+	if s.HostID != nil && s.Tenancy != tenancyHost {
+		errs = append(errs, field.Invalid(field.NewPath("hostID"), *s.HostID, "can only be set with tenancy \"host\""))
+	}
+
+	if s.HostResourceGroup != nil {
+		if s.Tenancy != "" && s.Tenancy != tenancyHost {
+			errs = append(errs, field.Invalid(field.NewPath("hostResourceGroup"), *s.HostResourceGroup, "can only be set with tenancy \"host\""))
+		}
+		if s.HostID != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("hostResourceGroup"), "cannot be set together with hostID"))
+		}
+	}
+
+	if s.CapacityReservationResourceGroupARN != nil && s.CapacityReservationID != nil {
+		errs = append(errs, field.Forbidden(field.NewPath("capacityReservationResourceGroupARN"), "cannot be set together with capacityReservationID"))
+	}
+
+	if s.InstanceMetadataOptions != nil {
+		switch s.InstanceMetadataOptions.HTTPTokens {
+		case "", HTTPTokensStateOptional, HTTPTokensStateRequired:
+		default:
+			errs = append(errs, field.NotSupported(field.NewPath("instanceMetadataOptions", "httpTokens"), s.InstanceMetadataOptions.HTTPTokens,
+				[]string{string(HTTPTokensStateOptional), string(HTTPTokensStateRequired)}))
+		}
+	}
+
+	if len(s.Subnets) > 0 {
+		if s.Subnet != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("subnets"), "cannot be set together with subnet"))
+		}
+		for i, ref := range s.Subnets {
+			if ref.ID == nil {
+				errs = append(errs, field.Required(field.NewPath("subnets").Index(i).Child("id"), "only subnet IDs are supported"))
+			}
+		}
+	}
+
+	return errs
+}
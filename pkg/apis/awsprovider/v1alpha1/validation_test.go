@@ -0,0 +1,158 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestAWSMachineProviderSpecValidate(t *testing.T) {
+	hostID := "h-0123456789abcdef0"
+	group := "group"
+	subnet := "subnet-0123456789abcdef0"
+	reservation := "cr-0123456789abcdef0"
+	reservationGroup := "arn:aws:resource-groups:us-east-1:123456789012:group/reservations"
+
+	testCases := []struct {
+		name         string
+		spec         AWSMachineProviderSpec
+		expectErrors int
+	}{
+		{
+			name: "no placement",
+		},
+		{
+			name: "default tenancy",
+			spec: AWSMachineProviderSpec{Tenancy: "default"},
+		},
+		{
+			name: "dedicated tenancy",
+			spec: AWSMachineProviderSpec{Tenancy: "dedicated"},
+		},
+		{
+			name: "host tenancy",
+			spec: AWSMachineProviderSpec{Tenancy: "host"},
+		},
+		{
+			name: "host tenancy with a host id and host affinity",
+			spec: AWSMachineProviderSpec{Tenancy: "host", HostID: &hostID, Affinity: "host"},
+		},
+		{
+			name: "host tenancy with default affinity",
+			spec: AWSMachineProviderSpec{Tenancy: "host", Affinity: "default"},
+		},
+		{
+			name: "host resource group without a tenancy and with host affinity",
+			spec: AWSMachineProviderSpec{HostResourceGroup: &group, Affinity: "host"},
+		},
+		{
+			name: "host resource group with host tenancy",
+			spec: AWSMachineProviderSpec{Tenancy: "host", HostResourceGroup: &group},
+		},
+		{
+			name:         "invalid tenancy",
+			spec:         AWSMachineProviderSpec{Tenancy: "shared"},
+			expectErrors: 1,
+		},
+		{
+			name:         "invalid affinity",
+			spec:         AWSMachineProviderSpec{Tenancy: "host", Affinity: "sticky"},
+			expectErrors: 1,
+		},
+		{
+			name:         "host affinity without a tenancy",
+			spec:         AWSMachineProviderSpec{Affinity: "host"},
+			expectErrors: 1,
+		},
+		{
+			name:         "host affinity with dedicated tenancy",
+			spec:         AWSMachineProviderSpec{Tenancy: "dedicated", Affinity: "host"},
+			expectErrors: 1,
+		},
+		{
+			name:         "default affinity with default tenancy",
+			spec:         AWSMachineProviderSpec{Tenancy: "default", Affinity: "default"},
+			expectErrors: 1,
+		},
+		{
+			name:         "host id without a tenancy",
+			spec:         AWSMachineProviderSpec{HostID: &hostID},
+			expectErrors: 1,
+		},
+		{
+			name:         "host id with dedicated tenancy",
+			spec:         AWSMachineProviderSpec{Tenancy: "dedicated", HostID: &hostID},
+			expectErrors: 1,
+		},
+		{
+			name:         "host resource group with dedicated tenancy",
+			spec:         AWSMachineProviderSpec{Tenancy: "dedicated", HostResourceGroup: &group},
+			expectErrors: 1,
+		},
+		{
+			name:         "host id with a host resource group",
+			spec:         AWSMachineProviderSpec{Tenancy: "host", HostID: &hostID, HostResourceGroup: &group},
+			expectErrors: 1,
+		},
+		{
+			name: "subnets by id",
+			spec: AWSMachineProviderSpec{Subnets: []AWSResourceReference{{ID: &subnet}}},
+		},
+		{
+			name:         "subnets with a subnet",
+			spec:         AWSMachineProviderSpec{Subnet: &AWSResourceReference{ID: &subnet}, Subnets: []AWSResourceReference{{ID: &subnet}}},
+			expectErrors: 1,
+		},
+		{
+			name:         "subnets by filters",
+			spec:         AWSMachineProviderSpec{Subnets: []AWSResourceReference{{ID: &subnet}, {Filters: []Filter{{Name: "tag:Name", Values: []string{"private"}}}}}},
+			expectErrors: 1,
+		},
+		{
+			name: "capacity reservation resource group",
+			spec: AWSMachineProviderSpec{CapacityReservationResourceGroupARN: &reservationGroup},
+		},
+		{
+			name:         "capacity reservation resource group with a capacity reservation",
+			spec:         AWSMachineProviderSpec{CapacityReservationID: &reservation, CapacityReservationResourceGroupARN: &reservationGroup},
+			expectErrors: 1,
+		},
+		{
+			name: "required instance metadata tokens",
+			spec: AWSMachineProviderSpec{InstanceMetadataOptions: &InstanceMetadataOptions{HTTPTokens: HTTPTokensStateRequired}},
+		},
+		{
+			name:         "unsupported instance metadata tokens",
+			spec:         AWSMachineProviderSpec{InstanceMetadataOptions: &InstanceMetadataOptions{HTTPTokens: "v2"}},
+			expectErrors: 1,
+		},
+		{
+			name:         "errors are aggregated",
+			spec:         AWSMachineProviderSpec{Tenancy: "dedicated", HostID: &hostID, Affinity: "host", HostResourceGroup: &group},
+			expectErrors: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.spec.Validate()
+			if len(errs) != tc.expectErrors {
+				t.Fatalf("expected %d errors, got %v", tc.expectErrors, errs)
+			}
+		})
+	}
+}
@@ -21,16 +21,43 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	userdata "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/userdata"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerDNSSpec) DeepCopyInto(out *APIServerDNSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDNSSpec.
+func (in *APIServerDNSSpec) DeepCopy() *APIServerDNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerDNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSClusterProviderSpec) DeepCopyInto(out *AWSClusterProviderSpec) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.NetworkSpec.DeepCopyInto(&out.NetworkSpec)
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = make([]ServiceEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreateSSHKeyPairs != nil {
+		in, out := &in.CreateSSHKeyPairs, &out.CreateSSHKeyPairs
+		*out = new(bool)
+		**out = **in
+	}
 	in.CAKeyPair.DeepCopyInto(&out.CAKeyPair)
 	in.EtcdCAKeyPair.DeepCopyInto(&out.EtcdCAKeyPair)
 	in.FrontProxyCAKeyPair.DeepCopyInto(&out.FrontProxyCAKeyPair)
@@ -41,6 +68,26 @@ func (in *AWSClusterProviderSpec) DeepCopyInto(out *AWSClusterProviderSpec) {
 		*out = make([]userdata.Files, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalUserDataParts != nil {
+		in, out := &in.AdditionalUserDataParts, &out.AdditionalUserDataParts
+		*out = make([]userdata.Part, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneLoadBalancer != nil {
+		in, out := &in.ControlPlaneLoadBalancer, &out.ControlPlaneLoadBalancer
+		*out = new(AWSLoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerDNS != nil {
+		in, out := &in.APIServerDNS, &out.APIServerDNS
+		*out = new(APIServerDNSSpec)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -90,6 +137,52 @@ func (in *AWSClusterProviderStatus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
+	*out = *in
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(ClassicELBScheme)
+		**out = **in
+	}
+	if in.CertificateARN != nil {
+		in, out := &in.CertificateARN, &out.CertificateARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ListenerProtocol != nil {
+		in, out := &in.ListenerProtocol, &out.ListenerProtocol
+		*out = new(ClassicELBProtocol)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(ClassicELBHealthCheck)
+		**out = **in
+	}
+	if in.CrossZoneLoadBalancing != nil {
+		in, out := &in.CrossZoneLoadBalancing, &out.CrossZoneLoadBalancing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(ClassicELBConnectionDraining)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerSpec.
+func (in *AWSLoadBalancerSpec) DeepCopy() *AWSLoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSLoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSMachineProviderCondition) DeepCopyInto(out *AWSMachineProviderCondition) {
 	*out = *in
@@ -121,6 +214,81 @@ func (in *AWSMachineProviderSpec) DeepCopyInto(out *AWSMachineProviderSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ComplianceScope != nil {
+		in, out := &in.ComplianceScope, &out.ComplianceScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationID != nil {
+		in, out := &in.CapacityReservationID, &out.CapacityReservationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationResourceGroupARN != nil {
+		in, out := &in.CapacityReservationResourceGroupARN, &out.CapacityReservationResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationPreference != nil {
+		in, out := &in.CapacityReservationPreference, &out.CapacityReservationPreference
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostResourceGroup != nil {
+		in, out := &in.HostResourceGroup, &out.HostResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceInitiatedShutdownBehavior != nil {
+		in, out := &in.InstanceInitiatedShutdownBehavior, &out.InstanceInitiatedShutdownBehavior
+		*out = new(string)
+		**out = **in
+	}
+	if in.HibernationOptions != nil {
+		in, out := &in.HibernationOptions, &out.HibernationOptions
+		*out = new(HibernationOptions)
+		**out = **in
+	}
+	if in.StartStoppedInstance != nil {
+		in, out := &in.StartStoppedInstance, &out.StartStoppedInstance
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CreditSpecification != nil {
+		in, out := &in.CreditSpecification, &out.CreditSpecification
+		*out = new(string)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SourceDestCheck != nil {
+		in, out := &in.SourceDestCheck, &out.SourceDestCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InstanceMetadataOptions != nil {
+		in, out := &in.InstanceMetadataOptions, &out.InstanceMetadataOptions
+		*out = new(InstanceMetadataOptions)
+		**out = **in
+	}
+	if in.SecondaryPrivateIPCount != nil {
+		in, out := &in.SecondaryPrivateIPCount, &out.SecondaryPrivateIPCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SecondaryPrivateIPs != nil {
+		in, out := &in.SecondaryPrivateIPs, &out.SecondaryPrivateIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PublicIP != nil {
 		in, out := &in.PublicIP, &out.PublicIP
 		*out = new(bool)
@@ -133,6 +301,23 @@ func (in *AWSMachineProviderSpec) DeepCopyInto(out *AWSMachineProviderSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalNetworkInterfaces != nil {
+		in, out := &in.AdditionalNetworkInterfaces, &out.AdditionalNetworkInterfaces
+		*out = make([]NetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrimaryNetworkInterfaceDeleteOnTermination != nil {
+		in, out := &in.PrimaryNetworkInterfaceDeleteOnTermination, &out.PrimaryNetworkInterfaceDeleteOnTermination
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AvailabilityZone != nil {
 		in, out := &in.AvailabilityZone, &out.AvailabilityZone
 		*out = new(string)
@@ -143,12 +328,64 @@ func (in *AWSMachineProviderSpec) DeepCopyInto(out *AWSMachineProviderSpec) {
 		*out = new(AWSResourceReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]AWSResourceReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RootVolumeEncrypted != nil {
+		in, out := &in.RootVolumeEncrypted, &out.RootVolumeEncrypted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RootVolumeKMSKeyID != nil {
+		in, out := &in.RootVolumeKMSKeyID, &out.RootVolumeKMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.EphemeralVolumes != nil {
+		in, out := &in.EphemeralVolumes, &out.EphemeralVolumes
+		*out = make([]EphemeralVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]ElasticInferenceAccelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.LaunchTimeout != nil {
+		in, out := &in.LaunchTimeout, &out.LaunchTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResourceCreationTimeout != nil {
+		in, out := &in.ResourceCreationTimeout, &out.ResourceCreationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ValidateLaunch != nil {
+		in, out := &in.ValidateLaunch, &out.ValidateLaunch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LifecycleHook != nil {
+		in, out := &in.LifecycleHook, &out.LifecycleHook
+		*out = new(LifecycleHook)
+		**out = **in
+	}
 	in.KubeadmConfiguration.DeepCopyInto(&out.KubeadmConfiguration)
 	if in.AdditionalUserDataFiles != nil {
 		in, out := &in.AdditionalUserDataFiles, &out.AdditionalUserDataFiles
 		*out = make([]userdata.Files, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalUserDataParts != nil {
+		in, out := &in.AdditionalUserDataParts, &out.AdditionalUserDataParts
+		*out = make([]userdata.Part, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -185,6 +422,50 @@ func (in *AWSMachineProviderStatus) DeepCopyInto(out *AWSMachineProviderStatus)
 		*out = new(InstanceState)
 		**out = **in
 	}
+	if in.AMIID != nil {
+		in, out := &in.AMIID, &out.AMIID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
+	if in.LaunchTime != nil {
+		in, out := &in.LaunchTime, &out.LaunchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NetworkInterfaceIDs != nil {
+		in, out := &in.NetworkInterfaceIDs, &out.NetworkInterfaceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetGroupARNs != nil {
+		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitoringState != nil {
+		in, out := &in.MonitoringState, &out.MonitoringState
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPTokens != nil {
+		in, out := &in.HTTPTokens, &out.HTTPTokens
+		*out = new(HTTPTokensState)
+		**out = **in
+	}
+	if in.AccountID != nil {
+		in, out := &in.AccountID, &out.AccountID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConsoleOutput != nil {
+		in, out := &in.ConsoleOutput, &out.ConsoleOutput
+		*out = new(string)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]AWSMachineProviderCondition, len(*in))
@@ -192,6 +473,16 @@ func (in *AWSMachineProviderStatus) DeepCopyInto(out *AWSMachineProviderStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(MachineFailureReason)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -332,6 +623,7 @@ func (in *ClassicELB) DeepCopy() *ClassicELB {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELBAttributes) DeepCopyInto(out *ClassicELBAttributes) {
 	*out = *in
+	out.ConnectionDraining = in.ConnectionDraining
 	return
 }
 
@@ -345,6 +637,22 @@ func (in *ClassicELBAttributes) DeepCopy() *ClassicELBAttributes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClassicELBConnectionDraining) DeepCopyInto(out *ClassicELBConnectionDraining) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClassicELBConnectionDraining.
+func (in *ClassicELBConnectionDraining) DeepCopy() *ClassicELBConnectionDraining {
+	if in == nil {
+		return nil
+	}
+	out := new(ClassicELBConnectionDraining)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELBHealthCheck) DeepCopyInto(out *ClassicELBHealthCheck) {
 	*out = *in
@@ -377,6 +685,38 @@ func (in *ClassicELBListener) DeepCopy() *ClassicELBListener {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticInferenceAccelerator) DeepCopyInto(out *ElasticInferenceAccelerator) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticInferenceAccelerator.
+func (in *ElasticInferenceAccelerator) DeepCopy() *ElasticInferenceAccelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticInferenceAccelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralVolume) DeepCopyInto(out *EphemeralVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolume.
+func (in *EphemeralVolume) DeepCopy() *EphemeralVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Filter) DeepCopyInto(out *Filter) {
 	*out = *in
@@ -398,6 +738,22 @@ func (in *Filter) DeepCopy() *Filter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationOptions) DeepCopyInto(out *HibernationOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationOptions.
+func (in *HibernationOptions) DeepCopy() *HibernationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressRule) DeepCopyInto(out *IngressRule) {
 	*out = *in
@@ -473,6 +829,43 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SecondaryPrivateIPCount != nil {
+		in, out := &in.SecondaryPrivateIPCount, &out.SecondaryPrivateIPCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SecondaryPrivateIPs != nil {
+		in, out := &in.SecondaryPrivateIPs, &out.SecondaryPrivateIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalNetworkInterfaces != nil {
+		in, out := &in.AdditionalNetworkInterfaces, &out.AdditionalNetworkInterfaces
+		*out = make([]NetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrimaryNetworkInterfaceDeleteOnTermination != nil {
+		in, out := &in.PrimaryNetworkInterfaceDeleteOnTermination, &out.PrimaryNetworkInterfaceDeleteOnTermination
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EphemeralVolumes != nil {
+		in, out := &in.EphemeralVolumes, &out.EphemeralVolumes
+		*out = make([]EphemeralVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]ElasticInferenceAccelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkInterfaceIDs != nil {
+		in, out := &in.NetworkInterfaceIDs, &out.NetworkInterfaceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PublicIP != nil {
 		in, out := &in.PublicIP, &out.PublicIP
 		*out = new(string)
@@ -488,6 +881,16 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RootDeviceEncrypted != nil {
+		in, out := &in.RootDeviceEncrypted, &out.RootDeviceEncrypted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RootDeviceKMSKeyID != nil {
+		in, out := &in.RootDeviceKMSKeyID, &out.RootDeviceKMSKeyID
+		*out = new(string)
+		**out = **in
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -495,6 +898,50 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 			(*out)[key] = val
 		}
 	}
+	if in.LaunchTime != nil {
+		in, out := &in.LaunchTime, &out.LaunchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CPUCredits != nil {
+		in, out := &in.CPUCredits, &out.CPUCredits
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationID != nil {
+		in, out := &in.CapacityReservationID, &out.CapacityReservationID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationResourceGroupARN != nil {
+		in, out := &in.CapacityReservationResourceGroupARN, &out.CapacityReservationResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.CapacityReservationPreference != nil {
+		in, out := &in.CapacityReservationPreference, &out.CapacityReservationPreference
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceInitiatedShutdownBehavior != nil {
+		in, out := &in.InstanceInitiatedShutdownBehavior, &out.InstanceInitiatedShutdownBehavior
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostID != nil {
+		in, out := &in.HostID, &out.HostID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SourceDestCheck != nil {
+		in, out := &in.SourceDestCheck, &out.SourceDestCheck
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -508,6 +955,22 @@ func (in *Instance) DeepCopy() *Instance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceMetadataOptions) DeepCopyInto(out *InstanceMetadataOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceMetadataOptions.
+func (in *InstanceMetadataOptions) DeepCopy() *InstanceMetadataOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceMetadataOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyPair) DeepCopyInto(out *KeyPair) {
 	*out = *in
@@ -552,6 +1015,44 @@ func (in *KubeadmConfiguration) DeepCopy() *KubeadmConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleHook) DeepCopyInto(out *LifecycleHook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleHook.
+func (in *LifecycleHook) DeepCopy() *LifecycleHook {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -584,6 +1085,32 @@ func (in *Network) DeepCopy() *Network {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterfaceSpec) DeepCopyInto(out *NetworkInterfaceSpec) {
+	*out = *in
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeleteOnTermination != nil {
+		in, out := &in.DeleteOnTermination, &out.DeleteOnTermination
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterfaceSpec.
+func (in *NetworkInterfaceSpec) DeepCopy() *NetworkInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
@@ -662,6 +1189,22 @@ func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceEndpoint) DeepCopyInto(out *ServiceEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpoint.
+func (in *ServiceEndpoint) DeepCopy() *ServiceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -770,3 +1313,31 @@ func (in *VPCSpec) DeepCopy() *VPCSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Volume) DeepCopyInto(out *Volume) {
+	*out = *in
+	if in.KMSKeyID != nil {
+		in, out := &in.KMSKeyID, &out.KMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
+func (in *Volume) DeepCopy() *Volume {
+	if in == nil {
+		return nil
+	}
+	out := new(Volume)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+)
+
+var (
+	accountIDCache sync.Map
+)
+
+// AccountID returns the ID of the AWS account the credentials of the scope
+// belong to. It is resolved with STS once per AWS session, so that scopes
+// sharing a session don't call STS again.
+func (s *Scope) AccountID() (string, error) {
+	if s.session != nil {
+		if id, ok := accountIDCache.Load(s.session); ok {
+			return id.(string), nil
+		}
+	}
+
+	out, err := s.STS.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get caller identity")
+	}
+
+	id := aws.StringValue(out.Account)
+	if s.session != nil {
+		accountIDCache.Store(s.session, id)
+	}
+	return id, nil
+}
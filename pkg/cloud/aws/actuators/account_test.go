@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators/mock_stsiface"
+)
+
+func TestAccountID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	stsMock.EXPECT().
+		GetCallerIdentity(&sts.GetCallerIdentityInput{}).
+		Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil).
+		Times(1)
+
+	// Scopes sharing a session only resolve the account ID once.
+	s := &session.Session{}
+	for i := 0; i < 2; i++ {
+		scope := &Scope{AWSClients: AWSClients{STS: stsMock}, session: s}
+
+		id, err := scope.AccountID()
+		if err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+		if id != "123456789012" {
+			t.Fatalf("expected account ID 123456789012, got %q", id)
+		}
+	}
+}
+
+func TestAccountIDError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+	stsMock.EXPECT().
+		GetCallerIdentity(gomock.Any()).
+		Return(nil, errors.New("access denied")).
+		Times(2)
+
+	// Failures aren't cached.
+	scope := &Scope{AWSClients: AWSClients{STS: stsMock}, session: &session.Session{}}
+	for i := 0; i < 2; i++ {
+		if _, err := scope.AccountID(); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+}
@@ -17,12 +17,22 @@ limitations under the License.
 package actuators
 
 import (
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 )
 
 // AWSClients contains all the aws clients used by the scopes.
 type AWSClients struct {
-	EC2 ec2iface.EC2API
-	ELB elbiface.ELBAPI
+	AutoScaling autoscalingiface.AutoScalingAPI
+	EC2         ec2iface.EC2API
+	ELB         elbiface.ELBAPI
+	ELBV2       elbv2iface.ELBV2API
+	Route53     route53iface.Route53API
+	SSM         ssmiface.SSMAPI
+	STS         stsiface.STSAPI
 }
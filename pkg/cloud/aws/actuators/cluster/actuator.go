@@ -44,7 +44,7 @@ const waitForControlPlaneMachineDuration = 15 * time.Second
 
 //+kubebuilder:rbac:groups=awsprovider.k8s.io,resources=awsclusterproviderconfigs;awsclusterproviderstatuses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=,resources=secrets,verbs=create;get;watch;list
+//+kubebuilder:rbac:groups=,resources=secrets,verbs=create;get;watch;list;delete
 //+kubebuilder:rbac:groups=,resources=configmaps,verbs=create;get;delete
 
 // Actuator is responsible for performing cluster reconciliation
@@ -211,6 +211,10 @@ func (a *Actuator) Delete(cluster *clusterv1.Cluster) error {
 		return errors.Errorf("unable to delete bastion: %+v", err)
 	}
 
+	if err := a.deleteKeyPairs(ec2svc, cluster); err != nil {
+		return errors.Errorf("unable to delete SSH key pairs: %+v", err)
+	}
+
 	if err := ec2svc.DeleteNetwork(); err != nil {
 		a.log.Error(err, "Error deleting cluster", "cluster-name", cluster.Name, "cluster-namespace", cluster.Namespace)
 		return &controllerError.RequeueAfterError{
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+// deleteKeyPairs deletes the SSH key pairs created for the machines of the
+// cluster, along with the secrets holding their private keys.
+func (a *Actuator) deleteKeyPairs(ec2svc *ec2.Service, cluster *clusterv1.Cluster) error {
+	secrets, err := actuators.FindSSHKeyPairSecrets(a.coreClient, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if name := secret.Annotations[actuators.SSHKeyPairNameAnnotation]; name != "" {
+			if err := ec2svc.DeleteKeyPair(name); err != nil {
+				return err
+			}
+		}
+
+		a.log.Info("Deleting SSH key pair secret", "secret-name", secret.Name)
+		if err := a.coreClient.Secrets(cluster.Namespace).Delete(secret.Name, nil); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete SSH key pair secret %q", secret.Name)
+		}
+	}
+
+	return nil
+}
@@ -19,16 +19,50 @@ package actuators
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
+	apicorev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
+// ControlPlaneLockLabelName is the label set on the ConfigMaps used to coordinate control plane nodes.
+const ControlPlaneLockLabelName = "aws.cluster.sigs.k8s.io/control-plane-lock"
+
 // ControlPlaneConfigMapName returns the name of the ConfigMap used to coordinate the bootstrapping of control plane
 // nodes.
 func ControlPlaneConfigMapName(cluster *v1alpha1.Cluster) string {
 	return fmt.Sprintf("%s-controlplane", cluster.UID)
 }
 
+// ControlPlaneJoinConfigMapName returns the name of the ConfigMap used to join control plane nodes one at a time.
+func ControlPlaneJoinConfigMapName(cluster *v1alpha1.Cluster) string {
+	return fmt.Sprintf("%s-controlplane-join", cluster.UID)
+}
+
+// ControlPlaneLockLabels returns the labels of the ConfigMaps used to coordinate control plane nodes, which
+// associate them with the cluster.
+func ControlPlaneLockLabels(cluster *v1alpha1.Cluster) map[string]string {
+	return map[string]string{
+		v1alpha1.MachineClusterLabelName: cluster.Name,
+		ControlPlaneLockLabelName:        "true",
+	}
+}
+
+// FindControlPlaneLockConfigMaps returns the ConfigMaps used to coordinate control plane nodes of the cluster.
+func FindControlPlaneLockConfigMaps(client corev1.ConfigMapsGetter, cluster *v1alpha1.Cluster) ([]apicorev1.ConfigMap, error) {
+	options := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=true", v1alpha1.MachineClusterLabelName, cluster.Name, ControlPlaneLockLabelName),
+	}
+
+	list, err := client.ConfigMaps(cluster.Namespace).List(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list control plane lock configmaps for cluster %q", cluster.Name)
+	}
+
+	return list.Items, nil
+}
+
 // ListOptionsForCluster returns a ListOptions with a label selector for clusterName.
 func ListOptionsForCluster(clusterName string) metav1.ListOptions {
 	return metav1.ListOptions{
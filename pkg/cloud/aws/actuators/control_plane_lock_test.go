@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestFindControlPlaneLockConfigMaps(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "name1",
+			UID:       "uid1",
+		},
+	}
+
+	configMaps := &listConfigMaps{
+		items: []v1.ConfigMap{
+			{ObjectMeta: metav1.ObjectMeta{Name: ControlPlaneConfigMapName(cluster), Labels: ControlPlaneLockLabels(cluster)}},
+			{ObjectMeta: metav1.ObjectMeta{Name: ControlPlaneJoinConfigMapName(cluster), Labels: ControlPlaneLockLabels(cluster)}},
+		},
+	}
+
+	found, err := FindControlPlaneLockConfigMaps(configMaps, cluster)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	if configMaps.namespace != "ns1" {
+		t.Fatalf("expected configmaps to be listed in namespace %q, got %q", "ns1", configMaps.namespace)
+	}
+
+	expectedSelector := "cluster.k8s.io/cluster-name=name1,aws.cluster.sigs.k8s.io/control-plane-lock=true"
+	if configMaps.selector != expectedSelector {
+		t.Fatalf("expected label selector %q, got %q", expectedSelector, configMaps.selector)
+	}
+
+	if len(found) != 2 {
+		t.Fatalf("expected 2 configmaps, got %d", len(found))
+	}
+}
+
+// listConfigMaps records the namespace and label selector configmaps are listed with.
+type listConfigMaps struct {
+	corev1client.ConfigMapInterface
+	items     []v1.ConfigMap
+	namespace string
+	selector  string
+}
+
+func (l *listConfigMaps) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	l.namespace = namespace
+	return l
+}
+
+func (l *listConfigMaps) List(opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+	l.selector = opts.LabelSelector
+	return &v1.ConfigMapList{Items: l.items}, nil
+}
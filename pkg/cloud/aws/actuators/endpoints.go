@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// serviceEndpointIDs are the IDs of the services whose endpoints can be overridden.
+var serviceEndpointIDs = map[string]bool{
+	ec2.EndpointsID:         true,
+	elb.EndpointsID:         true,
+	autoscaling.EndpointsID: true,
+	ssm.EndpointsID:         true,
+}
+
+// endpointResolver returns a resolver of AWS service endpoints that resolves
+// the overridden endpoints of services as configured, and all others in the
+// partition if one is set, or else in the partition of the region.
+func endpointResolver(partitionID string, serviceEndpoints []v1alpha1.ServiceEndpoint) (endpoints.Resolver, error) {
+	resolver := endpoints.DefaultResolver()
+	if partitionID != "" {
+		partition, ok := findPartition(partitionID)
+		if !ok {
+			return nil, errors.Errorf("unknown AWS partition %q", partitionID)
+		}
+		resolver = partition
+	}
+
+	overrides := map[string]v1alpha1.ServiceEndpoint{}
+	for _, e := range serviceEndpoints {
+		if !serviceEndpointIDs[e.ServiceID] {
+			return nil, errors.Errorf("unsupported service %q for endpoint %q", e.ServiceID, e.URL)
+		}
+		if e.URL == "" {
+			return nil, errors.Errorf("missing URL for the endpoint of service %q", e.ServiceID)
+		}
+		if _, ok := overrides[e.ServiceID]; ok {
+			return nil, errors.Errorf("endpoint of service %q is overridden more than once", e.ServiceID)
+		}
+		overrides[e.ServiceID] = e
+	}
+
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		e, ok := overrides[service]
+		if !ok {
+			return resolver.EndpointFor(service, region, opts...)
+		}
+
+		signingRegion := e.SigningRegion
+		if signingRegion == "" {
+			signingRegion = region
+		}
+		return endpoints.ResolvedEndpoint{
+			URL:           e.URL,
+			SigningRegion: signingRegion,
+		}, nil
+	}), nil
+}
+
+// findPartition returns the AWS partition with the given ID.
+func findPartition(id string) (endpoints.Partition, bool) {
+	for _, p := range endpoints.DefaultPartitions() {
+		if p.ID() == id {
+			return p, true
+		}
+	}
+	return endpoints.Partition{}, false
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"testing"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	testCases := []struct {
+		name                string
+		partition           string
+		endpoints           []v1alpha1.ServiceEndpoint
+		service             string
+		region              string
+		expectURL           string
+		expectSigningRegion string
+		expectErr           bool
+	}{
+		{
+			name:                "default endpoint",
+			service:             "ec2",
+			region:              "us-east-1",
+			expectURL:           "https://ec2.us-east-1.amazonaws.com",
+			expectSigningRegion: "us-east-1",
+		},
+		{
+			name:                "govcloud partition",
+			partition:           "aws-us-gov",
+			service:             "elasticloadbalancing",
+			region:              "us-gov-west-1",
+			expectURL:           "https://elasticloadbalancing.us-gov-west-1.amazonaws.com",
+			expectSigningRegion: "us-gov-west-1",
+		},
+		{
+			name:                "china partition",
+			partition:           "aws-cn",
+			service:             "ec2",
+			region:              "cn-north-1",
+			expectURL:           "https://ec2.cn-north-1.amazonaws.com.cn",
+			expectSigningRegion: "cn-north-1",
+		},
+		{
+			name:                "custom endpoint",
+			endpoints:           []v1alpha1.ServiceEndpoint{{ServiceID: "ec2", URL: "http://localhost:4566"}},
+			service:             "ec2",
+			region:              "us-east-1",
+			expectURL:           "http://localhost:4566",
+			expectSigningRegion: "us-east-1",
+		},
+		{
+			name:                "custom endpoint with signing region",
+			endpoints:           []v1alpha1.ServiceEndpoint{{ServiceID: "elasticloadbalancing", URL: "https://elb.example.com", SigningRegion: "us-west-2"}},
+			service:             "elasticloadbalancing",
+			region:              "us-east-1",
+			expectURL:           "https://elb.example.com",
+			expectSigningRegion: "us-west-2",
+		},
+		{
+			name:                "other services aren't overridden",
+			endpoints:           []v1alpha1.ServiceEndpoint{{ServiceID: "ec2", URL: "http://localhost:4566"}},
+			service:             "ssm",
+			region:              "us-east-1",
+			expectURL:           "https://ssm.us-east-1.amazonaws.com",
+			expectSigningRegion: "us-east-1",
+		},
+		{
+			name:      "unknown partition",
+			partition: "aws-moon",
+			expectErr: true,
+		},
+		{
+			name:      "unsupported service",
+			endpoints: []v1alpha1.ServiceEndpoint{{ServiceID: "s3", URL: "http://localhost:4566"}},
+			expectErr: true,
+		},
+		{
+			name:      "missing url",
+			endpoints: []v1alpha1.ServiceEndpoint{{ServiceID: "ec2"}},
+			expectErr: true,
+		},
+		{
+			name: "duplicate service",
+			endpoints: []v1alpha1.ServiceEndpoint{
+				{ServiceID: "ec2", URL: "http://localhost:4566"},
+				{ServiceID: "ec2", URL: "http://localhost:4567"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver, err := endpointResolver(tc.partition, tc.endpoints)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			resolved, err := resolver.EndpointFor(tc.service, tc.region)
+			if err != nil {
+				t.Fatalf("did not expect error resolving the endpoint: %v", err)
+			}
+			if resolved.URL != tc.expectURL {
+				t.Fatalf("expected URL %q, got %q", tc.expectURL, resolved.URL)
+			}
+			if resolved.SigningRegion != tc.expectSigningRegion {
+				t.Fatalf("expected signing region %q, got %q", tc.expectSigningRegion, resolved.SigningRegion)
+			}
+		})
+	}
+}
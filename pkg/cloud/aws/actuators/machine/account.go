@@ -0,0 +1,38 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+)
+
+// reconcileAccountID records the ID of the AWS account of the machine's
+// instance on its status, if enabled and not recorded yet.
+func (a *Actuator) reconcileAccountID(scope *actuators.MachineScope) error {
+	if !a.recordAccountID || scope.MachineStatus.AccountID != nil {
+		return nil
+	}
+
+	id, err := scope.AccountID()
+	if err != nil {
+		return err
+	}
+
+	scope.MachineStatus.AccountID = aws.String(id)
+	return nil
+}
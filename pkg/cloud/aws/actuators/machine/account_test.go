@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators/mock_stsiface"
+)
+
+func TestReconcileAccountID(t *testing.T) {
+	testCases := []struct {
+		name            string
+		recordAccountID bool
+		accountID       *string
+		expectCall      bool
+		expectAccountID string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:            "account ID is populated",
+			recordAccountID: true,
+			expectCall:      true,
+			expectAccountID: "123456789012",
+		},
+		{
+			name:            "account ID already recorded",
+			recordAccountID: true,
+			accountID:       aws.String("210987654321"),
+			expectAccountID: "210987654321",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			if tc.expectCall {
+				stsMock.EXPECT().
+					GetCallerIdentity(&sts.GetCallerIdentityInput{}).
+					Return(&sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil)
+			}
+
+			scope := &actuators.MachineScope{
+				Scope:         &actuators.Scope{AWSClients: actuators.AWSClients{STS: stsMock}},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{AccountID: tc.accountID},
+			}
+
+			a := &Actuator{recordAccountID: tc.recordAccountID}
+			if err := a.reconcileAccountID(scope); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if aws.StringValue(scope.MachineStatus.AccountID) != tc.expectAccountID {
+				t.Fatalf("expected account ID %q, got %q", tc.expectAccountID, aws.StringValue(scope.MachineStatus.AccountID))
+			}
+		})
+	}
+}
@@ -20,22 +20,28 @@ package machine
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"net"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/klogr"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/autoscaling"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/elb"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/route53"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/deployer"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/tokens"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 	client "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
 	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
@@ -46,12 +52,24 @@ const (
 	waitForClusterInfrastructureReadyDuration   = 15 * time.Second
 	waitForControlPlaneMachineExistenceDuration = 5 * time.Second
 	waitForControlPlaneReadyDuration            = 5 * time.Second
+	waitForRetryableCreateErrorDuration         = 30 * time.Second
+	waitForInstanceRecreateDuration             = 5 * time.Second
+	waitForControlPlaneJoinDuration             = 15 * time.Second
+	waitForNetworkInterfaceDetachDuration       = 10 * time.Second
+	waitForControlPlaneHealthyDuration          = 15 * time.Second
+	waitForInstanceRunningDuration              = 10 * time.Second
+	waitForTagThrottlingDuration                = 30 * time.Second
+	waitForPreTerminateHookDuration             = 10 * time.Second
+	waitForInstanceTerminateDuration            = 10 * time.Second
+	waitForConnectionDrainingDuration           = 15 * time.Second
 )
 
 //+kubebuilder:rbac:groups=awsprovider.k8s.io,resources=awsmachineproviderconfigs;awsmachineproviderstatuses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.k8s.io,resources=machines;machines/status;machinedeployments;machinedeployments/status;machinesets;machinesets/status;machineclasses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=nodes;events,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=create;get;list;update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;get;list;update
 
 // Actuator is responsible for performing machine reconciliation.
 type Actuator struct {
@@ -61,6 +79,32 @@ type Actuator struct {
 	clusterClient          client.ClusterV1alpha1Interface
 	log                    logr.Logger
 	controlPlaneInitLocker ControlPlaneInitLocker
+	controlPlaneJoinLocker ControlPlaneJoinLocker
+
+	allowedComplianceScopes    []string
+	validateControlPlaneHealth bool
+	waitForHealthyAPIServerELB bool
+	skipTagReconcile           bool
+	setMachinePhase            bool
+	recordAccountID            bool
+	recordConsoleOutput        bool
+	annotatePlacement          bool
+
+	probeControlPlaneConnectivity bool
+
+	workloadClusterCABundle         []byte
+	workloadClusterInsecureFallback bool
+	workloadClients                 workloadClientCache
+
+	clusterInfrastructureReadyRequeue   time.Duration
+	controlPlaneMachineExistenceRequeue time.Duration
+	controlPlaneMachineExistenceTimeout time.Duration
+	controlPlaneReadyRequeue            time.Duration
+	tagThrottlingRequeue                time.Duration
+	bootstrapTokenTTL                   time.Duration
+	requeueJitter                       float64
+
+	providerIDFormat ProviderIDFormat
 }
 
 // ActuatorParams holds parameter information for Actuator.
@@ -69,31 +113,181 @@ type ActuatorParams struct {
 	ClusterClient          client.ClusterV1alpha1Interface
 	LoggingContext         string
 	ControlPlaneInitLocker ControlPlaneInitLocker
+	// SerializeControlPlaneJoins makes control plane machines join the cluster one at a time.
+	SerializeControlPlaneJoins bool
+	// ControlPlaneJoinLocker is used to serialize control plane joins, if enabled.
+	ControlPlaneJoinLocker ControlPlaneJoinLocker
+	// AllowedComplianceScopes restricts the compliance scopes machines can set.
+	// Any scope is allowed if empty.
+	AllowedComplianceScopes []string
+	// ValidateControlPlaneHealth makes machines wait for the control plane to
+	// report itself healthy before they are allowed to join the cluster.
+	ValidateControlPlaneHealth bool
+	// ProbeControlPlaneConnectivity makes machines check that the control plane
+	// endpoint accepts TCP connections from the controller before they are
+	// allowed to join the cluster, for clearer diagnostics when it doesn't.
+	ProbeControlPlaneConnectivity bool
+	// WaitForHealthyAPIServerELB makes machines wait for at least one control
+	// plane instance behind the API server load balancer to be healthy before
+	// they are allowed to join the cluster.
+	WaitForHealthyAPIServerELB bool
+	// SkipTagReconcile makes the actuator only tag instances when launching
+	// them, instead of reconciling their tags on every update. Changes to the
+	// additional tags of existing machines are then not applied.
+	SkipTagReconcile bool
+	// SetMachinePhase makes the actuator set the phase of machines from the
+	// state of their instance, one of Provisioning, Running, Deleting or Failed.
+	SetMachinePhase bool
+	// RecordAccountID makes the actuator record the ID of the AWS account
+	// instances are launched in on the machine status, for auditing clusters
+	// that span several accounts.
+	RecordAccountID bool
+	// RecordConsoleOutput makes the actuator record the tail of the console
+	// output of instances that exceed their launch timeout on the machine
+	// status. The tail is logged either way.
+	RecordConsoleOutput bool
+	// AnnotatePlacement makes the actuator annotate machines with the subnet
+	// and availability zone their instance was launched in, so users can see
+	// where it landed without querying AWS.
+	AnnotatePlacement bool
+	// WorkloadClusterCABundle holds PEM encoded CA certificates that the control
+	// plane certificate of workload clusters is also verified against, in
+	// addition to the CA of their kubeconfig.
+	WorkloadClusterCABundle []byte
+	// WorkloadClusterInsecureFallback makes the actuator skip TLS verification
+	// when connecting to the control plane of a workload cluster whose
+	// certificate can't be verified, e.g. because it doesn't include the load
+	// balancer DNS name. Verification is always attempted first.
+	WorkloadClusterInsecureFallback bool
+	// ClusterInfrastructureReadyRequeue is how long to wait before reconciling
+	// a machine again while the cluster infrastructure isn't ready. Defaults to 15s.
+	ClusterInfrastructureReadyRequeue time.Duration
+	// ControlPlaneMachineExistenceRequeue is how long to wait before reconciling
+	// a node machine again while no control plane machine exists. Defaults to 5s.
+	ControlPlaneMachineExistenceRequeue time.Duration
+	// ControlPlaneMachineExistenceTimeout is how long a node machine waits for
+	// a control plane machine to exist before it is marked as failed, so that
+	// clusters without a control plane surface an error. Node machines wait
+	// indefinitely if zero.
+	ControlPlaneMachineExistenceTimeout time.Duration
+	// ControlPlaneReadyRequeue is how long to wait before reconciling a control
+	// plane machine again while another one initializes the cluster. Defaults to 5s.
+	ControlPlaneReadyRequeue time.Duration
+	// TagThrottlingRequeue is how long to wait before reconciling a machine
+	// again when tagging its instance was throttled by AWS, instead of failing
+	// the update. Defaults to 30s.
+	TagThrottlingRequeue time.Duration
+	// BootstrapTokenTTL is the lifetime of the bootstrap tokens machines join
+	// the cluster with. Defaults to 10m.
+	BootstrapTokenTTL time.Duration
+	// RequeueJitter spreads out the requeues of machines waiting on the cluster
+	// by varying each requeue interval randomly by up to this fraction of it,
+	// e.g. 0.1 for +/-10%. Requeue intervals aren't varied if zero.
+	RequeueJitter float64
+	// ProviderIDFormat is the format of the provider ID set on machines.
+	// Provider IDs of existing machines in another format are migrated to it.
+	// Defaults to ProviderIDFormatZone, which the AWS cloud provider expects.
+	ProviderIDFormat ProviderIDFormat
 }
 
 // NewActuator returns an actuator.
 func NewActuator(params ActuatorParams) *Actuator {
 	log := klogr.New().WithName(params.LoggingContext)
 
+	providerIDFormat := params.ProviderIDFormat
+	if providerIDFormat == "" {
+		providerIDFormat = ProviderIDFormatZone
+	}
+
 	locker := params.ControlPlaneInitLocker
 	if locker == nil {
 		locker = newControlPlaneInitLocker(log, params.CoreClient)
 	}
 
+	joinLocker := params.ControlPlaneJoinLocker
+	if joinLocker == nil && params.SerializeControlPlaneJoins {
+		joinLocker = newControlPlaneJoinLocker(log, params.CoreClient, params.ClusterClient)
+	}
+
 	return &Actuator{
 		Deployer:               deployer.New(deployer.Params{ScopeGetter: actuators.DefaultScopeGetter}),
 		coreClient:             params.CoreClient,
 		clusterClient:          params.ClusterClient,
 		log:                    log,
 		controlPlaneInitLocker: locker,
+		controlPlaneJoinLocker: joinLocker,
+
+		allowedComplianceScopes:    params.AllowedComplianceScopes,
+		validateControlPlaneHealth: params.ValidateControlPlaneHealth,
+		waitForHealthyAPIServerELB: params.WaitForHealthyAPIServerELB,
+		skipTagReconcile:           params.SkipTagReconcile,
+		setMachinePhase:            params.SetMachinePhase,
+		recordAccountID:            params.RecordAccountID,
+		recordConsoleOutput:        params.RecordConsoleOutput,
+		annotatePlacement:          params.AnnotatePlacement,
+
+		probeControlPlaneConnectivity: params.ProbeControlPlaneConnectivity,
+
+		workloadClusterCABundle:         params.WorkloadClusterCABundle,
+		workloadClusterInsecureFallback: params.WorkloadClusterInsecureFallback,
+
+		clusterInfrastructureReadyRequeue:   durationOrDefault(params.ClusterInfrastructureReadyRequeue, waitForClusterInfrastructureReadyDuration),
+		controlPlaneMachineExistenceRequeue: durationOrDefault(params.ControlPlaneMachineExistenceRequeue, waitForControlPlaneMachineExistenceDuration),
+		controlPlaneMachineExistenceTimeout: params.ControlPlaneMachineExistenceTimeout,
+		controlPlaneReadyRequeue:            durationOrDefault(params.ControlPlaneReadyRequeue, waitForControlPlaneReadyDuration),
+		tagThrottlingRequeue:                durationOrDefault(params.TagThrottlingRequeue, waitForTagThrottlingDuration),
+		bootstrapTokenTTL:                   durationOrDefault(params.BootstrapTokenTTL, defaultTokenTTL),
+		requeueJitter:                       params.RequeueJitter,
+
+		providerIDFormat: providerIDFormat,
+	}
+}
+
+// durationOrDefault returns d, or def if d isn't set.
+func durationOrDefault(d, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
+
+// requeueAfter returns a requeue error for the given interval, jittered by the
+// actuator's requeue jitter factor.
+func (a *Actuator) requeueAfter(d time.Duration) error {
+	return &controllerError.RequeueAfterError{RequeueAfter: jitter(d, a.requeueJitter)}
+}
+
+// jitter returns d varied randomly by up to +/- factor of it. The factor is
+// capped to 1 so the result is never negative.
+func jitter(d time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return d
 	}
+	if factor > 1 {
+		factor = 1
+	}
+	return d + time.Duration((rand.Float64()*2-1)*factor*float64(d))
 }
 
 // GetControlPlaneMachines retrieves all non-deleted control plane nodes from a MachineList
 func GetControlPlaneMachines(machineList *clusterv1.MachineList) []*clusterv1.Machine {
+	return getControlPlaneMachines(machineList, false)
+}
+
+// GetControlPlaneMachinesIncludingDeleting retrieves all control plane nodes from a MachineList,
+// including those pending deletion. Terminating control plane machines are still etcd members
+// until they are gone, so quorum-aware decisions should count them.
+func GetControlPlaneMachinesIncludingDeleting(machineList *clusterv1.MachineList) []*clusterv1.Machine {
+	return getControlPlaneMachines(machineList, true)
+}
+
+func getControlPlaneMachines(machineList *clusterv1.MachineList, includeDeleting bool) []*clusterv1.Machine {
 	var cpm []*clusterv1.Machine
 	for _, m := range machineList.Items {
-		if m.DeletionTimestamp.IsZero() && m.Spec.Versions.ControlPlane != "" {
+		if m.Spec.Versions.ControlPlane == "" {
+			continue
+		}
+		if includeDeleting || m.DeletionTimestamp.IsZero() {
 			cpm = append(cpm, m.DeepCopy())
 		}
 	}
@@ -105,20 +299,55 @@ func machinesEqual(m1 *clusterv1.Machine, m2 *clusterv1.Machine) bool {
 	return m1.Name == m2.Name && m1.Namespace == m2.Namespace
 }
 
+// Reconcile converges a machine towards its desired state: it looks up the
+// machine's instance once, then creates the instance if it doesn't exist or
+// updates it otherwise. Create, Update and Exists are kept for the machine
+// controller, which calls them separately.
+func (a *Actuator) Reconcile(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	cluster, err := a.getCluster(cluster, machine)
+	if err != nil {
+		return err
+	}
+
+	log := a.log.WithValues("machine-name", machine.Name, "namespace", machine.Namespace, "cluster-name", cluster.Name)
+	log.Info("Reconciling machine")
+
+	scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{Machine: machine, Cluster: cluster, Client: a.clusterClient, Logger: log})
+	if err != nil {
+		return errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	defer scope.Close()
+
+	ec2svc := ec2.NewService(scope.Scope)
+
+	instance, err := instanceIfExists(ec2svc, scope)
+	if err != nil {
+		return errors.Errorf("failed to get instance: %+v", err)
+	}
+
+	exists, err := a.exists(log, scope, ec2svc, instance)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return a.create(log, scope, ec2svc)
+	}
+
+	return a.update(scope, ec2svc, instance)
+}
+
 // Create creates a machine and is invoked by the machine controller.
 func (a *Actuator) Create(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
-	if cluster == nil {
-		return errors.Errorf("missing cluster for machine %s/%s", machine.Namespace, machine.Name)
+	cluster, err := a.getCluster(cluster, machine)
+	if err != nil {
+		return err
 	}
 
 	log := a.log.WithValues("machine-name", machine.Name, "namespace", machine.Namespace, "cluster-name", cluster.Name)
 	log.Info("Processing machine creation")
 
-	if cluster.Annotations[v1alpha1.AnnotationClusterInfrastructureReady] != v1alpha1.ValueReady {
-		log.Info("Cluster infrastructure is not ready yet - requeuing machine")
-		return &controllerError.RequeueAfterError{RequeueAfter: waitForClusterInfrastructureReadyDuration}
-	}
-
 	scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{Machine: machine, Cluster: cluster, Client: a.clusterClient, Logger: log})
 	if err != nil {
 		return errors.Errorf("failed to create scope: %+v", err)
@@ -126,7 +355,34 @@ func (a *Actuator) Create(ctx context.Context, cluster *clusterv1.Cluster, machi
 
 	defer scope.Close()
 
-	ec2svc := ec2.NewService(scope.Scope)
+	return a.create(log, scope, ec2.NewService(scope.Scope))
+}
+
+// create launches the instance of a machine, once the cluster is ready for it.
+func (a *Actuator) create(log logr.Logger, scope *actuators.MachineScope, ec2svc *ec2.Service) error {
+	cluster, machine := scope.Cluster, scope.Machine
+
+	// Add the finalizer before anything is launched, it is persisted when the
+	// scope is closed, even if the creation is requeued.
+	addFinalizer(machine, v1alpha1.MachineFinalizer)
+
+	if err := a.waitForMaintenanceWindow(log, scope, time.Now()); err != nil {
+		return err
+	}
+
+	if cluster.Annotations[v1alpha1.AnnotationClusterInfrastructureReady] != v1alpha1.ValueReady {
+		log.Info("Cluster infrastructure is not ready yet - requeuing machine")
+		return a.requeueAfter(a.clusterInfrastructureReadyRequeue)
+	}
+
+	if scope.MachineStatus.FailureReason != nil {
+		log.Info("Machine has a terminal failure, not creating an instance", "reason", *scope.MachineStatus.FailureReason)
+		return nil
+	}
+
+	if err := a.validateComplianceScope(scope.MachineConfig.ComplianceScope); err != nil {
+		return err
+	}
 
 	log.Info("Retrieving machines for cluster")
 	clusterMachines, err := scope.MachineClient.List(actuators.ListOptionsForCluster(cluster.Name))
@@ -134,10 +390,11 @@ func (a *Actuator) Create(ctx context.Context, cluster *clusterv1.Cluster, machi
 		return errors.Wrapf(err, "failed to retrieve machines in cluster %q", cluster.Name)
 	}
 
-	controlPlaneMachines := GetControlPlaneMachines(clusterMachines)
+	// Machines pending deletion still count here: during a rolling control plane
+	// upgrade the outgoing members keep serving until they are removed.
+	controlPlaneMachines := GetControlPlaneMachinesIncludingDeleting(clusterMachines)
 	if len(controlPlaneMachines) == 0 {
-		log.Info("No control plane machines exist yet - requeuing")
-		return &controllerError.RequeueAfterError{RequeueAfter: waitForControlPlaneMachineExistenceDuration}
+		return a.waitForControlPlaneMachines(log, scope, time.Now())
 	}
 
 	join, err := a.isNodeJoin(log, cluster, machine)
@@ -147,39 +404,101 @@ func (a *Actuator) Create(ctx context.Context, cluster *clusterv1.Cluster, machi
 
 	var bootstrapToken string
 	if join {
+		if a.probeControlPlaneConnectivity {
+			controlPlaneDNSName, err := a.GetIP(cluster, nil)
+			if err != nil {
+				return errors.Errorf("failed to retrieve controlplane (GetIP): %+v", err)
+			}
+
+			if err := checkControlPlaneReachable(net.JoinHostPort(controlPlaneDNSName, strconv.FormatInt(scope.APIServerPort(), 10)), controlPlaneDialTimeout); err != nil {
+				log.Info("Control plane endpoint is not reachable - requeuing", "reason", err.Error())
+				return a.requeueAfter(waitForControlPlaneHealthyDuration)
+			}
+		}
+
 		coreClient, err := a.coreV1Client(cluster)
 		if err != nil {
 			return errors.Wrapf(err, "unable to proceed until control plane is ready (error creating client) for cluster %q", path.Join(cluster.Namespace, cluster.Name))
 		}
 
+		if a.validateControlPlaneHealth {
+			if err := checkControlPlaneHealth(coreClient.RESTClient()); err != nil {
+				a.workloadClients.invalidate(cluster.UID)
+				log.Info("Control plane is not healthy - requeuing", "reason", err.Error())
+				return a.requeueAfter(waitForControlPlaneHealthyDuration)
+			}
+		}
+
+		if a.waitForHealthyAPIServerELB {
+			if err := checkAPIServerELBHealth(elb.NewService(scope.Scope)); err != nil {
+				log.Info("API server load balancer is not healthy - requeuing", "reason", err.Error())
+				return a.requeueAfter(waitForControlPlaneHealthyDuration)
+			}
+		}
+
 		log.Info("Machine will join the cluster")
 
-		bootstrapToken, err = tokens.NewBootstrap(coreClient, defaultTokenTTL)
+		bootstrapToken, err = tokens.NewBootstrap(coreClient, a.bootstrapTokenTTL)
 		if err != nil {
+			a.workloadClients.invalidate(cluster.UID)
 			return errors.Wrapf(err, "failed to create new bootstrap token")
 		}
 	} else {
 		log.Info("Machine will init the cluster")
 	}
 
+	if err := a.reconcileKeyPair(ec2svc, a.coreClient, scope); err != nil {
+		return err
+	}
+
 	i, err := ec2svc.CreateOrGetMachine(scope, bootstrapToken)
 	if err != nil {
-		return errors.Errorf("failed to create or get machine: %+v", err)
+		return handleCreateError(scope, err)
 	}
 
+	setMachineCreated(scope.MachineStatus, i)
+
 	scope.MachineStatus.InstanceID = &i.ID
 	scope.MachineStatus.InstanceState = &i.State
+	scope.MachineStatus.AMIID = &i.ImageID
+	scope.MachineStatus.SubnetID = &i.SubnetID
+	scope.MachineStatus.LaunchTime = i.LaunchTime
+	scope.MachineStatus.NetworkInterfaceIDs = i.NetworkInterfaceIDs
+	if i.MonitoringState != "" {
+		scope.MachineStatus.MonitoringState = aws.String(i.MonitoringState)
+	}
+	if i.HTTPTokens != "" {
+		scope.MachineStatus.HTTPTokens = &i.HTTPTokens
+	}
+
+	// Set the provider ID right away rather than on the next Exists, so the
+	// node can be linked to the machine as soon as it registers.
+	a.reconcileProviderID(scope, i)
 
 	if machine.Annotations == nil {
 		machine.Annotations = map[string]string{}
 	}
 
 	machine.Annotations["cluster-api-provider-aws"] = "true"
+	a.setPlacementAnnotations(machine, i)
 
-	if err := a.reconcileLBAttachment(scope, machine, i); err != nil {
+	if err := a.reconcileLBAttachment(elb.NewService(scope.Scope), scope, machine, i); err != nil {
 		return errors.Errorf("failed to reconcile LB attachment: %+v", err)
 	}
 
+	if err := a.reconcileAPIServerDNS(route53.NewService(scope.Scope), scope); err != nil {
+		return errors.Errorf("failed to reconcile API server DNS record: %+v", err)
+	}
+
+	if err := a.ensureSourceDestCheck(ec2svc, scope, i); err != nil {
+		return errors.Errorf("failed to ensure source/destination check: %+v", err)
+	}
+
+	if err := a.requeueIfNotRunning(scope, i); err != nil {
+		log.Info("Machine instance is not running within the resource creation timeout - requeuing", "instance-id", i.ID)
+		return err
+	}
+
 	log.Info("Create completed")
 
 	return nil
@@ -187,13 +506,17 @@ func (a *Actuator) Create(ctx context.Context, cluster *clusterv1.Cluster, machi
 
 func (a *Actuator) isNodeJoin(log logr.Logger, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (bool, error) {
 	if cluster.Annotations[v1alpha1.AnnotationControlPlaneReady] == v1alpha1.ValueReady {
+		if machine.Labels["set"] == "controlplane" && a.controlPlaneJoinLocker != nil && !a.controlPlaneJoinLocker.Acquire(cluster, machine) {
+			log.Info("Another control plane machine is joining the cluster - requeuing")
+			return true, a.requeueAfter(waitForControlPlaneJoinDuration)
+		}
 		return true, nil
 	}
 
 	if machine.Labels["set"] != "controlplane" {
 		// This isn't a control plane machine - have to wait
 		log.Info("No control plane machines exist yet - requeuing")
-		return true, &controllerError.RequeueAfterError{RequeueAfter: waitForControlPlaneMachineExistenceDuration}
+		return true, a.requeueAfter(a.controlPlaneMachineExistenceRequeue)
 	}
 
 	if a.controlPlaneInitLocker.Acquire(cluster) {
@@ -201,153 +524,266 @@ func (a *Actuator) isNodeJoin(log logr.Logger, cluster *clusterv1.Cluster, machi
 	}
 
 	log.Info("Unable to acquire control plane configmap lock - requeuing")
-	return true, &controllerError.RequeueAfterError{RequeueAfter: waitForControlPlaneReadyDuration}
+	return true, a.requeueAfter(a.controlPlaneReadyRequeue)
 }
 
+// coreV1Client returns a client for the control plane of the cluster, from the
+// cache if one was built already. Callers invalidate the cached client if it
+// fails, e.g. because the control plane endpoint or credentials changed.
 func (a *Actuator) coreV1Client(cluster *clusterv1.Cluster) (corev1.CoreV1Interface, error) {
+	if client, ok := a.workloadClients.get(cluster.UID); ok {
+		return client, nil
+	}
+
 	controlPlaneDNSName, err := a.GetIP(cluster, nil)
 	if err != nil {
 		return nil, errors.Errorf("failed to retrieve controlplane (GetIP): %+v", err)
 	}
 
-	controlPlaneURL := fmt.Sprintf("https://%s:6443", controlPlaneDNSName)
+	clusterConfig, err := v1alpha1.ClusterConfigFromProviderSpec(cluster.Spec.ProviderSpec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load provider spec of cluster %q", cluster.Name)
+	}
+
+	controlPlaneURL := fmt.Sprintf("https://%s", net.JoinHostPort(controlPlaneDNSName, strconv.FormatInt(actuators.APIServerPort(clusterConfig), 10)))
 
 	kubeConfig, err := a.GetKubeConfig(cluster, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to retrieve kubeconfig for cluster %q.", cluster.Name)
 	}
 
-	clientConfig, err := clientcmd.BuildConfigFromKubeconfigGetter(controlPlaneURL, func() (*clientcmdapi.Config, error) {
-		return clientcmd.Load([]byte(kubeConfig))
-	})
+	clientConfig, err := a.workloadClientConfig(controlPlaneURL, kubeConfig)
+	if err != nil {
+		return nil, err
+	}
 
+	client, err := corev1.NewForConfig(clientConfig)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get client config for cluster at %q", controlPlaneURL)
+		return nil, err
 	}
 
-	return corev1.NewForConfig(clientConfig)
+	a.workloadClients.set(cluster.UID, client)
+	return client, nil
 }
 
-func (a *Actuator) reconcileLBAttachment(scope *actuators.MachineScope, m *clusterv1.Machine, i *v1alpha1.Instance) error {
-	elbsvc := elb.NewService(scope.Scope)
+func (a *Actuator) reconcileLBAttachment(elbsvc service.ELBInterface, scope *actuators.MachineScope, m *clusterv1.Machine, i *v1alpha1.Instance) error {
 	if m.ObjectMeta.Labels["set"] == "controlplane" {
+		// Make sure the load balancer listens on the current API server port
+		// first, a stale listener would leave the instance unreachable.
+		if err := elbsvc.ReconcileAPIServerListeners(); err != nil {
+			return errors.Wrapf(err, "could not reconcile load balancer listeners for control plane instance %q", i.ID)
+		}
 		if err := elbsvc.RegisterInstanceWithAPIServerELB(i.ID); err != nil {
 			return errors.Wrapf(err, "could not register control plane instance %q with load balancer", i.ID)
 		}
 	}
 
-	return nil
+	return a.reconcileTargetGroups(elbsvc, scope, i.ID)
 }
 
-// Delete deletes a machine and is invoked by the Machine Controller
-func (a *Actuator) Delete(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
-	if cluster == nil {
-		return errors.Errorf("missing cluster for machine %s/%s", machine.Namespace, machine.Name)
+// deregisterLBAttachment deregisters the instances of a machine that is being
+// deleted from the load balancer and target groups that still list them, and
+// returns those instances. The load balancers keep listing an instance while
+// its connections drain, so it is only terminated once none lists it anymore.
+func (a *Actuator) deregisterLBAttachment(elbsvc service.ELBInterface, scope *actuators.MachineScope, m *clusterv1.Machine, instanceIDs []string) ([]string, error) {
+	if len(instanceIDs) == 0 {
+		return nil, nil
 	}
-	a.log.Info("Deleting machine in cluster", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "cluster-name", cluster.Name)
 
-	scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{Machine: machine, Cluster: cluster, Client: a.clusterClient, Logger: a.log})
+	var draining []string
+	if m.ObjectMeta.Labels["set"] == "controlplane" {
+		registered, err := elbsvc.InstancesRegisteredWithAPIServerELB(instanceIDs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not describe control plane instances %v of load balancer", instanceIDs)
+		}
+		if err := elbsvc.DeregisterInstancesFromAPIServerELB(registered); err != nil {
+			return nil, errors.Wrapf(err, "could not deregister control plane instances %v from load balancer", registered)
+		}
+		draining = registered
+	}
+
+	registered, err := a.deregisterTargetGroups(elbsvc, scope, instanceIDs)
 	if err != nil {
-		return errors.Errorf("failed to create scope: %+v", err)
+		return nil, err
 	}
 
-	defer scope.Close()
+	return append(draining, difference(registered, draining)...), nil
+}
 
-	ec2svc := ec2.NewService(scope.Scope)
+// terminateInstances deregisters and terminates the instances of a machine
+// that is being deleted, and requeues the machine until EC2 reports all of
+// them as terminated or no longer knows about them.
+func (a *Actuator) terminateInstances(ec2svc service.EC2MachineInterface, elbsvc service.ELBInterface, scope *actuators.MachineScope) error {
+	machine := scope.Machine
 
-	instance, err := ec2svc.InstanceIfExists(scope.MachineStatus.InstanceID)
+	// Look the recorded instance up in any state, so an instance that is still
+	// shutting down isn't mistaken for a terminated one.
+	instance, err := ec2svc.AnyInstanceIfExists(scope.MachineStatus.InstanceID)
 	if err != nil {
 		return errors.Errorf("failed to get instance: %+v", err)
 	}
 
-	if instance == nil {
-		instance, err = ec2svc.InstanceByTags(scope)
-		if err != nil {
-			return errors.Errorf("failed to query instance by tags: %+v", err)
-		} else if instance == nil {
-			// The machine hasn't been created yet
-			a.log.V(3).Info("Instance is nil and therefore does not exist")
-			return nil
+	// Also look for instances tagged for the machine, a previous create that
+	// partially succeeded could have left more than one instance behind.
+	taggedInstances, err := ec2svc.UnterminatedInstanceByTags(scope)
+	if err != nil {
+		return errors.Errorf("failed to query instance by tags: %+v", err)
+	}
+
+	instances := taggedInstances
+	if instance != nil {
+		instances = append([]*v1alpha1.Instance{instance}, taggedInstances...)
+	}
+
+	if len(instances) == 0 {
+		// The machine hasn't been created yet
+		a.log.V(3).Info("Instance is nil and therefore does not exist")
+	}
+
+	if hooks := preTerminateHooks(machine); len(hooks) > 0 && anyTerminable(instances) {
+		a.log.Info("Waiting for pre-terminate hooks before terminating machine instance", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "hooks", hooks)
+		return &controllerError.RequeueAfterError{RequeueAfter: waitForPreTerminateHookDuration}
+	}
+
+	seen := map[string]bool{}
+	var terminate []string
+	shuttingDown := 0
+	for _, instance := range instances {
+		if seen[instance.ID] {
+			continue
+		}
+		seen[instance.ID] = true
+
+		// Check the instance state. If it's already shutting down or terminated,
+		// do nothing. Otherwise attempt to delete it, including instances that
+		// were stopped by an OS initiated shutdown.
+		// This decision is based on the ec2-instance-lifecycle graph at
+		// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-lifecycle.html
+		switch instance.State {
+		case v1alpha1.InstanceStateTerminated:
+			a.log.Info("Machine instance is terminated", "instance-id", instance.ID)
+		case v1alpha1.InstanceStateShuttingDown:
+			a.log.Info("Machine instance is shutting down", "instance-id", instance.ID)
+			shuttingDown++
+		default:
+			terminate = append(terminate, instance.ID)
 		}
 	}
 
-	// Check the instance state. If it's already shutting down or terminated,
-	// do nothing. Otherwise attempt to delete it.
-	// This decision is based on the ec2-instance-lifecycle graph at
-	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-lifecycle.html
-	switch instance.State {
-	case v1alpha1.InstanceStateShuttingDown, v1alpha1.InstanceStateTerminated:
-		a.log.Info("Machine instance is shutting down or already terminated")
-		return nil
-	default:
-		a.log.Info("Terminating machine")
-		if err := ec2svc.TerminateInstance(instance.ID); err != nil {
-			return errors.Errorf("failed to terminate instance: %+v", err)
+	// Deregister the instances first, and only terminate them once the load
+	// balancers drained their connections.
+	draining, err := a.deregisterLBAttachment(elbsvc, scope, machine, terminate)
+	if err != nil {
+		return errors.Errorf("failed to deregister instances %v: %+v", terminate, err)
+	}
+	if len(draining) > 0 {
+		a.log.Info("Waiting for load balancers to drain machine instances", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "instance-ids", draining)
+		return &controllerError.RequeueAfterError{RequeueAfter: waitForConnectionDrainingDuration}
+	}
+
+	for _, id := range terminate {
+		a.log.Info("Terminating machine instance", "instance-id", id)
+		if err := ec2svc.TerminateInstance(id); err != nil {
+			return errors.Errorf("failed to terminate instance %q: %+v", id, err)
 		}
 	}
 
+	// Keep the finalizer until neither the instance ID nor the tags of the
+	// machine find an instance that isn't terminated.
+	if remaining := len(terminate) + shuttingDown; remaining > 0 {
+		a.log.Info("Waiting for machine instances to terminate", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "count", remaining)
+		return &controllerError.RequeueAfterError{RequeueAfter: waitForInstanceTerminateDuration}
+	}
+
 	return nil
 }
 
-// isMachineOudated checks that no immutable fields have been updated in an
-// Update request.
-// Returns a slice of errors representing attempts to change immutable state
-func (a *Actuator) isMachineOutdated(machineSpec *v1alpha1.AWSMachineProviderSpec, instance *v1alpha1.Instance) (errs []error) {
-	// Instance Type
-	if machineSpec.InstanceType != instance.Type {
-		errs = append(errs, errors.Errorf("instance type cannot be mutated from %q to %q", instance.Type, machineSpec.InstanceType))
+// Delete deletes a machine and is invoked by the Machine Controller
+func (a *Actuator) Delete(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
+	cluster, err := a.getCluster(cluster, machine)
+	if err != nil {
+		return err
 	}
+	a.log.Info("Deleting machine in cluster", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "cluster-name", cluster.Name)
 
-	// IAM Profile
-	if machineSpec.IAMInstanceProfile != instance.IAMProfile {
-		errs = append(errs, errors.Errorf("instance IAM profile cannot be mutated from %q to %q", instance.IAMProfile, machineSpec.IAMInstanceProfile))
+	scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{Machine: machine, Cluster: cluster, Client: a.clusterClient, Logger: a.log})
+	if err != nil {
+		return errors.Errorf("failed to create scope: %+v", err)
 	}
 
-	// SSH Key Name
-	if machineSpec.KeyName != aws.StringValue(instance.KeyName) {
-		errs = append(errs, errors.Errorf("SSH key name cannot be mutated from %q to %q", aws.StringValue(instance.KeyName), machineSpec.KeyName))
+	defer scope.Close()
+
+	ec2svc := ec2.NewService(scope.Scope)
+
+	if err := a.terminateInstances(ec2svc, elb.NewService(scope.Scope), scope); err != nil {
+		return err
 	}
 
-	// Root Device Size
-	if machineSpec.RootDeviceSize > 0 && machineSpec.RootDeviceSize != instance.RootDeviceSize {
-		errs = append(errs, errors.Errorf("Root volume size cannot be mutated from %v to %v", instance.RootDeviceSize, machineSpec.RootDeviceSize))
+	// Network interfaces that aren't deleted on termination can only be deleted
+	// once the instance is gone.
+	remaining, err := ec2svc.DeleteDetachedNetworkInterfaces(scope.MachineStatus.NetworkInterfaceIDs)
+	if err != nil {
+		return errors.Errorf("failed to delete network interfaces: %+v", err)
+	}
+	if remaining > 0 {
+		a.log.Info("Waiting for network interfaces to be detached", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "count", remaining)
+		return &controllerError.RequeueAfterError{RequeueAfter: waitForNetworkInterfaceDetachDuration}
 	}
 
-	// Subnet ID
-	// machineSpec.Subnet is a *AWSResourceReference and could technically be
-	// a *string, ARN or Filter. However, elsewhere in the code it is only used
-	// as a *string, so do the same here.
-	if machineSpec.Subnet != nil {
-		if aws.StringValue(machineSpec.Subnet.ID) != instance.SubnetID {
-			errs = append(errs, errors.Errorf("machine subnet ID cannot be mutated from %q to %q", instance.SubnetID, aws.StringValue(machineSpec.Subnet.ID)))
-		}
+	if err := a.deleteAPIServerDNS(route53.NewService(scope.Scope), scope); err != nil {
+		return errors.Errorf("failed to delete API server DNS record: %+v", err)
+	}
+
+	removeFinalizer(machine, v1alpha1.MachineFinalizer)
+
+	return nil
+}
+
+// getCluster returns the cluster the machine belongs to. The machine controller
+// passes a nil cluster when it failed to find it, in which case the cluster is
+// looked up through the cluster client using the machine's cluster name label.
+func (a *Actuator) getCluster(cluster *clusterv1.Cluster, machine *clusterv1.Machine) (*clusterv1.Cluster, error) {
+	if cluster != nil {
+		return cluster, nil
+	}
+
+	name := machine.Labels[clusterv1.MachineClusterLabelName]
+	if name == "" || a.clusterClient == nil {
+		return nil, errors.Errorf("missing cluster for machine %s/%s", machine.Namespace, machine.Name)
 	}
 
-	// PublicIP check is a little more complicated as the machineConfig is a
-	// simple bool indicating if the instance should have a public IP or not,
-	// while the instanceDescription contains the public IP assigned to the
-	// instance.
-	// Work out whether the instance already has a public IP or not based on
-	// the length of the PublicIP string. Anything >0 is assumed to mean it does
-	// have a public IP.
-	instanceHasPublicIP := false
-	if len(aws.StringValue(instance.PublicIP)) > 0 {
-		instanceHasPublicIP = true
+	a.log.V(2).Info("Looking up cluster for machine", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "cluster-name", name)
+	cluster, err := a.clusterClient.Clusters(machine.Namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cluster %q for machine %s/%s", name, machine.Namespace, machine.Name)
 	}
 
-	if aws.BoolValue(machineSpec.PublicIP) != instanceHasPublicIP {
-		errs = append(errs, errors.Errorf(`public IP setting cannot be mutated from "%v" to "%v"`, instanceHasPublicIP, aws.BoolValue(machineSpec.PublicIP)))
+	return cluster, nil
+}
+
+// iamInstanceProfileName returns the name of an IAM instance profile given
+// either its name, its ARN or its path-qualified name.
+func iamInstanceProfileName(profile string) string {
+	if profile == "" {
+		return ""
 	}
+	return path.Base(profile)
+}
 
-	return errs
+// isMachineOudated checks that no immutable fields have been updated in an
+// Update request.
+// Returns the attempts to change immutable state, one per field.
+func (a *Actuator) isMachineOutdated(machineSpec *v1alpha1.AWSMachineProviderSpec, machineStatus *v1alpha1.AWSMachineProviderStatus, instance *v1alpha1.Instance) ImmutableFieldErrors {
+	return immutableFieldChanges(machineSpec, machineStatus, instance)
 }
 
 // Update updates a machine and is invoked by the Machine Controller.
 // If the Update attempts to mutate any immutable state, the method will error
 // and no updates will be performed.
 func (a *Actuator) Update(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
-	if cluster == nil {
-		return errors.Errorf("missing cluster for machine %s/%s", machine.Namespace, machine.Name)
+	cluster, err := a.getCluster(cluster, machine)
+	if err != nil {
+		return err
 	}
 
 	a.log.Info("Updating machine in cluster", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "cluster-name", cluster.Name)
@@ -362,19 +798,68 @@ func (a *Actuator) Update(ctx context.Context, cluster *clusterv1.Cluster, machi
 	ec2svc := ec2.NewService(scope.Scope)
 
 	// Get the current instance description from AWS.
-	instanceDescription, err := ec2svc.InstanceIfExists(scope.MachineStatus.InstanceID)
+	instanceDescription, err := instanceIfExists(ec2svc, scope)
 	if err != nil {
 		return errors.Errorf("failed to get instance: %+v", err)
 	}
 
+	return a.update(scope, ec2svc, instanceDescription)
+}
+
+// update converges the instance of a machine, as last described by AWS, with
+// the machine spec.
+func (a *Actuator) update(scope *actuators.MachineScope, ec2svc service.EC2MachineInterface, instanceDescription *v1alpha1.Instance) error {
+	machine := scope.Machine
+
+	// Machines launched before the finalizer was introduced get it on their
+	// next update, it is persisted when the scope is closed.
+	if machine.DeletionTimestamp.IsZero() {
+		addFinalizer(machine, v1alpha1.MachineFinalizer)
+	}
+
+	// If the instance is gone, there is nothing to update, the machine has to be recreated.
+	if err := a.resetTerminatedInstance(ec2svc, scope, instanceDescription); err != nil {
+		return err
+	}
+
+	// Hibernate or resume the instance as requested by the machine annotation.
+	if err := a.ensureHibernation(ec2svc, scope, instanceDescription); err != nil {
+		if _, ok := err.(*controllerError.RequeueAfterError); ok {
+			return err
+		}
+		return errors.Errorf("failed to ensure hibernation: %+v", err)
+	}
+
+	// A stopped instance is only reconciled once it has been started again.
+	if isStopped(instanceDescription) {
+		return a.requeueStopped(scope, instanceDescription)
+	}
+
 	// We can now compare the various AWS state to the state we were passed.
 	// We will check immutable state first, in order to fail quickly before
 	// moving on to state that we can mutate.
-	if errs := a.isMachineOutdated(scope.MachineConfig, instanceDescription); len(errs) > 0 {
-		return errors.Errorf("found attempt to change immutable state for machine %q: %+q", machine.Name, errs)
+	errs := a.isMachineOutdated(scope.MachineConfig, scope.MachineStatus, instanceDescription)
+	setImmutableFieldsCondition(scope.MachineStatus, errs)
+	if len(errs) > 0 {
+		return errors.Wrapf(errs, "found attempt to change immutable state for machine %q", machine.Name)
+	}
+
+	// Flag the machine for replacement if its spec changed since the instance was launched.
+	if err := a.ensureSpecDriftCondition(scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to check machine spec drift: %+v", err)
+	}
+
+	if len(instanceDescription.NetworkInterfaceIDs) > 0 {
+		scope.MachineStatus.NetworkInterfaceIDs = instanceDescription.NetworkInterfaceIDs
+	}
+
+	a.reconcileProviderID(scope, instanceDescription)
+
+	if err := a.reconcileAccountID(scope); err != nil {
+		return errors.Errorf("failed to record AWS account ID: %+v", err)
 	}
 
-	existingSecurityGroups, err := ec2svc.GetInstanceSecurityGroups(*scope.MachineStatus.InstanceID)
+	primaryENI, existingSecurityGroups, err := ec2svc.GetInstanceSecurityGroups(*scope.MachineStatus.InstanceID)
 	if err != nil {
 		return err
 	}
@@ -383,7 +868,7 @@ func (a *Actuator) Update(ctx context.Context, cluster *clusterv1.Cluster, machi
 	_, err = a.ensureSecurityGroups(
 		ec2svc,
 		scope,
-		*scope.MachineStatus.InstanceID,
+		primaryENI,
 		scope.MachineConfig.AdditionalSecurityGroups,
 		existingSecurityGroups,
 	)
@@ -391,19 +876,84 @@ func (a *Actuator) Update(ctx context.Context, cluster *clusterv1.Cluster, machi
 		return errors.Errorf("failed to apply security groups: %+v", err)
 	}
 
-	// Ensure that the tags are correct.
-	_, err = a.ensureTags(ec2svc, machine, scope.MachineStatus.InstanceID, scope.MachineConfig.AdditionalTags)
-	if err != nil {
+	if err := a.validateComplianceScope(scope.MachineConfig.ComplianceScope); err != nil {
+		return err
+	}
+
+	// Ensure that the tags, including the volume encryption tags, are correct.
+	if err := a.reconcileTags(ec2svc, scope, instanceDescription); err != nil {
+		if _, ok := err.(*controllerError.RequeueAfterError); ok {
+			return err
+		}
 		return errors.Errorf("failed to ensure tags: %+v", err)
 	}
 
+	// Ensure that the credit specification is correct.
+	if err := a.ensureCreditSpecification(ec2svc, scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to ensure credit specification: %+v", err)
+	}
+
+	// Ensure that detailed monitoring is correct.
+	if err := a.ensureMonitoring(ec2svc, scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to ensure monitoring: %+v", err)
+	}
+
+	// Ensure that the source/destination check is correct.
+	if err := a.ensureSourceDestCheck(ec2svc, scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to ensure source/destination check: %+v", err)
+	}
+
+	// Ensure that the instance metadata options are correct.
+	if err := a.ensureInstanceMetadataOptions(ec2svc, scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to ensure instance metadata options: %+v", err)
+	}
+
+	// Let the Auto Scaling group carry on once the instance has bootstrapped.
+	if err := a.ensureLifecycleActionCompleted(autoscaling.NewService(scope.Scope), scope, instanceDescription); err != nil {
+		return errors.Errorf("failed to complete lifecycle action: %+v", err)
+	}
+
 	return nil
 }
 
+// resetTerminatedInstance clears the instance from the machine status and requeues
+// the machine if the machine's lookup didn't return the instance because EC2
+// reports it as shutting down or terminated, or no longer knows about it, so
+// that the machine controller notices it doesn't exist and creates a new one.
+// The status of an instance in any other state is kept, and the machine requeued.
+func (a *Actuator) resetTerminatedInstance(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if instance != nil {
+		return nil
+	}
+
+	current, err := svc.AnyInstanceIfExists(scope.MachineStatus.InstanceID)
+	if err != nil {
+		return errors.Errorf("failed to get instance: %+v", err)
+	}
+
+	if current != nil {
+		switch current.State {
+		case v1alpha1.InstanceStateShuttingDown, v1alpha1.InstanceStateTerminated:
+		default:
+			a.log.Info("Machine instance wasn't found in a state it can be updated in, requeuing",
+				"machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", current.ID, "state", current.State)
+			return a.requeueAfter(waitForInstanceRunningDuration)
+		}
+	}
+
+	a.log.Info("Machine instance no longer exists, clearing its status so it can be recreated",
+		"machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", aws.StringValue(scope.MachineStatus.InstanceID))
+
+	clearMachineInstance(scope)
+
+	return &controllerError.RequeueAfterError{RequeueAfter: waitForInstanceRecreateDuration}
+}
+
 // Exists test for the existence of a machine and is invoked by the Machine Controller
 func (a *Actuator) Exists(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (bool, error) {
-	if cluster == nil {
-		return false, errors.Errorf("missing cluster for machine %s/%s", machine.Namespace, machine.Name)
+	cluster, err := a.getCluster(cluster, machine)
+	if err != nil {
+		return false, err
 	}
 
 	a.log.Info("Checking if machine exists in cluster", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "cluster-name", cluster.Name)
@@ -422,36 +972,120 @@ func (a *Actuator) Exists(ctx context.Context, cluster *clusterv1.Cluster, machi
 		return false, nil
 	}
 
-	instance, err := ec2svc.InstanceIfExists(scope.MachineStatus.InstanceID)
+	instance, err := instanceIfExists(ec2svc, scope)
 	if err != nil {
 		return false, errors.Errorf("failed to retrieve instance: %+v", err)
 	}
 
+	return a.exists(a.log, scope, ec2svc, instance)
+}
+
+// exists returns true if the instance of a machine, as last described by AWS,
+// is pending or running, and reconciles the machine state that only depends
+// on the instance being there.
+func (a *Actuator) exists(log logr.Logger, scope *actuators.MachineScope, ec2svc *ec2.Service, instance *v1alpha1.Instance) (bool, error) {
+	machine := scope.Machine
+
 	if instance == nil {
 		return false, nil
 	}
 
-	a.log.Info("Found instance for machine", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "instance", instance)
+	log.Info("Found instance for machine", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "instance", instance)
+
+	if a.setMachinePhase {
+		// Deferred so that failures surfaced below are reflected in the phase.
+		defer setMachinePhase(scope, instance.State)
+	}
 
 	switch instance.State {
 	case v1alpha1.InstanceStateRunning:
-		a.log.Info("Machine instance is running", "instance-id", *scope.MachineStatus.InstanceID)
+		log.Info("Machine instance is running", "instance-id", *scope.MachineStatus.InstanceID)
 	case v1alpha1.InstanceStatePending:
-		a.log.Info("Machine instance is pending", "instance-id", *scope.MachineStatus.InstanceID)
+		log.Info("Machine instance is pending", "instance-id", *scope.MachineStatus.InstanceID)
+	case v1alpha1.InstanceStateStopping, v1alpha1.InstanceStateStopped:
+		// Only returned for machines that start or hibernate their stopped instance.
+		log.Info("Machine instance is stopped", "instance-id", *scope.MachineStatus.InstanceID, "state", instance.State)
+		if err := a.startStoppedInstance(ec2svc, scope, instance); err != nil {
+			return true, errors.Errorf("failed to start stopped instance: %+v", err)
+		}
+		scope.MachineStatus.InstanceState = &instance.State
+		return true, nil
 	default:
 		return false, nil
 	}
 
 	scope.MachineStatus.InstanceState = &instance.State
+	if scope.MachineStatus.LaunchTime == nil {
+		scope.MachineStatus.LaunchTime = instance.LaunchTime
+	}
+
+	if isLaunchTimedOut(scope, instance, time.Now()) {
+		// Surface the failure on the machine and stop here, a machine health
+		// check can then remediate the machine by deleting it.
+		log.Info("Machine instance exceeded its launch timeout", "instance-id", *scope.MachineStatus.InstanceID)
+		reason := common.CreateMachineError
+		message := fmt.Sprintf("instance %q has been pending for longer than the launch timeout of %v",
+			*scope.MachineStatus.InstanceID, scope.MachineConfig.LaunchTimeout.Duration)
+		scope.Machine.Status.ErrorReason = &reason
+		scope.Machine.Status.ErrorMessage = &message
+		a.reportConsoleOutput(log, ec2svc, scope)
+		return true, nil
+	}
 
-	if err := a.reconcileLBAttachment(scope, machine, instance); err != nil {
+	if err := a.reconcileLBAttachment(elb.NewService(scope.Scope), scope, machine, instance); err != nil {
 		return true, err
 	}
 
-	if machine.Spec.ProviderID == nil || *machine.Spec.ProviderID == "" {
-		providerID := fmt.Sprintf("aws:////%s", *scope.MachineStatus.InstanceID)
-		scope.Machine.Spec.ProviderID = &providerID
+	if err := a.reconcileAPIServerDNS(route53.NewService(scope.Scope), scope); err != nil {
+		return true, errors.Errorf("failed to reconcile API server DNS record: %+v", err)
 	}
 
+	if err := a.ensureSourceDestCheck(ec2svc, scope, instance); err != nil {
+		return true, errors.Errorf("failed to ensure source/destination check: %+v", err)
+	}
+
+	a.reconcileProviderID(scope, instance)
+
 	return true, nil
 }
+
+// requeueIfNotRunning returns a requeue error if the instance is still pending
+// after waiting for it up to the resource creation timeout set in the machine
+// configuration.
+func (a *Actuator) requeueIfNotRunning(scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if scope.MachineConfig.ResourceCreationTimeout == nil || instance.State != v1alpha1.InstanceStatePending {
+		return nil
+	}
+
+	return a.requeueAfter(waitForInstanceRunningDuration)
+}
+
+// waitForControlPlaneMachines requeues a machine while no control plane machine
+// exists. Once the machine has waited for longer than the control plane machine
+// existence timeout since it was created, it is marked as failed instead.
+func (a *Actuator) waitForControlPlaneMachines(log logr.Logger, scope *actuators.MachineScope, now time.Time) error {
+	created := scope.Machine.CreationTimestamp
+	if a.controlPlaneMachineExistenceTimeout > 0 && !created.IsZero() && now.After(created.Add(a.controlPlaneMachineExistenceTimeout)) {
+		log.Info("No control plane machines exist after the maximum wait - marking machine as failed", "timeout", a.controlPlaneMachineExistenceTimeout)
+		message := fmt.Sprintf("no control plane machine exists in the cluster after waiting for %v", a.controlPlaneMachineExistenceTimeout)
+		setTerminalFailure(scope, v1alpha1.NoControlPlaneMachineFailure, common.InvalidConfigurationMachineError, message)
+		return nil
+	}
+
+	log.Info("No control plane machines exist yet - requeuing")
+	return a.requeueAfter(a.controlPlaneMachineExistenceRequeue)
+}
+
+// isLaunchTimedOut returns true if the instance is still pending after the
+// launch timeout set in the machine configuration has elapsed.
+func isLaunchTimedOut(scope *actuators.MachineScope, instance *v1alpha1.Instance, now time.Time) bool {
+	if instance.State != v1alpha1.InstanceStatePending {
+		return false
+	}
+
+	if scope.MachineConfig.LaunchTimeout == nil || scope.MachineStatus.LaunchTime == nil {
+		return false
+	}
+
+	return now.After(scope.MachineStatus.LaunchTime.Add(scope.MachineConfig.LaunchTimeout.Duration))
+}
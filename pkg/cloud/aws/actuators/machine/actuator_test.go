@@ -17,15 +17,28 @@ limitations under the License.
 package machine
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"k8s.io/klog/klogr"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+	ec2svc "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	client "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
 	"sigs.k8s.io/cluster-api/pkg/controller/machine"
 )
 
@@ -311,6 +324,119 @@ func TestGetControlPlaneMachines(t *testing.T) {
 	}
 }
 
+func TestGetControlPlaneMachinesIncludingDeleting(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         *clusterv1.MachineList
+		expectedNames []string
+	}{
+		{
+			name: "0 machines",
+			input: &clusterv1.MachineList{
+				Items: []clusterv1.Machine{},
+			},
+			expectedNames: []string{},
+		},
+		{
+			name: "controlplane machine pending deletion is included",
+			input: &clusterv1.MachineList{
+				Items: []clusterv1.Machine{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "master-0",
+							Namespace: "awesome-ns",
+						},
+						Spec: clusterv1.MachineSpec{
+							Versions: clusterv1.MachineVersionInfo{
+								Kubelet:      "v1.13.0",
+								ControlPlane: "v1.13.0",
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "master-1",
+							Namespace: "awesome-ns",
+							DeletionTimestamp: &metav1.Time{
+								Time: time.Now(),
+							},
+						},
+						Spec: clusterv1.MachineSpec{
+							Versions: clusterv1.MachineVersionInfo{
+								Kubelet:      "v1.13.0",
+								ControlPlane: "v1.13.0",
+							},
+						},
+					},
+				},
+			},
+			expectedNames: []string{"master-0", "master-1"},
+		},
+		{
+			name: "only deleting controlplane machines",
+			input: &clusterv1.MachineList{
+				Items: []clusterv1.Machine{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "master-0",
+							Namespace: "awesome-ns",
+							DeletionTimestamp: &metav1.Time{
+								Time: time.Now(),
+							},
+						},
+						Spec: clusterv1.MachineSpec{
+							Versions: clusterv1.MachineVersionInfo{
+								Kubelet:      "v1.13.0",
+								ControlPlane: "v1.13.0",
+							},
+						},
+					},
+				},
+			},
+			expectedNames: []string{"master-0"},
+		},
+		{
+			name: "deleting worker machines are excluded",
+			input: &clusterv1.MachineList{
+				Items: []clusterv1.Machine{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "worker-0",
+							Namespace: "awesome-ns",
+							DeletionTimestamp: &metav1.Time{
+								Time: time.Now(),
+							},
+						},
+						Spec: clusterv1.MachineSpec{
+							Versions: clusterv1.MachineVersionInfo{
+								Kubelet: "v1.13.0",
+							},
+						},
+					},
+				},
+			},
+			expectedNames: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := GetControlPlaneMachinesIncludingDeleting(tc.input)
+			if len(actual) != len(tc.expectedNames) {
+				t.Fatalf("Unexpected number of controlplane machines returned. Got: %d, Want: %d", len(actual), len(tc.expectedNames))
+			}
+			for i, name := range tc.expectedNames {
+				if actual[i].Name != name {
+					t.Fatalf("Expected controlplane machine %q at index %d, got %q", name, i, actual[i].Name)
+				}
+			}
+			if excluding := GetControlPlaneMachines(tc.input); len(excluding) > len(actual) {
+				t.Fatalf("GetControlPlaneMachines returned more machines (%d) than the inclusive variant (%d)", len(excluding), len(actual))
+			}
+		})
+	}
+}
+
 func TestMachineEqual(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -386,12 +512,49 @@ func TestMachineEqual(t *testing.T) {
 
 func TestImmutableStateChange(t *testing.T) {
 	testCases := []struct {
-		name        string
-		machineSpec v1alpha1.AWSMachineProviderSpec
-		instance    v1alpha1.Instance
+		name          string
+		machineSpec   v1alpha1.AWSMachineProviderSpec
+		machineStatus v1alpha1.AWSMachineProviderStatus
+		instance      v1alpha1.Instance
 		// expected length of returned errors
 		expected int
 	}{
+		{
+			name: "ami is changed",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{ID: aws.String("ami-new")},
+			},
+			instance: v1alpha1.Instance{
+				ImageID: "ami-old",
+			},
+			expected: 1,
+		},
+		{
+			name: "resolved ami is unchanged",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				AMISSMParameter: "/aws/service/ami",
+			},
+			machineStatus: v1alpha1.AWSMachineProviderStatus{
+				AMIID: aws.String("ami-old"),
+			},
+			instance: v1alpha1.Instance{
+				ImageID: "ami-old",
+			},
+			expected: 0,
+		},
+		{
+			name: "resolved ami is changed",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				AMISSMParameter: "/aws/service/ami",
+			},
+			machineStatus: v1alpha1.AWSMachineProviderStatus{
+				AMIID: aws.String("ami-new"),
+			},
+			instance: v1alpha1.Instance{
+				ImageID: "ami-old",
+			},
+			expected: 1,
+		},
 		{
 			name: "instance type is unchanged",
 			machineSpec: v1alpha1.AWSMachineProviderSpec{
@@ -432,6 +595,54 @@ func TestImmutableStateChange(t *testing.T) {
 			},
 			expected: 1,
 		},
+		{
+			name: "iam profile is cleared",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				IAMInstanceProfile: "",
+			},
+			instance: v1alpha1.Instance{
+				IAMProfile: "test-profile",
+			},
+			expected: 0,
+		},
+		{
+			name: "iam profile is set",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				IAMInstanceProfile: "test-profile",
+			},
+			instance: v1alpha1.Instance{},
+			expected: 1,
+		},
+		{
+			name: "iam profile is unchanged when specified by arn",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				IAMInstanceProfile: "arn:aws:iam::123456789012:instance-profile/path/test-profile",
+			},
+			instance: v1alpha1.Instance{
+				IAMProfile: "path/test-profile",
+			},
+			expected: 0,
+		},
+		{
+			name: "iam profile is changed when specified by arn",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				IAMInstanceProfile: "arn:aws:iam::123456789012:instance-profile/test-profile-updated",
+			},
+			instance: v1alpha1.Instance{
+				IAMProfile: "test-profile",
+			},
+			expected: 1,
+		},
+		{
+			name: "secondary private ips are assigned",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				SecondaryPrivateIPCount: aws.Int64(2),
+			},
+			instance: v1alpha1.Instance{
+				SecondaryPrivateIPs: []string{"10.0.0.11", "10.0.0.12"},
+			},
+			expected: 0,
+		},
 		{
 			name: "keyname is unchanged",
 			machineSpec: v1alpha1.AWSMachineProviderSpec{
@@ -547,6 +758,34 @@ func TestImmutableStateChange(t *testing.T) {
 			},
 			expected: 1,
 		},
+		{
+			name:        "tenancy is omitted",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{},
+			instance: v1alpha1.Instance{
+				Tenancy: "default",
+			},
+			expected: 0,
+		},
+		{
+			name: "tenancy is unchanged",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				Tenancy: "dedicated",
+			},
+			instance: v1alpha1.Instance{
+				Tenancy: "dedicated",
+			},
+			expected: 0,
+		},
+		{
+			name: "tenancy is changed",
+			machineSpec: v1alpha1.AWSMachineProviderSpec{
+				Tenancy: "dedicated",
+			},
+			instance: v1alpha1.Instance{
+				Tenancy: "default",
+			},
+			expected: 1,
+		},
 		{
 			name: "multiple immutable changes",
 			machineSpec: v1alpha1.AWSMachineProviderSpec{
@@ -565,7 +804,7 @@ func TestImmutableStateChange(t *testing.T) {
 	testActuator := NewActuator(ActuatorParams{})
 
 	for _, tc := range testCases {
-		changed := len(testActuator.isMachineOutdated(&tc.machineSpec, &tc.instance))
+		changed := len(testActuator.isMachineOutdated(&tc.machineSpec, &tc.machineStatus, &tc.instance))
 
 		if tc.expected != changed {
 			t.Fatalf("[%s] Expected MachineSpec [%+v], NOT Equal Instance [%+v]",
@@ -649,10 +888,811 @@ func TestIsNodeJoin(t *testing.T) {
 	}
 }
 
-type fakeControlPlaneInitLocker struct {
-	succeed bool
+func TestRequeueIntervals(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        ActuatorParams
+		expectRequeue time.Duration
+		expectTTL     time.Duration
+	}{
+		{
+			name:          "defaults",
+			expectRequeue: waitForControlPlaneMachineExistenceDuration,
+			expectTTL:     defaultTokenTTL,
+		},
+		{
+			name: "configured",
+			params: ActuatorParams{
+				ControlPlaneMachineExistenceRequeue: time.Minute,
+				BootstrapTokenTTL:                   time.Hour,
+			},
+			expectRequeue: time.Minute,
+			expectTTL:     time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.ControlPlaneInitLocker = &fakeControlPlaneInitLocker{}
+			a := NewActuator(tc.params)
+
+			if a.bootstrapTokenTTL != tc.expectTTL {
+				t.Errorf("expected bootstrap token TTL %v, got %v", tc.expectTTL, a.bootstrapTokenTTL)
+			}
+
+			_, err := a.isNodeJoin(klogr.New(), &clusterv1.Cluster{}, &clusterv1.Machine{})
+			requeue, ok := err.(*controllerError.RequeueAfterError)
+			if !ok {
+				t.Fatalf("expected a requeue, got %v", err)
+			}
+			if requeue.RequeueAfter != tc.expectRequeue {
+				t.Errorf("expected requeue after %v, got %v", tc.expectRequeue, requeue.RequeueAfter)
+			}
+		})
+	}
 }
 
-func (f *fakeControlPlaneInitLocker) Acquire(cluster *clusterv1.Cluster) bool {
-	return f.succeed
+func TestWaitForControlPlaneMachines(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		created       time.Time
+		expectFailure bool
+	}{
+		{
+			name:    "no timeout",
+			created: now.Add(-24 * time.Hour),
+		},
+		{
+			name:    "within the timeout",
+			timeout: time.Hour,
+			created: now.Add(-30 * time.Minute),
+		},
+		{
+			name:    "creation time unknown",
+			timeout: time.Hour,
+		},
+		{
+			name:          "timeout exceeded",
+			timeout:       time.Hour,
+			created:       now.Add(-2 * time.Hour),
+			expectFailure: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default", CreationTimestamp: metav1.NewTime(tc.created)},
+				},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := NewActuator(ActuatorParams{
+				ControlPlaneInitLocker:              &fakeControlPlaneInitLocker{},
+				ControlPlaneMachineExistenceTimeout: tc.timeout,
+			})
+			err := a.waitForControlPlaneMachines(klogr.New(), scope, now)
+
+			if !tc.expectFailure {
+				if _, ok := err.(*controllerError.RequeueAfterError); !ok {
+					t.Fatalf("expected a requeue, got %v", err)
+				}
+				if scope.MachineStatus.FailureReason != nil {
+					t.Fatalf("did not expect a failure, got %q", *scope.MachineStatus.FailureReason)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if scope.MachineStatus.FailureReason == nil || *scope.MachineStatus.FailureReason != v1alpha1.NoControlPlaneMachineFailure {
+				t.Fatalf("expected failure reason %q, got %v", v1alpha1.NoControlPlaneMachineFailure, scope.MachineStatus.FailureReason)
+			}
+			if scope.Machine.Status.ErrorReason == nil || *scope.Machine.Status.ErrorReason != common.InvalidConfigurationMachineError {
+				t.Fatalf("expected machine error reason %q, got %v", common.InvalidConfigurationMachineError, scope.Machine.Status.ErrorReason)
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor float64
+		min    time.Duration
+		max    time.Duration
+	}{
+		{
+			name: "no jitter",
+			min:  10 * time.Second,
+			max:  10 * time.Second,
+		},
+		{
+			name:   "jitter within the factor",
+			factor: 0.2,
+			min:    8 * time.Second,
+			max:    12 * time.Second,
+		},
+		{
+			name:   "factor is capped",
+			factor: 5,
+			min:    0,
+			max:    20 * time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := jitter(10*time.Second, tc.factor); got < tc.min || got > tc.max {
+					t.Fatalf("expected a duration between %v and %v, got %v", tc.min, tc.max, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsLaunchTimedOut(t *testing.T) {
+	now := time.Now()
+	launched := metav1.NewTime(now.Add(-10 * time.Minute))
+
+	tests := []struct {
+		name          string
+		launchTimeout *metav1.Duration
+		launchTime    *metav1.Time
+		state         v1alpha1.InstanceState
+		expected      bool
+	}{
+		{
+			name:       "no launch timeout",
+			launchTime: &launched,
+			state:      v1alpha1.InstanceStatePending,
+			expected:   false,
+		},
+		{
+			name:          "no launch time",
+			launchTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			state:         v1alpha1.InstanceStatePending,
+			expected:      false,
+		},
+		{
+			name:          "pending within the launch timeout",
+			launchTimeout: &metav1.Duration{Duration: 15 * time.Minute},
+			launchTime:    &launched,
+			state:         v1alpha1.InstanceStatePending,
+			expected:      false,
+		},
+		{
+			name:          "pending past the launch timeout",
+			launchTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			launchTime:    &launched,
+			state:         v1alpha1.InstanceStatePending,
+			expected:      true,
+		},
+		{
+			name:          "running past the launch timeout",
+			launchTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			launchTime:    &launched,
+			state:         v1alpha1.InstanceStateRunning,
+			expected:      false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{LaunchTimeout: tc.launchTimeout},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{LaunchTime: tc.launchTime},
+			}
+
+			actual := isLaunchTimedOut(scope, &v1alpha1.Instance{State: tc.state}, now)
+			if tc.expected != actual {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRequeueIfNotRunning(t *testing.T) {
+	tests := []struct {
+		name                    string
+		resourceCreationTimeout *metav1.Duration
+		state                   v1alpha1.InstanceState
+		expectRequeue           bool
+	}{
+		{
+			name:  "pending without a resource creation timeout",
+			state: v1alpha1.InstanceStatePending,
+		},
+		{
+			name:                    "pending past the resource creation timeout",
+			resourceCreationTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			state:                   v1alpha1.InstanceStatePending,
+			expectRequeue:           true,
+		},
+		{
+			name:                    "running within the resource creation timeout",
+			resourceCreationTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+			state:                   v1alpha1.InstanceStateRunning,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{ResourceCreationTimeout: tc.resourceCreationTimeout},
+			}
+
+			err := (&Actuator{}).requeueIfNotRunning(scope, &v1alpha1.Instance{State: tc.state})
+			if !tc.expectRequeue {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				return
+			}
+			if _, ok := err.(*controllerError.RequeueAfterError); !ok {
+				t.Fatalf("expected a requeue, got %v", err)
+			}
+		})
+	}
+}
+
+func TestResetTerminatedInstance(t *testing.T) {
+	describeInstance := func(state string) func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+		return func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+			m.DescribeInstances(&ec2.DescribeInstancesInput{
+				InstanceIds: []*string{aws.String("i-1")},
+				Filters: []*ec2.Filter{
+					{Name: aws.String("vpc-id"), Values: []*string{aws.String("vpc-1")}},
+				},
+			}).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{
+						Instances: []*ec2.Instance{
+							{
+								InstanceId:   aws.String("i-1"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+								State:        &ec2.InstanceState{Name: aws.String(state)},
+							},
+						},
+					},
+				},
+			}, nil)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		instance      *v1alpha1.Instance
+		expect        func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectRequeue bool
+		expectError   bool
+		expectCleared bool
+	}{
+		{
+			name:          "instance is terminated",
+			expect:        describeInstance(ec2.InstanceStateNameTerminated),
+			expectRequeue: true,
+			expectCleared: true,
+		},
+		{
+			name:          "instance is shutting down",
+			expect:        describeInstance(ec2.InstanceStateNameShuttingDown),
+			expectRequeue: true,
+			expectCleared: true,
+		},
+		{
+			name: "instance no longer exists",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Any()).Return(nil, awserr.New(awserrors.InvalidInstanceIDNotFound, "not found", nil))
+			},
+			expectRequeue: true,
+			expectCleared: true,
+		},
+		{
+			name:          "instance is stopped",
+			expect:        describeInstance(ec2.InstanceStateNameStopped),
+			expectRequeue: true,
+		},
+		{
+			name:          "instance was missed by the lookup",
+			expect:        describeInstance(ec2.InstanceStateNameRunning),
+			expectRequeue: true,
+		},
+		{
+			name: "instance lookup fails",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.Any()).Return(nil, awserr.New(awserrors.RequestLimitExceeded, "slow down", nil))
+			},
+			expectError: true,
+		},
+		{
+			name:     "instance is running",
+			instance: &v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			clusterScope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{EC2: ec2Mock},
+			})
+			if err != nil {
+				t.Fatalf("failed to create test context: %v", err)
+			}
+			clusterScope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{VPC: v1alpha1.VPCSpec{ID: "vpc-1"}},
+			}
+
+			state := v1alpha1.InstanceStateRunning
+			httpTokens := v1alpha1.HTTPTokensStateRequired
+			launchTime := metav1.Now()
+			scope := &actuators.MachineScope{
+				Scope: clusterScope,
+				Machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						ProviderID: aws.String("aws:////i-1"),
+					},
+				},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{
+					InstanceID:          aws.String("i-1"),
+					InstanceState:       &state,
+					AMIID:               aws.String("ami-1"),
+					SubnetID:            aws.String("subnet-1"),
+					LaunchTime:          &launchTime,
+					NetworkInterfaceIDs: []string{"eni-1"},
+					TargetGroupARNs:     []string{"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/1"},
+					MonitoringState:     aws.String("enabled"),
+					HTTPTokens:          &httpTokens,
+					AccountID:           aws.String("123456789012"),
+					ConsoleOutput:       aws.String("console"),
+				},
+			}
+
+			a := NewActuator(ActuatorParams{})
+			err = a.resetTerminatedInstance(ec2svc.NewService(clusterScope), scope, tc.instance)
+
+			switch {
+			case tc.expectError:
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if _, ok := err.(*controllerError.RequeueAfterError); ok {
+					t.Fatalf("expected a lookup error, got a requeue")
+				}
+			case tc.expectRequeue:
+				if _, ok := err.(*controllerError.RequeueAfterError); !ok {
+					t.Fatalf("expected a requeue error, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if !tc.expectCleared {
+				if scope.MachineStatus.InstanceID == nil || scope.Machine.Spec.ProviderID == nil {
+					t.Fatal("expected the instance to be kept in the machine")
+				}
+				return
+			}
+
+			// Only the conditions and the launch generation outlive the instance.
+			expected := &v1alpha1.AWSMachineProviderStatus{
+				Conditions:       scope.MachineStatus.Conditions,
+				LaunchGeneration: 1,
+			}
+			if !reflect.DeepEqual(scope.MachineStatus, expected) {
+				t.Errorf("expected the instance to be cleared from the status, got %+v", scope.MachineStatus)
+			}
+			if scope.Machine.Spec.ProviderID != nil {
+				t.Errorf("expected the provider ID to be cleared, got %q", *scope.Machine.Spec.ProviderID)
+			}
+		})
+	}
+}
+
+func TestGetCluster(t *testing.T) {
+	existing := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "default",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		cluster       *clusterv1.Cluster
+		machine       *clusterv1.Machine
+		clusterClient client.ClusterV1alpha1Interface
+		expectError   bool
+		expected      *clusterv1.Cluster
+	}{
+		{
+			name:    "cluster is passed in",
+			cluster: existing,
+			machine: &clusterv1.Machine{},
+			// A nil client would panic if it were used.
+			expected: existing,
+		},
+		{
+			name:    "nil cluster is looked up from the machine label",
+			cluster: nil,
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.MachineClusterLabelName: "existing"},
+				},
+			},
+			clusterClient: &fakeClusterV1alpha1{clusters: []*clusterv1.Cluster{existing}},
+			expected:      existing,
+		},
+		{
+			name:    "nil cluster without a machine label",
+			cluster: nil,
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			},
+			clusterClient: &fakeClusterV1alpha1{clusters: []*clusterv1.Cluster{existing}},
+			expectError:   true,
+		},
+		{
+			name:    "nil cluster without a cluster client",
+			cluster: nil,
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.MachineClusterLabelName: "existing"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:    "nil cluster that does not exist",
+			cluster: nil,
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.MachineClusterLabelName: "missing"},
+				},
+			},
+			clusterClient: &fakeClusterV1alpha1{clusters: []*clusterv1.Cluster{existing}},
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := NewActuator(ActuatorParams{ClusterClient: tc.clusterClient})
+
+			actual, err := a.getCluster(tc.cluster, tc.machine)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual != tc.expected {
+				t.Errorf("expected cluster %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+type fakeClusterV1alpha1 struct {
+	client.ClusterV1alpha1Interface
+	clusters []*clusterv1.Cluster
+}
+
+func (f *fakeClusterV1alpha1) Clusters(namespace string) client.ClusterInterface {
+	return &fakeClusterClient{namespace: namespace, clusters: f.clusters}
+}
+
+type fakeClusterClient struct {
+	client.ClusterInterface
+	namespace string
+	clusters  []*clusterv1.Cluster
+}
+
+func (f *fakeClusterClient) Get(name string, options metav1.GetOptions) (*clusterv1.Cluster, error) {
+	for _, c := range f.clusters {
+		if c.Namespace == f.namespace && c.Name == name {
+			return c, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(clusterv1.SchemeGroupVersion.WithResource("clusters").GroupResource(), name)
+}
+
+type fakeControlPlaneInitLocker struct {
+	succeed bool
+}
+
+func (f *fakeControlPlaneInitLocker) Acquire(cluster *clusterv1.Cluster) bool {
+	return f.succeed
+}
+
+func TestReconcileLBAttachment(t *testing.T) {
+	testCases := []struct {
+		name   string
+		labels map[string]string
+		expect func(m *mocks.MockELBInterfaceMockRecorder)
+	}{
+		{
+			name:   "control plane instance",
+			labels: map[string]string{"set": "controlplane"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				gomock.InOrder(
+					m.ReconcileAPIServerListeners().Return(nil),
+					m.RegisterInstanceWithAPIServerELB("i-1").Return(nil),
+				)
+			},
+		},
+		{
+			name:   "node instance",
+			labels: map[string]string{"set": "node"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockELBInterface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tc.labels}}
+			scope := &actuators.MachineScope{
+				Machine:       machine,
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.reconcileLBAttachment(svc, scope, machine, &v1alpha1.Instance{ID: "i-1"}); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeregisterLBAttachment(t *testing.T) {
+	testCases := []struct {
+		name           string
+		labels         map[string]string
+		instanceIDs    []string
+		expect         func(m *mocks.MockELBInterfaceMockRecorder)
+		expectDraining []string
+	}{
+		{
+			name:        "control plane instances",
+			labels:      map[string]string{"set": "controlplane"},
+			instanceIDs: []string{"i-1", "i-2"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithAPIServerELB([]string{"i-1", "i-2"}).Return([]string{"i-1", "i-2"}, nil)
+				m.DeregisterInstancesFromAPIServerELB([]string{"i-1", "i-2"}).Return(nil)
+				m.InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2"}, []string{"tg-1"}).Return([]string{"i-1", "i-2"}, nil)
+				m.DeregisterInstanceFromTargetGroups("i-1", []string{"tg-1"}).Return(nil)
+				m.DeregisterInstanceFromTargetGroups("i-2", []string{"tg-1"}).Return(nil)
+			},
+			expectDraining: []string{"i-1", "i-2"},
+		},
+		{
+			name:        "control plane instance drained from the load balancer only",
+			labels:      map[string]string{"set": "controlplane"},
+			instanceIDs: []string{"i-1", "i-2"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithAPIServerELB([]string{"i-1", "i-2"}).Return(nil, nil)
+				m.DeregisterInstancesFromAPIServerELB(nil).Return(nil)
+				m.InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2"}, []string{"tg-1"}).Return([]string{"i-2"}, nil)
+				m.DeregisterInstanceFromTargetGroups("i-2", []string{"tg-1"}).Return(nil)
+			},
+			expectDraining: []string{"i-2"},
+		},
+		{
+			name:        "node instances",
+			labels:      map[string]string{"set": "node"},
+			instanceIDs: []string{"i-1", "i-2"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2"}, []string{"tg-1"}).Return([]string{"i-1", "i-2"}, nil)
+				m.DeregisterInstanceFromTargetGroups("i-1", []string{"tg-1"}).Return(nil)
+				m.DeregisterInstanceFromTargetGroups("i-2", []string{"tg-1"}).Return(nil)
+			},
+			expectDraining: []string{"i-1", "i-2"},
+		},
+		{
+			name:        "drained node instances",
+			labels:      map[string]string{"set": "node"},
+			instanceIDs: []string{"i-1", "i-2"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2"}, []string{"tg-1"}).Return(nil, nil)
+			},
+		},
+		{
+			name:   "no instances to terminate",
+			labels: map[string]string{"set": "controlplane"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockELBInterface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tc.labels}}
+			scope := &actuators.MachineScope{
+				Machine:       machine,
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{TargetGroupARNs: []string{"tg-1"}},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			draining, err := a.deregisterLBAttachment(svc, scope, machine, tc.instanceIDs)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !reflect.DeepEqual(draining, tc.expectDraining) {
+				t.Fatalf("expected draining instances %v, got %v", tc.expectDraining, draining)
+			}
+		})
+	}
+}
+
+func TestTerminateInstances(t *testing.T) {
+	testCases := []struct {
+		name          string
+		labels        map[string]string
+		expectEC2     func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectELB     func(m *mocks.MockELBInterfaceMockRecorder)
+		expectRequeue bool
+	}{
+		{
+			name:   "drained instances are terminated",
+			labels: map[string]string{"set": "controlplane"},
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning}, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return([]*v1alpha1.Instance{
+					{ID: "i-1", State: v1alpha1.InstanceStateRunning},
+					{ID: "i-2", State: v1alpha1.InstanceStatePending},
+				}, nil)
+				m.TerminateInstance("i-1").Return(nil)
+				m.TerminateInstance("i-2").Return(nil)
+			},
+			expectELB: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithAPIServerELB([]string{"i-1", "i-2"}).Return(nil, nil)
+				m.DeregisterInstancesFromAPIServerELB(nil).Return(nil)
+			},
+			expectRequeue: true,
+		},
+		{
+			name:   "instances draining from the load balancer are not terminated yet",
+			labels: map[string]string{"set": "controlplane"},
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning}, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return(nil, nil)
+			},
+			expectELB: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.InstancesRegisteredWithAPIServerELB([]string{"i-1"}).Return([]string{"i-1"}, nil)
+				m.DeregisterInstancesFromAPIServerELB([]string{"i-1"}).Return(nil)
+			},
+			expectRequeue: true,
+		},
+		{
+			name: "shutting down instance is waited for",
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateShuttingDown}, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return([]*v1alpha1.Instance{
+					{ID: "i-1", State: v1alpha1.InstanceStateShuttingDown},
+				}, nil)
+			},
+			expectELB:     func(m *mocks.MockELBInterfaceMockRecorder) {},
+			expectRequeue: true,
+		},
+		{
+			name: "shutting down instance only found by tags is waited for",
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(nil, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return([]*v1alpha1.Instance{
+					{ID: "i-2", State: v1alpha1.InstanceStateShuttingDown},
+				}, nil)
+			},
+			expectELB:     func(m *mocks.MockELBInterfaceMockRecorder) {},
+			expectRequeue: true,
+		},
+		{
+			name: "terminated instance is done",
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateTerminated}, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return(nil, nil)
+			},
+			expectELB: func(m *mocks.MockELBInterfaceMockRecorder) {},
+		},
+		{
+			name: "instance no longer exists",
+			expectEC2: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.AnyInstanceIfExists(aws.String("i-1")).Return(nil, nil)
+				m.UnterminatedInstanceByTags(gomock.Any()).Return(nil, nil)
+			},
+			expectELB: func(m *mocks.MockELBInterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2svc := mocks.NewMockEC2Interface(mockCtrl)
+			elbsvc := mocks.NewMockELBInterface(mockCtrl)
+			tc.expectEC2(ec2svc.EXPECT())
+			tc.expectELB(elbsvc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine:       &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tc.labels}},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			err := a.terminateInstances(ec2svc, elbsvc, scope)
+			if !tc.expectRequeue {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				return
+			}
+			if _, ok := err.(*controllerError.RequeueAfterError); !ok {
+				t.Fatalf("expected a requeue error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateAddsFinalizer(t *testing.T) {
+	scope := &actuators.MachineScope{
+		Machine:       &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+		MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	svc := mocks.NewMockEC2Interface(mockCtrl)
+	svc.EXPECT().AnyInstanceIfExists(nil).Return(nil, nil)
+
+	a := &Actuator{log: klogr.New()}
+	// The instance is gone, so the update stops at requeueing its recreation.
+	if _, ok := a.update(scope, svc, nil).(*controllerError.RequeueAfterError); !ok {
+		t.Fatal("expected a requeue error")
+	}
+	if !reflect.DeepEqual(scope.Machine.Finalizers, []string{v1alpha1.MachineFinalizer}) {
+		t.Errorf("expected the finalizer to be added, got %v", scope.Machine.Finalizers)
+	}
 }
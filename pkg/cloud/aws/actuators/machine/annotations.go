@@ -41,6 +41,9 @@ func (a *Actuator) updateMachineAnnotationJSON(machine *clusterv1.Machine, annot
 func (a *Actuator) updateMachineAnnotation(machine *clusterv1.Machine, annotation string, content string) {
 	// Get the annotations
 	annotations := machine.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
 
 	// Set our annotation to the given content.
 	annotations[annotation] = content
@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// consoleOutputTailLines is the number of lines at the end of the console
+// output of an instance that are reported when it fails to launch.
+const consoleOutputTailLines = 50
+
+// reportConsoleOutput logs the tail of the console output of the machine's
+// instance, which holds the cloud-init output of broken user data, and records
+// it on the machine status if enabled. It is best effort, errors are only
+// logged.
+func (a *Actuator) reportConsoleOutput(log logr.Logger, svc service.EC2MachineInterface, scope *actuators.MachineScope) {
+	id := aws.StringValue(scope.MachineStatus.InstanceID)
+	output, err := svc.GetConsoleOutput(id)
+	if err != nil {
+		log.Error(err, "Failed to get console output of machine instance", "instance-id", id)
+		return
+	}
+
+	tail := consoleOutputTail(output, consoleOutputTailLines)
+	log.Info("Console output of machine instance", "instance-id", id, "output", tail)
+
+	if a.recordConsoleOutput {
+		scope.MachineStatus.ConsoleOutput = aws.String(tail)
+	}
+}
+
+// consoleOutputTail returns at most the given number of lines at the end of
+// the console output.
+func consoleOutputTail(output string, lines int) string {
+	output = strings.TrimRight(output, "\n")
+	parts := strings.Split(output, "\n")
+	if len(parts) <= lines {
+		return output
+	}
+	return strings.Join(parts[len(parts)-lines:], "\n")
+}
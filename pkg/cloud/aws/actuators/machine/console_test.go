@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestConsoleOutputTail(t *testing.T) {
+	testCases := []struct {
+		name   string
+		output string
+		lines  int
+		expect string
+	}{
+		{
+			name:   "empty output",
+			lines:  2,
+			expect: "",
+		},
+		{
+			name:   "output shorter than the tail",
+			output: "a\nb\n",
+			lines:  3,
+			expect: "a\nb",
+		},
+		{
+			name:   "output longer than the tail",
+			output: "a\nb\nc\nd\n",
+			lines:  2,
+			expect: "c\nd",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tail := consoleOutputTail(tc.output, tc.lines); tail != tc.expect {
+				t.Fatalf("expected tail %q, got %q", tc.expect, tail)
+			}
+		})
+	}
+}
+
+func TestReportConsoleOutput(t *testing.T) {
+	output := strings.Repeat("boot\n", consoleOutputTailLines) + "cloud-init failed\n"
+
+	testCases := []struct {
+		name                string
+		recordConsoleOutput bool
+		expect              func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectRecorded      *string
+	}{
+		{
+			name: "console output is only logged",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput("i-1").Return(output, nil)
+			},
+		},
+		{
+			name:                "console output is recorded",
+			recordConsoleOutput: true,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput("i-1").Return(output, nil)
+			},
+			expectRecorded: aws.String(strings.Repeat("boot\n", consoleOutputTailLines-1) + "cloud-init failed"),
+		},
+		{
+			name:                "console output is unavailable",
+			recordConsoleOutput: true,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.GetConsoleOutput("i-1").Return("", errors.New("unavailable"))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+			}
+
+			a := &Actuator{recordConsoleOutput: tc.recordConsoleOutput}
+			a.reportConsoleOutput(klogr.New(), svc, scope)
+
+			if aws.StringValue(scope.MachineStatus.ConsoleOutput) != aws.StringValue(tc.expectRecorded) {
+				t.Fatalf("expected console output %q to be recorded, got %q", aws.StringValue(tc.expectRecorded), aws.StringValue(scope.MachineStatus.ConsoleOutput))
+			}
+		})
+	}
+}
@@ -64,6 +64,7 @@ func (l *controlPlaneInitLocker) Acquire(cluster *clusterv1.Cluster) bool {
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cluster.Namespace,
 			Name:      configMapName,
+			Labels:    actuators.ControlPlaneLockLabels(cluster),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: cluster.APIVersion,
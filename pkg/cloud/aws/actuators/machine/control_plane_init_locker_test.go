@@ -17,6 +17,7 @@ limitations under the License.
 package machine
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
@@ -89,6 +91,30 @@ func TestControlPlaneInitLockerAcquire(t *testing.T) {
 	}
 }
 
+func TestControlPlaneInitLockerLabels(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "name1",
+			UID:       types.UID("uid1"),
+		},
+	}
+
+	configMaps := &memoryConfigMaps{}
+	l := newControlPlaneInitLocker(klogr.New(), configMaps)
+	if !l.Acquire(cluster) {
+		t.Fatal("expected the lock to be acquired")
+	}
+
+	expected := map[string]string{
+		clusterv1.MachineClusterLabelName:   "name1",
+		actuators.ControlPlaneLockLabelName: "true",
+	}
+	if !reflect.DeepEqual(configMaps.configMap.Labels, expected) {
+		t.Fatalf("expected labels %v, got %v", expected, configMaps.configMap.Labels)
+	}
+}
+
 type configMapsGetter struct {
 	configMap   *v1.ConfigMap
 	getError    error
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/go-logr/logr"
+	apicorev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	client "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
+)
+
+// joiningMachineKey is the key of the control plane join configmap lock holding
+// the name of the machine currently joining the control plane.
+const joiningMachineKey = "joining-machine"
+
+// ControlPlaneJoinLocker provides a locking mechanism to join control plane machines one at a time.
+type ControlPlaneJoinLocker interface {
+	// Acquire returns true if the machine holds the join lock for the cluster.
+	Acquire(cluster *clusterv1.Cluster, machine *clusterv1.Machine) bool
+}
+
+// controlPlaneJoinLocker uses a ConfigMap to serialize control plane joins. The lock
+// is held until the machine holding it has joined the cluster as a node, is deleted,
+// or no longer exists.
+type controlPlaneJoinLocker struct {
+	log             logr.Logger
+	configMapClient corev1.ConfigMapsGetter
+	machineClient   client.MachinesGetter
+}
+
+var _ ControlPlaneJoinLocker = &controlPlaneJoinLocker{}
+
+func newControlPlaneJoinLocker(log logr.Logger, configMapClient corev1.ConfigMapsGetter, machineClient client.MachinesGetter) *controlPlaneJoinLocker {
+	return &controlPlaneJoinLocker{
+		log:             log,
+		configMapClient: configMapClient,
+		machineClient:   machineClient,
+	}
+}
+
+func (l *controlPlaneJoinLocker) Acquire(cluster *clusterv1.Cluster, machine *clusterv1.Machine) bool {
+	configMapName := actuators.ControlPlaneJoinConfigMapName(cluster)
+	log := l.log.WithValues("namespace", cluster.Namespace, "cluster-name", cluster.Name, "configmap-name", configMapName, "machine-name", machine.Name)
+
+	configMap, err := l.configMapClient.ConfigMaps(cluster.Namespace).Get(configMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		configMap = &apicorev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      configMapName,
+				Labels:    actuators.ControlPlaneLockLabels(cluster),
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: cluster.APIVersion,
+						Kind:       cluster.Kind,
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					},
+				},
+			},
+			Data: map[string]string{joiningMachineKey: machine.Name},
+		}
+
+		log.Info("Attempting to create control plane join configmap lock")
+		if _, err := l.configMapClient.ConfigMaps(cluster.Namespace).Create(configMap); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Someone else beat us to it
+				log.Info("Control plane join configmap lock already exists")
+			} else {
+				log.Error(err, "Error creating control plane join configmap lock")
+			}
+			return false
+		}
+		return true
+	} else if err != nil {
+		log.Error(err, "Error getting control plane join configmap lock")
+		return false
+	}
+
+	holder := configMap.Data[joiningMachineKey]
+	if holder == machine.Name {
+		return true
+	}
+
+	released, err := l.released(cluster.Namespace, holder)
+	if err != nil {
+		log.Error(err, "Error checking the machine holding the control plane join configmap lock", "holder", holder)
+		return false
+	}
+	if !released {
+		log.Info("Another control plane machine is joining", "holder", holder)
+		return false
+	}
+
+	// The resource version of the configmap ensures only one machine takes over the lock.
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[joiningMachineKey] = machine.Name
+
+	log.Info("Attempting to take over control plane join configmap lock", "previous-holder", holder)
+	if _, err := l.configMapClient.ConfigMaps(cluster.Namespace).Update(configMap); err != nil {
+		if apierrors.IsConflict(err) {
+			log.Info("Control plane join configmap lock was taken over by another machine")
+		} else {
+			log.Error(err, "Error updating control plane join configmap lock")
+		}
+		return false
+	}
+
+	return true
+}
+
+// released returns true if the named machine no longer needs the join lock.
+func (l *controlPlaneJoinLocker) released(namespace, name string) (bool, error) {
+	if name == "" {
+		return true, nil
+	}
+
+	holder, err := l.machineClient.Machines(namespace).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return !holder.DeletionTimestamp.IsZero() || holder.Status.NodeRef != nil, nil
+}
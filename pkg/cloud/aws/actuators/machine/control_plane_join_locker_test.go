@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	client "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
+)
+
+func TestControlPlaneJoinLockerAcquire(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "name1",
+			UID:       "uid1",
+		},
+	}
+	first := newJoiningMachine("first")
+	second := newJoiningMachine("second")
+
+	configMaps := &memoryConfigMaps{}
+	machines := &memoryMachines{machines: map[string]*clusterv1.Machine{
+		first.Name:  first,
+		second.Name: second,
+	}}
+	l := newControlPlaneJoinLocker(klogr.New(), configMaps, machines)
+
+	if !l.Acquire(cluster, first) {
+		t.Fatal("expected the first machine to acquire the lock")
+	}
+	if labels := configMaps.configMap.Labels; labels[clusterv1.MachineClusterLabelName] != cluster.Name || labels[actuators.ControlPlaneLockLabelName] != "true" {
+		t.Fatalf("expected the lock to be labeled with the cluster, got %v", labels)
+	}
+	if l.Acquire(cluster, second) {
+		t.Fatal("expected the second machine not to acquire the lock while the first one is joining")
+	}
+	if !l.Acquire(cluster, first) {
+		t.Fatal("expected the first machine to still hold the lock")
+	}
+
+	first.Status.NodeRef = &v1.ObjectReference{Name: "first-node"}
+	if !l.Acquire(cluster, second) {
+		t.Fatal("expected the second machine to acquire the lock once the first one joined")
+	}
+	if l.Acquire(cluster, newJoiningMachine("third")) {
+		t.Fatal("expected a third machine not to acquire the lock while the second one is joining")
+	}
+}
+
+func TestControlPlaneJoinLockerAcquireReleasedHolder(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "name1",
+			UID:       "uid1",
+		},
+	}
+	deleted := newJoiningMachine("deleted")
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+
+	tests := []struct {
+		name     string
+		holder   string
+		machines map[string]*clusterv1.Machine
+	}{
+		{
+			name:     "holder no longer exists",
+			holder:   "gone",
+			machines: map[string]*clusterv1.Machine{},
+		},
+		{
+			name:     "holder is being deleted",
+			holder:   "deleted",
+			machines: map[string]*clusterv1.Machine{"deleted": deleted},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configMaps := &memoryConfigMaps{
+				configMap: &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cluster.Namespace,
+						Name:      "uid1-controlplane-join",
+					},
+					Data: map[string]string{joiningMachineKey: tc.holder},
+				},
+			}
+			l := newControlPlaneJoinLocker(klogr.New(), configMaps, &memoryMachines{machines: tc.machines})
+
+			if !l.Acquire(cluster, newJoiningMachine("next")) {
+				t.Fatal("expected the lock to be acquired")
+			}
+			if configMaps.configMap.Data[joiningMachineKey] != "next" {
+				t.Fatalf("expected the lock to be held by %q, got %q", "next", configMaps.configMap.Data[joiningMachineKey])
+			}
+		})
+	}
+}
+
+func newJoiningMachine(name string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      name,
+			Labels:    map[string]string{"set": "controlplane"},
+		},
+	}
+}
+
+// memoryConfigMaps stores a single configmap in memory.
+type memoryConfigMaps struct {
+	corev1client.ConfigMapInterface
+	configMap *v1.ConfigMap
+}
+
+func (m *memoryConfigMaps) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	return m
+}
+
+func (m *memoryConfigMaps) Get(name string, getOptions metav1.GetOptions) (*v1.ConfigMap, error) {
+	if m.configMap == nil || m.configMap.Name != name {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	return m.configMap.DeepCopy(), nil
+}
+
+func (m *memoryConfigMaps) Create(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	if m.configMap != nil {
+		return nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	m.configMap = configMap.DeepCopy()
+	return m.configMap, nil
+}
+
+func (m *memoryConfigMaps) Update(configMap *v1.ConfigMap) (*v1.ConfigMap, error) {
+	if m.configMap == nil {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	m.configMap = configMap.DeepCopy()
+	return m.configMap, nil
+}
+
+// memoryMachines looks machines up from a map keyed by name.
+type memoryMachines struct {
+	client.MachineInterface
+	machines map[string]*clusterv1.Machine
+}
+
+func (m *memoryMachines) Machines(namespace string) client.MachineInterface {
+	return m
+}
+
+func (m *memoryMachines) Get(name string, options metav1.GetOptions) (*clusterv1.Machine, error) {
+	machine, ok := m.machines[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "machines"}, name)
+	}
+	return machine, nil
+}
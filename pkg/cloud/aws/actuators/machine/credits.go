@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+)
+
+// ensureCreditSpecification changes the credit option for CPU usage of a
+// burstable performance instance to match the machine spec. Nothing is
+// changed if the spec doesn't set CreditSpecification.
+func (a *Actuator) ensureCreditSpecification(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	desired := scope.MachineConfig.CreditSpecification
+	if desired == nil {
+		return nil
+	}
+
+	if err := ec2.ValidateCreditSpecification(instance.Type, desired); err != nil {
+		return err
+	}
+
+	current, err := svc.GetInstanceCreditSpecification(instance.ID)
+	if err != nil {
+		return err
+	}
+
+	if current == *desired {
+		return nil
+	}
+
+	return svc.UpdateInstanceCreditSpecification(instance.ID, *desired)
+}
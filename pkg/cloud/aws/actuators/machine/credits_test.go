@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestEnsureCreditSpecification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cpuCredits   *string
+		instanceType string
+		expect       func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectError  bool
+	}{
+		{
+			name:         "credit specification is not set",
+			instanceType: "t3.large",
+			expect:       func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:         "credit specification is unchanged",
+			cpuCredits:   aws.String(ec2.CPUCreditsUnlimited),
+			instanceType: "t3.large",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.GetInstanceCreditSpecification("i-1").Return(ec2.CPUCreditsUnlimited, nil)
+			},
+		},
+		{
+			name:         "credit specification is changed",
+			cpuCredits:   aws.String(ec2.CPUCreditsUnlimited),
+			instanceType: "t3.large",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.GetInstanceCreditSpecification("i-1").Return(ec2.CPUCreditsStandard, nil)
+				m.UpdateInstanceCreditSpecification("i-1", ec2.CPUCreditsUnlimited).Return(nil)
+			},
+		},
+		{
+			name:         "credit specification on a non burstable instance",
+			cpuCredits:   aws.String(ec2.CPUCreditsUnlimited),
+			instanceType: "m5.large",
+			expect:       func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{CreditSpecification: tc.cpuCredits},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{}
+			err := a.ensureCreditSpecification(svc, scope, &v1alpha1.Instance{ID: "i-1", Type: tc.instanceType})
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// reconcileAPIServerDNS points the API server record of the cluster, if
+// configured, at the API server load balancer when a control plane machine
+// is reconciled.
+func (a *Actuator) reconcileAPIServerDNS(svc service.Route53Interface, scope *actuators.MachineScope) error {
+	if scope.ClusterConfig.APIServerDNS == nil || scope.Machine.Labels["set"] != "controlplane" {
+		return nil
+	}
+
+	return svc.ReconcileAPIServerRecord()
+}
+
+// deleteAPIServerDNS deletes the API server record of the cluster, if
+// configured, once the last control plane machine is deleted.
+func (a *Actuator) deleteAPIServerDNS(svc service.Route53Interface, scope *actuators.MachineScope) error {
+	if scope.ClusterConfig.APIServerDNS == nil || scope.Machine.Labels["set"] != "controlplane" {
+		return nil
+	}
+
+	machines, err := scope.MachineClient.List(actuators.ListOptionsForCluster(scope.Cluster.Name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list machines of cluster %q", scope.Cluster.Name)
+	}
+	for _, m := range GetControlPlaneMachines(machines) {
+		if !machinesEqual(m, scope.Machine) {
+			a.log.V(2).Info("Keeping API server record for remaining control plane machines", "machine-name", scope.Machine.Name, "machine-namespace", scope.Machine.Namespace)
+			return nil
+		}
+	}
+
+	a.log.Info("Deleting API server record of cluster with its last control plane machine", "machine-name", scope.Machine.Name, "machine-namespace", scope.Machine.Namespace)
+	return svc.DeleteAPIServerRecord()
+}
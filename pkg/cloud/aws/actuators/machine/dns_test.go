@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	client "sigs.k8s.io/cluster-api/pkg/client/clientset_generated/clientset/typed/cluster/v1alpha1"
+)
+
+// listedMachines lists a fixed set of machines.
+type listedMachines struct {
+	client.MachineInterface
+	machines []clusterv1.Machine
+}
+
+func (m *listedMachines) List(opts metav1.ListOptions) (*clusterv1.MachineList, error) {
+	return &clusterv1.MachineList{Items: m.machines}, nil
+}
+
+func controlPlaneMachine(name string, deleting bool) clusterv1.Machine {
+	m := clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{"set": "controlplane"},
+		},
+		Spec: clusterv1.MachineSpec{
+			Versions: clusterv1.MachineVersionInfo{ControlPlane: "1.14.1"},
+		},
+	}
+	if deleting {
+		now := metav1.Now()
+		m.DeletionTimestamp = &now
+	}
+	return m
+}
+
+func TestReconcileAPIServerDNS(t *testing.T) {
+	testCases := []struct {
+		name   string
+		dns    *v1alpha1.APIServerDNSSpec
+		labels map[string]string
+		expect func(m *mocks.MockRoute53InterfaceMockRecorder)
+	}{
+		{
+			name:   "not configured",
+			labels: map[string]string{"set": "controlplane"},
+			expect: func(m *mocks.MockRoute53InterfaceMockRecorder) {},
+		},
+		{
+			name:   "control plane machine",
+			dns:    &v1alpha1.APIServerDNSSpec{HostedZoneID: "Z1", RecordName: "api.test.example.com"},
+			labels: map[string]string{"set": "controlplane"},
+			expect: func(m *mocks.MockRoute53InterfaceMockRecorder) {
+				m.ReconcileAPIServerRecord().Return(nil)
+			},
+		},
+		{
+			name:   "node machine",
+			dns:    &v1alpha1.APIServerDNSSpec{HostedZoneID: "Z1", RecordName: "api.test.example.com"},
+			labels: map[string]string{"set": "node"},
+			expect: func(m *mocks.MockRoute53InterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockRoute53Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Scope: &actuators.Scope{
+					ClusterConfig: &v1alpha1.AWSClusterProviderSpec{APIServerDNS: tc.dns},
+				},
+				Machine: &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tc.labels}},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.reconcileAPIServerDNS(svc, scope); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteAPIServerDNS(t *testing.T) {
+	dns := &v1alpha1.APIServerDNSSpec{HostedZoneID: "Z1", RecordName: "api.test.example.com"}
+
+	testCases := []struct {
+		name     string
+		dns      *v1alpha1.APIServerDNSSpec
+		machines []clusterv1.Machine
+		expect   func(m *mocks.MockRoute53InterfaceMockRecorder)
+	}{
+		{
+			name:     "not configured",
+			machines: []clusterv1.Machine{controlPlaneMachine("cp-0", true)},
+			expect:   func(m *mocks.MockRoute53InterfaceMockRecorder) {},
+		},
+		{
+			name:     "last control plane machine",
+			dns:      dns,
+			machines: []clusterv1.Machine{controlPlaneMachine("cp-0", true)},
+			expect: func(m *mocks.MockRoute53InterfaceMockRecorder) {
+				m.DeleteAPIServerRecord().Return(nil)
+			},
+		},
+		{
+			name:     "other control plane machines are deleted too",
+			dns:      dns,
+			machines: []clusterv1.Machine{controlPlaneMachine("cp-0", true), controlPlaneMachine("cp-1", true)},
+			expect: func(m *mocks.MockRoute53InterfaceMockRecorder) {
+				m.DeleteAPIServerRecord().Return(nil)
+			},
+		},
+		{
+			name:     "control plane machines remain",
+			dns:      dns,
+			machines: []clusterv1.Machine{controlPlaneMachine("cp-0", true), controlPlaneMachine("cp-1", false)},
+			expect:   func(m *mocks.MockRoute53InterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockRoute53Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			machine := tc.machines[0]
+			scope := &actuators.MachineScope{
+				Scope: &actuators.Scope{
+					Cluster:       &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+					ClusterConfig: &v1alpha1.AWSClusterProviderSpec{APIServerDNS: tc.dns},
+				},
+				Machine:       &machine,
+				MachineClient: &listedMachines{machines: tc.machines},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.deleteAPIServerDNS(svc, scope); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
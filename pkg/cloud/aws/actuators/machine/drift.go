@@ -0,0 +1,56 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+)
+
+const (
+	specHashChangedReason = "SpecHashChanged"
+	specHashMatchesReason = "SpecHashMatches"
+)
+
+// ensureSpecDriftCondition compares the hash of the machine spec with the one the
+// instance was tagged with at launch, and records in the MachineSpecDrifted
+// condition whether the instance has to be replaced to match the spec.
+// Instances launched before the hash was recorded are left alone.
+func (a *Actuator) ensureSpecDriftCondition(scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	launched, ok := instance.Tags[v1alpha1.NameAWSProviderSpecHash]
+	if !ok {
+		return nil
+	}
+
+	current, err := ec2.ProviderSpecHash(scope.MachineConfig)
+	if err != nil {
+		return err
+	}
+
+	if current == launched {
+		setMachineCondition(scope.MachineStatus, v1alpha1.MachineSpecDrifted, corev1.ConditionFalse, specHashMatchesReason, "")
+		return nil
+	}
+
+	setMachineCondition(scope.MachineStatus, v1alpha1.MachineSpecDrifted, corev1.ConditionTrue, specHashChangedReason,
+		fmt.Sprintf("instance %q was launched from a different machine spec and has to be replaced", instance.ID))
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+)
+
+func TestEnsureSpecDriftCondition(t *testing.T) {
+	launchSpec := &v1alpha1.AWSMachineProviderSpec{
+		InstanceType:   "m5.large",
+		AdditionalTags: map[string]string{"team": "a"},
+	}
+	launchHash, err := ec2.ProviderSpecHash(launchSpec)
+	if err != nil {
+		t.Fatalf("failed to hash spec: %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		spec            *v1alpha1.AWSMachineProviderSpec
+		tags            map[string]string
+		expectCondition *corev1.ConditionStatus
+	}{
+		{
+			name: "instance without a spec hash",
+			spec: &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge"},
+			tags: map[string]string{},
+		},
+		{
+			name:            "unchanged spec",
+			spec:            launchSpec.DeepCopy(),
+			tags:            map[string]string{v1alpha1.NameAWSProviderSpecHash: launchHash},
+			expectCondition: conditionStatus(corev1.ConditionFalse),
+		},
+		{
+			name: "spec changed in place",
+			spec: &v1alpha1.AWSMachineProviderSpec{
+				InstanceType:   "m5.large",
+				AdditionalTags: map[string]string{"team": "b"},
+			},
+			tags:            map[string]string{v1alpha1.NameAWSProviderSpecHash: launchHash},
+			expectCondition: conditionStatus(corev1.ConditionFalse),
+		},
+		{
+			name: "spec changed requiring replacement",
+			spec: &v1alpha1.AWSMachineProviderSpec{
+				InstanceType:   "m5.xlarge",
+				AdditionalTags: map[string]string{"team": "a"},
+			},
+			tags:            map[string]string{v1alpha1.NameAWSProviderSpecHash: launchHash},
+			expectCondition: conditionStatus(corev1.ConditionTrue),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				MachineConfig: tc.spec,
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			actuator := &Actuator{}
+			if err := actuator.ensureSpecDriftCondition(scope, &v1alpha1.Instance{ID: "i-1", Tags: tc.tags}); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if tc.expectCondition == nil {
+				if len(scope.MachineStatus.Conditions) != 0 {
+					t.Fatalf("expected no conditions, got %+v", scope.MachineStatus.Conditions)
+				}
+				return
+			}
+
+			if len(scope.MachineStatus.Conditions) != 1 {
+				t.Fatalf("expected 1 condition, got %d", len(scope.MachineStatus.Conditions))
+			}
+			condition := scope.MachineStatus.Conditions[0]
+			if condition.Type != v1alpha1.MachineSpecDrifted || condition.Status != *tc.expectCondition {
+				t.Fatalf("unexpected condition %+v", condition)
+			}
+		})
+	}
+}
+
+func conditionStatus(s corev1.ConditionStatus) *corev1.ConditionStatus {
+	return &s
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+// addFinalizer adds the finalizer to the machine, unless it has it already.
+func addFinalizer(machine *clusterv1.Machine, finalizer string) {
+	for _, f := range machine.Finalizers {
+		if f == finalizer {
+			return
+		}
+	}
+	machine.Finalizers = append(machine.Finalizers, finalizer)
+}
+
+// removeFinalizer removes the finalizer from the machine, if it has it.
+func removeFinalizer(machine *clusterv1.Machine, finalizer string) {
+	var finalizers []string
+	for _, f := range machine.Finalizers {
+		if f != finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	machine.Finalizers = finalizers
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestAddFinalizer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		finalizers []string
+		expect     []string
+	}{
+		{
+			name:   "no finalizers",
+			expect: []string{v1alpha1.MachineFinalizer},
+		},
+		{
+			name:       "other finalizers",
+			finalizers: []string{clusterv1.MachineFinalizer},
+			expect:     []string{clusterv1.MachineFinalizer, v1alpha1.MachineFinalizer},
+		},
+		{
+			name:       "finalizer already added",
+			finalizers: []string{v1alpha1.MachineFinalizer, clusterv1.MachineFinalizer},
+			expect:     []string{v1alpha1.MachineFinalizer, clusterv1.MachineFinalizer},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Finalizers: tc.finalizers}}
+			addFinalizer(machine, v1alpha1.MachineFinalizer)
+			if !reflect.DeepEqual(machine.Finalizers, tc.expect) {
+				t.Fatalf("expected finalizers %v, got %v", tc.expect, machine.Finalizers)
+			}
+		})
+	}
+}
+
+func TestRemoveFinalizer(t *testing.T) {
+	testCases := []struct {
+		name       string
+		finalizers []string
+		expect     []string
+	}{
+		{
+			name:       "finalizer is removed",
+			finalizers: []string{clusterv1.MachineFinalizer, v1alpha1.MachineFinalizer},
+			expect:     []string{clusterv1.MachineFinalizer},
+		},
+		{
+			name:       "last finalizer is removed",
+			finalizers: []string{v1alpha1.MachineFinalizer},
+		},
+		{
+			name:       "finalizer is missing",
+			finalizers: []string{clusterv1.MachineFinalizer},
+			expect:     []string{clusterv1.MachineFinalizer},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Finalizers: tc.finalizers}}
+			removeFinalizer(machine, v1alpha1.MachineFinalizer)
+			if !reflect.DeepEqual(machine.Finalizers, tc.expect) {
+				t.Fatalf("expected finalizers %v, got %v", tc.expect, machine.Finalizers)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// controlPlaneDialTimeout is how long to wait for a TCP connection to the
+// control plane endpoint when probing its connectivity.
+const controlPlaneDialTimeout = 5 * time.Second
+
+// checkControlPlaneReachable dials the control plane endpoint at address and
+// returns an error if a TCP connection can't be established within timeout.
+func checkControlPlaneReachable(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return errors.Wrapf(err, "control plane endpoint %q is not reachable", address)
+	}
+	return conn.Close()
+}
+
+// checkControlPlaneHealth probes the /healthz endpoint of the control plane
+// and returns an error unless the API server reports itself healthy.
+func checkControlPlaneHealth(client rest.Interface) error {
+	body, err := client.Get().AbsPath("/healthz").Do().Raw()
+	if err != nil {
+		return errors.Wrapf(err, "control plane health check failed")
+	}
+
+	if string(body) != "ok" {
+		return errors.Errorf("control plane is not healthy: %q", string(body))
+	}
+
+	return nil
+}
+
+// checkAPIServerELBHealth returns an error unless at least one control plane
+// instance registered with the API server ELB is healthy.
+func checkAPIServerELBHealth(elbsvc services.ELBInterface) error {
+	healthy, err := elbsvc.APIServerELBHasHealthyInstance()
+	if err != nil {
+		return err
+	}
+
+	if !healthy {
+		return errors.New("API server load balancer has no healthy control plane instances")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestCheckControlPlaneReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	closed.Close()
+
+	testCases := []struct {
+		name      string
+		address   string
+		expectErr bool
+	}{
+		{
+			name:    "reachable endpoint",
+			address: listener.Addr().String(),
+		},
+		{
+			name:      "unreachable endpoint",
+			address:   closed.Addr().String(),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkControlPlaneReachable(tc.address, time.Second)
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckControlPlaneHealth(t *testing.T) {
+	testCases := []struct {
+		name      string
+		status    int
+		body      string
+		expectErr bool
+	}{
+		{
+			name:   "healthy control plane",
+			status: http.StatusOK,
+			body:   "ok",
+		},
+		{
+			name:      "unhealthy control plane",
+			status:    http.StatusInternalServerError,
+			body:      "[-]etcd failed: reason withheld\nhealthz check failed",
+			expectErr: true,
+		},
+		{
+			name:      "unexpected healthz response",
+			status:    http.StatusOK,
+			body:      "degraded",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/healthz" {
+					t.Errorf("Unexpected request path %q", r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client, err := corev1.NewForConfig(&rest.Config{Host: server.URL})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			err = checkControlPlaneHealth(client.RESTClient())
+			if tc.expectErr && err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckAPIServerELBHealth(t *testing.T) {
+	testCases := []struct {
+		name      string
+		healthy   bool
+		err       error
+		expectErr bool
+	}{
+		{
+			name:    "healthy control plane instance",
+			healthy: true,
+		},
+		{
+			name:      "no healthy control plane instances",
+			expectErr: true,
+		},
+		{
+			name:      "instance health unavailable",
+			err:       errors.New("throttled"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbMock := mocks.NewMockELBInterface(mockCtrl)
+			elbMock.EXPECT().APIServerELBHasHealthyInstance().Return(tc.healthy, tc.err)
+
+			err := checkAPIServerELBHealth(elbMock)
+			if tc.expectErr && err == nil {
+				t.Fatal("Expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
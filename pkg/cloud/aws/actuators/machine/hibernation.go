@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// ensureHibernation hibernates the running instance of a machine annotated for
+// hibernation, and resumes its stopped instance once the annotation is gone.
+// Either way the machine is requeued, it is only reconciled further once its
+// instance is running again. Nothing is changed for instances without
+// hibernation configured, or while the instance is transitioning between
+// states.
+func (a *Actuator) ensureHibernation(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if !instance.HibernationConfigured {
+		return nil
+	}
+
+	hibernate := scope.Machine.Annotations[v1alpha1.AnnotationHibernate] == "true"
+
+	switch {
+	case hibernate && instance.State == v1alpha1.InstanceStateRunning:
+		a.log.Info("Hibernating machine instance", "machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", instance.ID)
+		if err := svc.HibernateInstance(instance.ID); err != nil {
+			return err
+		}
+		instance.State = v1alpha1.InstanceStateStopping
+		return a.requeueAfter(waitForInstanceRunningDuration)
+	case !hibernate && instance.State == v1alpha1.InstanceStateStopped:
+		a.log.Info("Resuming machine instance", "machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", instance.ID)
+		if err := svc.ResumeInstance(instance.ID); err != nil {
+			return err
+		}
+		instance.State = v1alpha1.InstanceStatePending
+		return a.requeueAfter(waitForInstanceRunningDuration)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+func TestEnsureHibernation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		instance      v1alpha1.Instance
+		expect        func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectState   v1alpha1.InstanceState
+		expectRequeue bool
+	}{
+		{
+			name:        "hibernation is not configured",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+			instance:    v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning},
+			expect:      func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectState: v1alpha1.InstanceStateRunning,
+		},
+		{
+			name:        "running instance is hibernated",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+			instance:    v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning, HibernationConfigured: true},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.HibernateInstance("i-1").Return(nil)
+			},
+			expectState:   v1alpha1.InstanceStateStopping,
+			expectRequeue: true,
+		},
+		{
+			name:        "hibernated instance stays hibernated",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+			instance:    v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateStopped, HibernationConfigured: true},
+			expect:      func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectState: v1alpha1.InstanceStateStopped,
+		},
+		{
+			name:     "hibernated instance is resumed",
+			instance: v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateStopped, HibernationConfigured: true},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.ResumeInstance("i-1").Return(nil)
+			},
+			expectState:   v1alpha1.InstanceStatePending,
+			expectRequeue: true,
+		},
+		{
+			name:        "instance is resumed when the annotation isn't true",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "false"},
+			instance:    v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateStopped, HibernationConfigured: true},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.ResumeInstance("i-1").Return(nil)
+			},
+			expectState:   v1alpha1.InstanceStatePending,
+			expectRequeue: true,
+		},
+		{
+			name:        "hibernating instance is left alone",
+			instance:    v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateStopping, HibernationConfigured: true},
+			expect:      func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectState: v1alpha1.InstanceStateStopping,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default", Annotations: tc.annotations},
+				},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			err := a.ensureHibernation(svc, scope, &tc.instance)
+			if _, requeue := err.(*controllerError.RequeueAfterError); requeue != tc.expectRequeue {
+				t.Fatalf("expected requeue to be %v, got %v", tc.expectRequeue, err)
+			}
+			if tc.instance.State != tc.expectState {
+				t.Fatalf("expected instance state %q, got %q", tc.expectState, tc.instance.State)
+			}
+		})
+	}
+}
+
+func TestHibernationCycle(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	svc := mocks.NewMockEC2Interface(mockCtrl)
+
+	// The machine enables hibernation, but doesn't start stopped instances.
+	scope := &actuators.MachineScope{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "machine-1",
+				Namespace:   "default",
+				Annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+				Finalizers:  []string{v1alpha1.MachineFinalizer},
+			},
+		},
+		MachineConfig: &v1alpha1.AWSMachineProviderSpec{HibernationOptions: &v1alpha1.HibernationOptions{Configured: true}},
+		MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+	}
+	a := &Actuator{log: klogr.New()}
+
+	stoppedInstance := func(state v1alpha1.InstanceState) {
+		svc.EXPECT().InstanceIfExists(aws.String("i-1")).Return(nil, nil)
+		svc.EXPECT().StoppedInstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: state, HibernationConfigured: true}, nil)
+	}
+
+	update := func(stopped bool) error {
+		instance, err := instanceIfExists(svc, scope)
+		if err != nil {
+			t.Fatalf("did not expect error: %v", err)
+		}
+		if stopped {
+			if exists, err := a.exists(a.log, scope, nil, instance); err != nil || !exists {
+				t.Fatalf("expected the stopped instance to exist, got %v, %v", exists, err)
+			}
+		}
+		return a.update(scope, svc, instance)
+	}
+
+	// The running instance is hibernated.
+	svc.EXPECT().InstanceIfExists(aws.String("i-1")).Return(&v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning, HibernationConfigured: true}, nil)
+	svc.EXPECT().HibernateInstance("i-1").Return(nil)
+	if _, ok := update(false).(*controllerError.RequeueAfterError); !ok {
+		t.Fatal("expected a requeue while the instance hibernates")
+	}
+
+	// The hibernating instance is waited for.
+	stoppedInstance(v1alpha1.InstanceStateStopping)
+	if _, ok := update(true).(*controllerError.RequeueAfterError); !ok {
+		t.Fatal("expected a requeue while the instance is stopping")
+	}
+
+	// The hibernated instance is left alone, and kept by the machine.
+	stoppedInstance(v1alpha1.InstanceStateStopped)
+	if err := update(true); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	// The hibernated instance is resumed once the annotation is removed.
+	delete(scope.Machine.Annotations, v1alpha1.AnnotationHibernate)
+	stoppedInstance(v1alpha1.InstanceStateStopped)
+	svc.EXPECT().ResumeInstance("i-1").Return(nil)
+	if _, ok := update(true).(*controllerError.RequeueAfterError); !ok {
+		t.Fatal("expected a requeue while the instance resumes")
+	}
+
+	if aws.StringValue(scope.MachineStatus.InstanceID) != "i-1" {
+		t.Fatalf("expected the instance to be kept in the machine status, got %v", scope.MachineStatus.InstanceID)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+const (
+	immutableFieldsChangedReason   = "ImmutableFieldsChanged"
+	immutableFieldsUnchangedReason = "ImmutableFieldsUnchanged"
+)
+
+// ImmutableFieldError is an attempt to change a field of the machine spec that
+// cannot be mutated once the instance has been launched.
+type ImmutableFieldError struct {
+	// Field is the path of the field in the machine provider spec.
+	Field string
+
+	// Old is the value the instance was launched with, or the value of the
+	// spec being updated.
+	Old string
+
+	// New is the value requested by the machine provider spec.
+	New string
+}
+
+func (e *ImmutableFieldError) Error() string {
+	return fmt.Sprintf("%s cannot be mutated from %q to %q", e.Field, e.Old, e.New)
+}
+
+// ImmutableFieldErrors aggregates all the attempts to change immutable fields
+// of a machine.
+type ImmutableFieldErrors []*ImmutableFieldError
+
+func (e ImmutableFieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Fields returns the paths of the violated fields, in order.
+func (e ImmutableFieldErrors) Fields() []string {
+	fields := make([]string, len(e))
+	for i := range e {
+		fields[i] = e[i].Field
+	}
+	return fields
+}
+
+// immutableField is a field of the machine spec that cannot be mutated once
+// the instance has been launched. Both the reconciliation of machines and the
+// validation of their updates check these fields.
+type immutableField struct {
+	// path is the path of the field in the machine provider spec.
+	path string
+
+	// desired returns the value requested by the spec, or nil if the spec
+	// leaves the field unset, in which case it is never considered changed.
+	desired func(spec *v1alpha1.AWSMachineProviderSpec, status *v1alpha1.AWSMachineProviderStatus) *string
+
+	// actual returns the value of the field for the instance.
+	actual func(instance *v1alpha1.Instance) string
+
+	// normalize, if set, returns the form in which values are compared.
+	normalize func(value string) string
+}
+
+func (f *immutableField) equal(a, b string) bool {
+	if f.normalize != nil {
+		return f.normalize(a) == f.normalize(b)
+	}
+	return a == b
+}
+
+var immutableFields = []immutableField{
+	{
+		// AMIs that are looked up rather than set explicitly are resolved once
+		// and recorded in the status, compare against that value so that newly
+		// published images don't mark the machine as outdated.
+		path: "ami",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, status *v1alpha1.AWSMachineProviderStatus) *string {
+			ami := aws.StringValue(spec.AMI.ID)
+			if ami == "" {
+				ami = aws.StringValue(status.AMIID)
+			}
+			return optionalString(ami)
+		},
+		actual: func(instance *v1alpha1.Instance) string { return instance.ImageID },
+	},
+	{
+		path: "instanceType",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			return aws.String(spec.InstanceType)
+		},
+		actual: func(instance *v1alpha1.Instance) string { return instance.Type },
+	},
+	{
+		// The profile can be specified either by name or by ARN, while EC2 only
+		// reports the ARN: compare profile names so both forms are equivalent.
+		// An empty profile means the instance profile is left unchanged, rather
+		// than being disassociated from the instance.
+		path: "iamInstanceProfile",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			return optionalString(spec.IAMInstanceProfile)
+		},
+		actual:    func(instance *v1alpha1.Instance) string { return instance.IAMProfile },
+		normalize: iamInstanceProfileName,
+	},
+	{
+		path: "keyName",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			return aws.String(spec.KeyName)
+		},
+		actual: func(instance *v1alpha1.Instance) string { return aws.StringValue(instance.KeyName) },
+	},
+	{
+		// EC2 reports instances launched without a tenancy as "default", only
+		// compare when the machine spec sets one.
+		path: "tenancy",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			return optionalString(spec.Tenancy)
+		},
+		actual: func(instance *v1alpha1.Instance) string { return instance.Tenancy },
+	},
+	{
+		path: "rootDeviceSize",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			if spec.RootDeviceSize <= 0 {
+				return nil
+			}
+			return aws.String(strconv.FormatInt(spec.RootDeviceSize, 10))
+		},
+		actual: func(instance *v1alpha1.Instance) string { return strconv.FormatInt(instance.RootDeviceSize, 10) },
+	},
+	{
+		// machineSpec.Subnet is a *AWSResourceReference and could technically be
+		// a *string, ARN or Filter. However, elsewhere in the code it is only used
+		// as a *string, so do the same here.
+		path: "subnet.id",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			if spec.Subnet == nil {
+				return nil
+			}
+			return aws.String(aws.StringValue(spec.Subnet.ID))
+		},
+		actual: func(instance *v1alpha1.Instance) string { return instance.SubnetID },
+	},
+	{
+		// The machine spec is a simple bool indicating if the instance should
+		// have a public IP or not, while the instance description contains the
+		// public IP assigned to the instance, if any.
+		path: "publicIP",
+		desired: func(spec *v1alpha1.AWSMachineProviderSpec, _ *v1alpha1.AWSMachineProviderStatus) *string {
+			return aws.String(strconv.FormatBool(aws.BoolValue(spec.PublicIP)))
+		},
+		actual: func(instance *v1alpha1.Instance) string {
+			return strconv.FormatBool(len(aws.StringValue(instance.PublicIP)) > 0)
+		},
+	},
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return aws.String(value)
+}
+
+// immutableFieldChanges returns the immutable fields of the instance that the
+// machine spec attempts to change.
+func immutableFieldChanges(spec *v1alpha1.AWSMachineProviderSpec, status *v1alpha1.AWSMachineProviderStatus, instance *v1alpha1.Instance) ImmutableFieldErrors {
+	var errs ImmutableFieldErrors
+	for i := range immutableFields {
+		f := &immutableFields[i]
+		desired := f.desired(spec, status)
+		if desired == nil {
+			continue
+		}
+		if actual := f.actual(instance); !f.equal(actual, *desired) {
+			errs = append(errs, &ImmutableFieldError{Field: f.path, Old: actual, New: *desired})
+		}
+	}
+	return errs
+}
+
+// ValidateImmutableFieldUpdate returns the immutable fields that an update of
+// the spec of a machine, with the given status, attempts to change. Fields
+// can be changed freely until the instance of the machine is created, as can
+// fields unset in either spec, which are checked against the instance when
+// the machine is reconciled.
+func ValidateImmutableFieldUpdate(oldSpec, newSpec *v1alpha1.AWSMachineProviderSpec, status *v1alpha1.AWSMachineProviderStatus) ImmutableFieldErrors {
+	if status.InstanceID == nil {
+		return nil
+	}
+
+	var errs ImmutableFieldErrors
+	for i := range immutableFields {
+		f := &immutableFields[i]
+		oldValue, newValue := f.desired(oldSpec, status), f.desired(newSpec, status)
+		if oldValue == nil || newValue == nil {
+			continue
+		}
+		if !f.equal(*oldValue, *newValue) {
+			errs = append(errs, &ImmutableFieldError{Field: f.path, Old: *oldValue, New: *newValue})
+		}
+	}
+	return errs
+}
+
+// setImmutableFieldsCondition records in the MachineImmutableFieldsChanged
+// condition which immutable fields, if any, the machine spec attempts to change.
+func setImmutableFieldsCondition(status *v1alpha1.AWSMachineProviderStatus, errs ImmutableFieldErrors) {
+	if len(errs) == 0 {
+		setMachineCondition(status, v1alpha1.MachineImmutableFieldsChanged, corev1.ConditionFalse, immutableFieldsUnchangedReason, "")
+		return
+	}
+
+	setMachineCondition(status, v1alpha1.MachineImmutableFieldsChanged, corev1.ConditionTrue, immutableFieldsChangedReason,
+		fmt.Sprintf("fields cannot be mutated: %s", strings.Join(errs.Fields(), ", ")))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+func TestIsMachineOutdatedFields(t *testing.T) {
+	spec := &v1alpha1.AWSMachineProviderSpec{
+		InstanceType:   "m5.xlarge",
+		KeyName:        "new-key",
+		RootDeviceSize: 20,
+		PublicIP:       aws.Bool(true),
+	}
+	instance := &v1alpha1.Instance{
+		Type:           "m5.large",
+		KeyName:        aws.String("old-key"),
+		RootDeviceSize: 10,
+	}
+
+	errs := NewActuator(ActuatorParams{}).isMachineOutdated(spec, &v1alpha1.AWSMachineProviderStatus{}, instance)
+
+	expected := ImmutableFieldErrors{
+		{Field: "instanceType", Old: "m5.large", New: "m5.xlarge"},
+		{Field: "keyName", Old: "old-key", New: "new-key"},
+		{Field: "rootDeviceSize", Old: "10", New: "20"},
+		{Field: "publicIP", Old: "false", New: "true"},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, errs)
+	}
+
+	expectedMsg := `instanceType cannot be mutated from "m5.large" to "m5.xlarge"; ` +
+		`keyName cannot be mutated from "old-key" to "new-key"; ` +
+		`rootDeviceSize cannot be mutated from "10" to "20"; ` +
+		`publicIP cannot be mutated from "false" to "true"`
+	if errs.Error() != expectedMsg {
+		t.Fatalf("expected message %q, got %q", expectedMsg, errs.Error())
+	}
+
+	wrapped := errors.Wrap(errs, "update failed")
+	if _, ok := errors.Cause(wrapped).(ImmutableFieldErrors); !ok {
+		t.Fatalf("expected the cause of %v to be ImmutableFieldErrors", wrapped)
+	}
+}
+
+func TestSetImmutableFieldsCondition(t *testing.T) {
+	status := &v1alpha1.AWSMachineProviderStatus{}
+
+	setImmutableFieldsCondition(status, ImmutableFieldErrors{
+		{Field: "instanceType", Old: "m5.large", New: "m5.xlarge"},
+		{Field: "keyName", Old: "old-key", New: "new-key"},
+	})
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected a single condition, got %+v", status.Conditions)
+	}
+	c := status.Conditions[0]
+	if c.Type != v1alpha1.MachineImmutableFieldsChanged || c.Status != corev1.ConditionTrue || c.Reason != immutableFieldsChangedReason {
+		t.Fatalf("unexpected condition %+v", c)
+	}
+	if !strings.Contains(c.Message, "instanceType, keyName") {
+		t.Fatalf("expected the condition message to list the violated fields, got %q", c.Message)
+	}
+
+	setImmutableFieldsCondition(status, nil)
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected a single condition, got %+v", status.Conditions)
+	}
+	c = status.Conditions[0]
+	if c.Status != corev1.ConditionFalse || c.Reason != immutableFieldsUnchangedReason || c.Message != "" {
+		t.Fatalf("unexpected condition %+v", c)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+)
+
+// reconcileKeyPair creates the SSH key pair of a machine when it doesn't exist
+// and the cluster opted in to creating key pairs, storing the private key in a
+// secret next to the cluster so it can be deleted with it. Key pairs that can
+// be imported from the cluster's SSH public key are left to the ec2 service.
+func (a *Actuator) reconcileKeyPair(svc service.EC2MachineInterface, secrets corev1.SecretsGetter, scope *actuators.MachineScope) error {
+	if !aws.BoolValue(scope.ClusterConfig.CreateSSHKeyPairs) || scope.ClusterConfig.SSHPublicKey != "" {
+		return nil
+	}
+
+	name := ec2.KeyPairName(scope)
+	exists, err := svc.KeyPairExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	secretName := actuators.SSHKeyPairSecretName(scope.Cluster, name)
+	if errs := validation.IsDNS1123Subdomain(secretName); len(errs) > 0 {
+		return errors.Errorf("cannot create SSH key pair %q: %q is not a valid secret name: %s", name, secretName, strings.Join(errs, ", "))
+	}
+
+	a.log.Info("Creating SSH key pair", "key-name", name, "secret-name", secretName)
+	material, err := svc.CreateKeyPair(name)
+	if err != nil {
+		return err
+	}
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Labels:      actuators.SSHKeyPairLabels(scope.Cluster),
+			Annotations: map[string]string{actuators.SSHKeyPairNameAnnotation: name},
+		},
+		Type: apiv1.SecretTypeSSHAuth,
+		Data: map[string][]byte{
+			apiv1.SSHAuthPrivateKey: []byte(material),
+		},
+	}
+
+	client := secrets.Secrets(scope.Cluster.Namespace)
+	_, err = client.Create(secret)
+	if apierrors.IsAlreadyExists(err) {
+		// The secret outlived a key pair that was deleted out of band.
+		_, err = client.Update(secret)
+	}
+	if err != nil {
+		// The private key can't be retrieved again, so delete the key pair for
+		// it to be created anew on the next attempt.
+		if err := svc.DeleteKeyPair(name); err != nil {
+			a.log.Error(err, "Failed to delete SSH key pair whose private key could not be stored", "key-name", name)
+		}
+		return errors.Wrapf(err, "failed to store the private key of SSH key pair %q in secret %q", name, secretName)
+	}
+
+	return nil
+}
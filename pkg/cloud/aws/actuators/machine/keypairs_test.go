@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestReconcileKeyPair(t *testing.T) {
+	alreadyExists := apierrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, "test-cluster-ssh-key-my-key")
+
+	testCases := []struct {
+		name         string
+		create       *bool
+		sshPublicKey string
+		keyName      string
+		createError  error
+		updateError  error
+		expect       func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectSecret bool
+		expectError  string
+	}{
+		{
+			name:   "key pairs are not created unless opted in",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:         "key pairs are imported from the cluster's public key",
+			create:       aws.Bool(true),
+			sshPublicKey: "ssh-rsa AAAA test",
+			expect:       func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:   "existing key pair",
+			create: aws.Bool(true),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("my-key").Return(true, nil)
+			},
+		},
+		{
+			name:   "missing key pair is created",
+			create: aws.Bool(true),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("my-key").Return(false, nil)
+				m.CreateKeyPair("my-key").Return("PRIVATE KEY", nil)
+			},
+			expectSecret: true,
+		},
+		{
+			name:        "leftover secret is updated",
+			create:      aws.Bool(true),
+			createError: alreadyExists,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("my-key").Return(false, nil)
+				m.CreateKeyPair("my-key").Return("PRIVATE KEY", nil)
+			},
+			expectSecret: true,
+		},
+		{
+			name:        "key pair is deleted when its private key can't be stored",
+			create:      aws.Bool(true),
+			createError: errors.New("create error"),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("my-key").Return(false, nil)
+				m.CreateKeyPair("my-key").Return("PRIVATE KEY", nil)
+				m.DeleteKeyPair("my-key").Return(nil)
+			},
+			expectError: `failed to store the private key of SSH key pair "my-key" in secret "test-cluster-ssh-key-my-key"`,
+		},
+		{
+			name:        "key pair is deleted when its leftover secret can't be updated",
+			create:      aws.Bool(true),
+			createError: alreadyExists,
+			updateError: errors.New("update error"),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("my-key").Return(false, nil)
+				m.CreateKeyPair("my-key").Return("PRIVATE KEY", nil)
+				m.DeleteKeyPair("my-key").Return(nil)
+			},
+			expectError: "update error",
+		},
+		{
+			name:    "key pair name that can't name a secret",
+			create:  aws.Bool(true),
+			keyName: "My Key",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.KeyPairExists("My Key").Return(false, nil)
+			},
+			expectError: `cannot create SSH key pair "My Key"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			keyName := tc.keyName
+			if keyName == "" {
+				keyName = "my-key"
+			}
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "test-cluster",
+				},
+			}
+			scope := &actuators.MachineScope{
+				Scope: &actuators.Scope{
+					Cluster: cluster,
+					ClusterConfig: &v1alpha1.AWSClusterProviderSpec{
+						SSHPublicKey:      tc.sshPublicKey,
+						CreateSSHKeyPairs: tc.create,
+					},
+				},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{KeyName: keyName},
+			}
+			secrets := &memorySecrets{createError: tc.createError, updateError: tc.updateError}
+
+			a := &Actuator{log: klogr.New()}
+			err := a.reconcileKeyPair(ec2Mock, secrets, scope)
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if !tc.expectSecret {
+				if secrets.secret != nil {
+					t.Fatalf("did not expect a secret, got %v", secrets.secret)
+				}
+				return
+			}
+
+			secret := secrets.secret
+			if secret == nil {
+				t.Fatal("expected the private key to be stored in a secret")
+			}
+			if secrets.namespace != "ns1" || secret.Name != "test-cluster-ssh-key-my-key" {
+				t.Fatalf("expected secret ns1/test-cluster-ssh-key-my-key, got %s/%s", secrets.namespace, secret.Name)
+			}
+			if secret.Type != v1.SecretTypeSSHAuth {
+				t.Fatalf("expected secret type %q, got %q", v1.SecretTypeSSHAuth, secret.Type)
+			}
+			if key := string(secret.Data[v1.SSHAuthPrivateKey]); key != "PRIVATE KEY" {
+				t.Fatalf("expected the private key to be stored, got %q", key)
+			}
+			if !reflect.DeepEqual(secret.Labels, actuators.SSHKeyPairLabels(cluster)) {
+				t.Fatalf("expected labels %v, got %v", actuators.SSHKeyPairLabels(cluster), secret.Labels)
+			}
+			if name := secret.Annotations[actuators.SSHKeyPairNameAnnotation]; name != "my-key" {
+				t.Fatalf("expected the key pair name to be recorded, got %q", name)
+			}
+		})
+	}
+}
+
+type memorySecrets struct {
+	namespace   string
+	secret      *v1.Secret
+	createError error
+	updateError error
+}
+
+func (s *memorySecrets) Secrets(namespace string) corev1client.SecretInterface {
+	s.namespace = namespace
+	return &memorySecretClient{secrets: s}
+}
+
+type memorySecretClient struct {
+	secrets *memorySecrets
+}
+
+func (c *memorySecretClient) Create(secret *v1.Secret) (*v1.Secret, error) {
+	if c.secrets.createError != nil {
+		return nil, c.secrets.createError
+	}
+	c.secrets.secret = secret
+	return secret, nil
+}
+
+func (c *memorySecretClient) Update(secret *v1.Secret) (*v1.Secret, error) {
+	if c.secrets.updateError != nil {
+		return nil, c.secrets.updateError
+	}
+	c.secrets.secret = secret
+	return secret, nil
+}
+
+func (c *memorySecretClient) Delete(name string, options *metav1.DeleteOptions) error {
+	panic("not implemented")
+}
+
+func (c *memorySecretClient) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	panic("not implemented")
+}
+
+func (c *memorySecretClient) Get(name string, options metav1.GetOptions) (*v1.Secret, error) {
+	panic("not implemented")
+}
+
+func (c *memorySecretClient) List(opts metav1.ListOptions) (*v1.SecretList, error) {
+	panic("not implemented")
+}
+
+func (c *memorySecretClient) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	panic("not implemented")
+}
+
+func (c *memorySecretClient) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v1.Secret, error) {
+	panic("not implemented")
+}
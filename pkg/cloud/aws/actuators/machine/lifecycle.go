@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// ensureLifecycleActionCompleted completes the lifecycle action of the machine's
+// lifecycle hook once the instance is running and its node has joined the cluster.
+// The instance the action was completed for is recorded in an annotation, so it
+// is only completed once per instance.
+func (a *Actuator) ensureLifecycleActionCompleted(svc service.AutoScalingInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	hook := scope.MachineConfig.LifecycleHook
+	if hook == nil || instance.State != v1alpha1.InstanceStateRunning || scope.Machine.Status.NodeRef == nil {
+		return nil
+	}
+
+	if a.machineAnnotation(scope.Machine, v1alpha1.AnnotationLifecycleActionCompleted) == instance.ID {
+		return nil
+	}
+
+	if err := svc.CompleteLifecycleAction(instance.ID, hook); err != nil {
+		return err
+	}
+
+	a.updateMachineAnnotation(scope.Machine, v1alpha1.AnnotationLifecycleActionCompleted, instance.ID)
+	return nil
+}
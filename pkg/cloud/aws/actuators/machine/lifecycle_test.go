@@ -0,0 +1,123 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestEnsureLifecycleActionCompleted(t *testing.T) {
+	hook := &v1alpha1.LifecycleHook{AutoScalingGroupName: "workers", Name: "bootstrap"}
+	nodeRef := &corev1.ObjectReference{Kind: "Node", Name: "node-1"}
+
+	testCases := []struct {
+		name             string
+		hook             *v1alpha1.LifecycleHook
+		nodeRef          *corev1.ObjectReference
+		annotations      map[string]string
+		state            v1alpha1.InstanceState
+		expect           func(m *mocks.MockAutoScalingInterfaceMockRecorder)
+		expectAnnotation string
+	}{
+		{
+			name:    "no lifecycle hook",
+			nodeRef: nodeRef,
+			state:   v1alpha1.InstanceStateRunning,
+			expect:  func(m *mocks.MockAutoScalingInterfaceMockRecorder) {},
+		},
+		{
+			name:    "instance is not running yet",
+			hook:    hook,
+			nodeRef: nodeRef,
+			state:   v1alpha1.InstanceStatePending,
+			expect:  func(m *mocks.MockAutoScalingInterfaceMockRecorder) {},
+		},
+		{
+			name:   "node has not joined yet",
+			hook:   hook,
+			state:  v1alpha1.InstanceStateRunning,
+			expect: func(m *mocks.MockAutoScalingInterfaceMockRecorder) {},
+		},
+		{
+			name:    "lifecycle action is completed",
+			hook:    hook,
+			nodeRef: nodeRef,
+			state:   v1alpha1.InstanceStateRunning,
+			expect: func(m *mocks.MockAutoScalingInterfaceMockRecorder) {
+				m.CompleteLifecycleAction("i-1", hook).Return(nil)
+			},
+			expectAnnotation: "i-1",
+		},
+		{
+			name:             "lifecycle action was already completed",
+			hook:             hook,
+			nodeRef:          nodeRef,
+			annotations:      map[string]string{v1alpha1.AnnotationLifecycleActionCompleted: "i-1"},
+			state:            v1alpha1.InstanceStateRunning,
+			expect:           func(m *mocks.MockAutoScalingInterfaceMockRecorder) {},
+			expectAnnotation: "i-1",
+		},
+		{
+			name:        "lifecycle action was completed for a previous instance",
+			hook:        hook,
+			nodeRef:     nodeRef,
+			annotations: map[string]string{v1alpha1.AnnotationLifecycleActionCompleted: "i-0"},
+			state:       v1alpha1.InstanceStateRunning,
+			expect: func(m *mocks.MockAutoScalingInterfaceMockRecorder) {
+				m.CompleteLifecycleAction("i-1", hook).Return(nil)
+			},
+			expectAnnotation: "i-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockAutoScalingInterface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+					Status:     clusterv1.MachineStatus{NodeRef: tc.nodeRef},
+				},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{LifecycleHook: tc.hook},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{}
+			instance := &v1alpha1.Instance{ID: "i-1", State: tc.state}
+			if err := a.ensureLifecycleActionCompleted(svc, scope, instance); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if got := scope.Machine.Annotations[v1alpha1.AnnotationLifecycleActionCompleted]; got != tc.expectAnnotation {
+				t.Errorf("expected annotation %q, got %q", tc.expectAnnotation, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+)
+
+// waitForMaintenanceWindow requeues a machine until the maintenance window of
+// its cluster that's in progress at now ends, so that no instance is launched
+// during it.
+func (a *Actuator) waitForMaintenanceWindow(log logr.Logger, scope *actuators.MachineScope, now time.Time) error {
+	end, err := maintenanceWindowEnd(scope.ClusterConfig.MaintenanceWindow, now)
+	if err != nil {
+		return err
+	}
+	if end.IsZero() {
+		return nil
+	}
+
+	log.Info("Cluster is in a maintenance window - requeuing machine", "until", end)
+	return a.requeueAfter(end.Sub(now))
+}
+
+// maintenanceWindowEnd returns when the maintenance window in progress at now
+// ends, or the zero time if none is.
+func maintenanceWindowEnd(window *v1alpha1.MaintenanceWindow, now time.Time) (time.Time, error) {
+	if window == nil || window.Duration.Duration <= 0 {
+		return time.Time{}, nil
+	}
+
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid maintenance window start %q", window.Start)
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, name := range window.Days {
+		day, ok := parseWeekday(name)
+		if !ok {
+			return time.Time{}, errors.Errorf("invalid maintenance window day %q", name)
+		}
+		days[day] = true
+	}
+
+	// Windows lasting longer than a day may have started on any of the
+	// previous days, the one ending last wins.
+	var end time.Time
+	now = now.UTC()
+	for i := 0; i <= int(window.Duration.Duration/(24*time.Hour))+1; i++ {
+		day := now.AddDate(0, 0, -i)
+		begin := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if len(days) > 0 && !days[begin.Weekday()] {
+			continue
+		}
+		if e := begin.Add(window.Duration.Duration); !now.Before(begin) && now.Before(e) && e.After(end) {
+			end = e
+		}
+	}
+	return end, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
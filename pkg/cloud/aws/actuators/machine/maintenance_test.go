@@ -0,0 +1,177 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+func TestMaintenanceWindowEnd(t *testing.T) {
+	// 2019-06-01 is a Saturday.
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2019, time.June, day, hour, min, 0, 0, time.UTC)
+	}
+	nightly := &v1alpha1.MaintenanceWindow{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+	saturdayNight := &v1alpha1.MaintenanceWindow{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}, Days: []string{"saturday"}}
+	weekend := &v1alpha1.MaintenanceWindow{Start: "00:00", Duration: metav1.Duration{Duration: 48 * time.Hour}, Days: []string{"Saturday"}}
+
+	testCases := []struct {
+		name        string
+		window      *v1alpha1.MaintenanceWindow
+		now         time.Time
+		expect      time.Time
+		expectError bool
+	}{
+		{
+			name: "no window",
+			now:  at(1, 23, 0),
+		},
+		{
+			name:   "in a window started the same day",
+			window: nightly,
+			now:    at(1, 23, 0),
+			expect: at(2, 2, 0),
+		},
+		{
+			name:   "in a window started the previous day",
+			window: nightly,
+			now:    at(1, 1, 30),
+			expect: at(1, 2, 0),
+		},
+		{
+			name:   "out of the window",
+			window: nightly,
+			now:    at(1, 12, 0),
+		},
+		{
+			name:   "at the end of the window",
+			window: nightly,
+			now:    at(1, 2, 0),
+		},
+		{
+			name:   "at the start of the window",
+			window: nightly,
+			now:    at(1, 22, 0),
+			expect: at(2, 2, 0),
+		},
+		{
+			name:   "in a window converted to UTC",
+			window: nightly,
+			now:    time.Date(2019, time.June, 1, 18, 0, 0, 0, time.FixedZone("EDT", -4*60*60)),
+			expect: at(2, 2, 0),
+		},
+		{
+			name:   "in a window on an allowed day",
+			window: saturdayNight,
+			now:    at(2, 1, 0),
+			expect: at(2, 2, 0),
+		},
+		{
+			name:   "out of a window on another day",
+			window: saturdayNight,
+			now:    at(3, 1, 0),
+		},
+		{
+			name:   "in a window lasting several days",
+			window: weekend,
+			now:    at(2, 12, 0),
+			expect: at(3, 0, 0),
+		},
+		{
+			name:        "invalid start",
+			window:      &v1alpha1.MaintenanceWindow{Start: "10pm", Duration: metav1.Duration{Duration: time.Hour}},
+			now:         at(1, 12, 0),
+			expectError: true,
+		},
+		{
+			name:        "invalid day",
+			window:      &v1alpha1.MaintenanceWindow{Start: "22:00", Duration: metav1.Duration{Duration: time.Hour}, Days: []string{"Caturday"}},
+			now:         at(1, 12, 0),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			end, err := maintenanceWindowEnd(tc.window, tc.now)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !end.Equal(tc.expect) {
+				t.Fatalf("expected end %v, got %v", tc.expect, end)
+			}
+		})
+	}
+}
+
+func TestWaitForMaintenanceWindow(t *testing.T) {
+	window := &v1alpha1.MaintenanceWindow{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+
+	testCases := []struct {
+		name          string
+		now           time.Time
+		expectRequeue time.Duration
+	}{
+		{
+			name:          "in the window",
+			now:           time.Date(2019, time.June, 1, 23, 0, 0, 0, time.UTC),
+			expectRequeue: 3 * time.Hour,
+		},
+		{
+			name: "out of the window",
+			now:  time.Date(2019, time.June, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				Scope: &actuators.Scope{ClusterConfig: &v1alpha1.AWSClusterProviderSpec{MaintenanceWindow: window}},
+			}
+
+			a := &Actuator{}
+			err := a.waitForMaintenanceWindow(klogr.New(), scope, tc.now)
+			if tc.expectRequeue == 0 {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				return
+			}
+
+			requeue, ok := err.(*controllerError.RequeueAfterError)
+			if !ok {
+				t.Fatalf("expected a requeue error, got %v", err)
+			}
+			if requeue.RequeueAfter != tc.expectRequeue {
+				t.Fatalf("expected requeue after %v, got %v", tc.expectRequeue, requeue.RequeueAfter)
+			}
+		})
+	}
+}
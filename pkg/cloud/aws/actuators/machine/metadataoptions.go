@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// ensureInstanceMetadataOptions changes whether requests to the instance
+// metadata service must use session tokens to match the machine spec once the
+// instance is running, e.g. to migrate instances launched with optional tokens
+// to IMDSv2, and records the result in the machine status. The current state
+// is the one EC2 reports for the instance. Nothing is changed if the spec
+// doesn't set HTTPTokens.
+func (a *Actuator) ensureInstanceMetadataOptions(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if instance.HTTPTokens != "" {
+		reported := instance.HTTPTokens
+		scope.MachineStatus.HTTPTokens = &reported
+	}
+
+	options := scope.MachineConfig.InstanceMetadataOptions
+	if options == nil || options.HTTPTokens == "" || instance.State != v1alpha1.InstanceStateRunning {
+		return nil
+	}
+
+	current := instance.HTTPTokens
+	if current == "" {
+		current = v1alpha1.HTTPTokensStateOptional
+	}
+	if current == options.HTTPTokens {
+		return nil
+	}
+
+	a.log.Info("Updating instance metadata options", "machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", instance.ID,
+		"from", current, "to", options.HTTPTokens)
+	if err := svc.UpdateInstanceMetadataOptions(instance.ID, options.HTTPTokens); err != nil {
+		return err
+	}
+
+	httpTokens := options.HTTPTokens
+	scope.MachineStatus.HTTPTokens = &httpTokens
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestEnsureInstanceMetadataOptions(t *testing.T) {
+	optional, required := v1alpha1.HTTPTokensStateOptional, v1alpha1.HTTPTokensStateRequired
+
+	testCases := []struct {
+		name       string
+		options    *v1alpha1.InstanceMetadataOptions
+		state      v1alpha1.InstanceState
+		httpTokens v1alpha1.HTTPTokensState
+		expect     func(m *mocks.MockEC2InterfaceMockRecorder)
+		expected   *v1alpha1.HTTPTokensState
+	}{
+		{
+			name:   "instance metadata options are not set",
+			state:  v1alpha1.InstanceStateRunning,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:       "instance metadata options are not set in the spec",
+			state:      v1alpha1.InstanceStateRunning,
+			httpTokens: optional,
+			expect:     func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expected:   &optional,
+		},
+		{
+			name:       "instance with optional tokens is migrated to IMDSv2",
+			options:    &v1alpha1.InstanceMetadataOptions{HTTPTokens: required},
+			state:      v1alpha1.InstanceStateRunning,
+			httpTokens: optional,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceMetadataOptions("i-1", required).Return(nil)
+			},
+			expected: &required,
+		},
+		{
+			name:    "instance without reported metadata options is migrated to IMDSv2",
+			options: &v1alpha1.InstanceMetadataOptions{HTTPTokens: required},
+			state:   v1alpha1.InstanceStateRunning,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceMetadataOptions("i-1", required).Return(nil)
+			},
+			expected: &required,
+		},
+		{
+			name:       "instance already requires tokens",
+			options:    &v1alpha1.InstanceMetadataOptions{HTTPTokens: required},
+			state:      v1alpha1.InstanceStateRunning,
+			httpTokens: required,
+			expect:     func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expected:   &required,
+		},
+		{
+			name:       "instance with optional tokens stays on IMDSv1",
+			options:    &v1alpha1.InstanceMetadataOptions{HTTPTokens: optional},
+			state:      v1alpha1.InstanceStateRunning,
+			httpTokens: optional,
+			expect:     func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expected:   &optional,
+		},
+		{
+			name:       "instance is not running yet",
+			options:    &v1alpha1.InstanceMetadataOptions{HTTPTokens: required},
+			state:      v1alpha1.InstanceStatePending,
+			httpTokens: optional,
+			expect:     func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expected:   &optional,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine:       &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"}},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{InstanceMetadataOptions: tc.options},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.ensureInstanceMetadataOptions(svc, scope, &v1alpha1.Instance{ID: "i-1", State: tc.state, HTTPTokens: tc.httpTokens}); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			actual := scope.MachineStatus.HTTPTokens
+			if (actual == nil) != (tc.expected == nil) || (actual != nil && *actual != *tc.expected) {
+				t.Fatalf("expected HTTP tokens %v in the status, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// ensureMonitoring enables or disables detailed monitoring of the instance
+// to match the machine spec, and records the resulting monitoring state in
+// the machine status. Nothing is changed if the spec doesn't set Monitoring.
+func (a *Actuator) ensureMonitoring(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if instance.MonitoringState != "" {
+		scope.MachineStatus.MonitoringState = aws.String(instance.MonitoringState)
+	}
+
+	desired := scope.MachineConfig.Monitoring
+	if desired == nil || aws.BoolValue(instance.Monitoring) == *desired {
+		return nil
+	}
+
+	state, err := svc.UpdateInstanceMonitoring(instance.ID, *desired)
+	if err != nil {
+		return err
+	}
+
+	if state != "" {
+		scope.MachineStatus.MonitoringState = aws.String(state)
+	}
+
+	return nil
+}
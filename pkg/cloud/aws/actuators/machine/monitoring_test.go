@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestEnsureMonitoring(t *testing.T) {
+	testCases := []struct {
+		name          string
+		monitoring    *bool
+		instance      v1alpha1.Instance
+		expect        func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectedState string
+	}{
+		{
+			name: "monitoring is not set",
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				Monitoring:      aws.Bool(true),
+				MonitoringState: ec2.MonitoringStateEnabled,
+			},
+			expect:        func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectedState: ec2.MonitoringStateEnabled,
+		},
+		{
+			name:       "monitoring is unchanged",
+			monitoring: aws.Bool(false),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				Monitoring:      aws.Bool(false),
+				MonitoringState: ec2.MonitoringStateDisabled,
+			},
+			expect:        func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectedState: ec2.MonitoringStateDisabled,
+		},
+		{
+			name:       "monitoring is enabled",
+			monitoring: aws.Bool(true),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				Monitoring:      aws.Bool(false),
+				MonitoringState: ec2.MonitoringStateDisabled,
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceMonitoring("i-1", true).Return(ec2.MonitoringStatePending, nil)
+			},
+			expectedState: ec2.MonitoringStatePending,
+		},
+		{
+			name:       "monitoring is disabled",
+			monitoring: aws.Bool(false),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				Monitoring:      aws.Bool(true),
+				MonitoringState: ec2.MonitoringStateEnabled,
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceMonitoring("i-1", false).Return(ec2.MonitoringStateDisabling, nil)
+			},
+			expectedState: ec2.MonitoringStateDisabling,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{Monitoring: tc.monitoring},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{}
+			if err := a.ensureMonitoring(svc, scope, &tc.instance); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if state := aws.StringValue(scope.MachineStatus.MonitoringState); state != tc.expectedState {
+				t.Fatalf("expected monitoring state %q, got %q", tc.expectedState, state)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+)
+
+// Phases set on machines from the state of their instance.
+const (
+	// MachinePhaseProvisioning is the phase of a machine whose instance is pending.
+	MachinePhaseProvisioning = "Provisioning"
+
+	// MachinePhaseRunning is the phase of a machine whose instance is running.
+	MachinePhaseRunning = "Running"
+
+	// MachinePhaseDeleting is the phase of a machine that is being deleted.
+	MachinePhaseDeleting = "Deleting"
+
+	// MachinePhaseFailed is the phase of a machine that failed, or whose
+	// instance stopped or went away without the machine being deleted.
+	MachinePhaseFailed = "Failed"
+)
+
+// machinePhase maps the state of the instance of a machine to the phase of
+// the machine.
+func machinePhase(state v1alpha1.InstanceState, deleting bool, failed bool) string {
+	switch {
+	case deleting:
+		return MachinePhaseDeleting
+	case failed:
+		return MachinePhaseFailed
+	}
+
+	switch state {
+	case v1alpha1.InstanceStatePending:
+		return MachinePhaseProvisioning
+	case v1alpha1.InstanceStateRunning:
+		return MachinePhaseRunning
+	default:
+		return MachinePhaseFailed
+	}
+}
+
+// setMachinePhase sets the phase of the machine from the state of its instance.
+func setMachinePhase(scope *actuators.MachineScope, state v1alpha1.InstanceState) {
+	deleting := !scope.Machine.ObjectMeta.DeletionTimestamp.IsZero()
+	failed := scope.Machine.Status.ErrorReason != nil
+	phase := machinePhase(state, deleting, failed)
+	scope.Machine.Status.Phase = &phase
+}
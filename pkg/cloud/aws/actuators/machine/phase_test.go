@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestMachinePhase(t *testing.T) {
+	testCases := []struct {
+		name     string
+		state    v1alpha1.InstanceState
+		deleting bool
+		failed   bool
+		expected string
+	}{
+		{name: "pending", state: v1alpha1.InstanceStatePending, expected: MachinePhaseProvisioning},
+		{name: "running", state: v1alpha1.InstanceStateRunning, expected: MachinePhaseRunning},
+		{name: "shutting down", state: v1alpha1.InstanceStateShuttingDown, expected: MachinePhaseFailed},
+		{name: "terminated", state: v1alpha1.InstanceStateTerminated, expected: MachinePhaseFailed},
+		{name: "stopping", state: v1alpha1.InstanceStateStopping, expected: MachinePhaseFailed},
+		{name: "stopped", state: v1alpha1.InstanceStateStopped, expected: MachinePhaseFailed},
+		{name: "running while deleting", state: v1alpha1.InstanceStateRunning, deleting: true, expected: MachinePhaseDeleting},
+		{name: "shutting down while deleting", state: v1alpha1.InstanceStateShuttingDown, deleting: true, expected: MachinePhaseDeleting},
+		{name: "pending with a failure", state: v1alpha1.InstanceStatePending, failed: true, expected: MachinePhaseFailed},
+		{name: "failed while deleting", state: v1alpha1.InstanceStateRunning, deleting: true, failed: true, expected: MachinePhaseDeleting},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if phase := machinePhase(tc.state, tc.deleting, tc.failed); phase != tc.expected {
+				t.Fatalf("expected phase %q, got %q", tc.expected, phase)
+			}
+		})
+	}
+}
+
+func TestSetMachinePhase(t *testing.T) {
+	now := metav1.Now()
+	reason := common.CreateMachineError
+
+	testCases := []struct {
+		name     string
+		machine  *clusterv1.Machine
+		expected string
+	}{
+		{
+			name:     "running",
+			machine:  &clusterv1.Machine{},
+			expected: MachinePhaseRunning,
+		},
+		{
+			name:     "deleting",
+			machine:  &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}},
+			expected: MachinePhaseDeleting,
+		},
+		{
+			name:     "failed",
+			machine:  &clusterv1.Machine{Status: clusterv1.MachineStatus{ErrorReason: &reason}},
+			expected: MachinePhaseFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{Machine: tc.machine}
+			setMachinePhase(scope, v1alpha1.InstanceStateRunning)
+
+			if scope.Machine.Status.Phase == nil || *scope.Machine.Status.Phase != tc.expected {
+				t.Fatalf("expected phase %q, got %v", tc.expected, scope.Machine.Status.Phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+// setPlacementAnnotations annotates the machine with the subnet and availability
+// zone of its instance, if enabled. Values that aren't known are left out.
+func (a *Actuator) setPlacementAnnotations(machine *clusterv1.Machine, i *v1alpha1.Instance) {
+	if !a.annotatePlacement {
+		return
+	}
+
+	if i.SubnetID != "" {
+		a.updateMachineAnnotation(machine, v1alpha1.AnnotationSubnetID, i.SubnetID)
+	}
+	if i.AvailabilityZone != "" {
+		a.updateMachineAnnotation(machine, v1alpha1.AnnotationAvailabilityZone, i.AvailabilityZone)
+	}
+}
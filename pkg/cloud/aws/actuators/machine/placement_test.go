@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestSetPlacementAnnotations(t *testing.T) {
+	testCases := []struct {
+		name              string
+		annotatePlacement bool
+		instance          *v1alpha1.Instance
+		expect            map[string]string
+	}{
+		{
+			name:     "disabled",
+			instance: &v1alpha1.Instance{SubnetID: "subnet-1", AvailabilityZone: "us-east-1a"},
+			expect:   map[string]string{"existing": "true"},
+		},
+		{
+			name:              "subnet and availability zone",
+			annotatePlacement: true,
+			instance:          &v1alpha1.Instance{SubnetID: "subnet-1", AvailabilityZone: "us-east-1a"},
+			expect: map[string]string{
+				"existing":                          "true",
+				v1alpha1.AnnotationSubnetID:         "subnet-1",
+				v1alpha1.AnnotationAvailabilityZone: "us-east-1a",
+			},
+		},
+		{
+			name:              "availability zone unknown",
+			annotatePlacement: true,
+			instance:          &v1alpha1.Instance{SubnetID: "subnet-1"},
+			expect: map[string]string{
+				"existing":                  "true",
+				v1alpha1.AnnotationSubnetID: "subnet-1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"existing": "true"}},
+			}
+
+			a := &Actuator{annotatePlacement: tc.annotatePlacement}
+			a.setPlacementAnnotations(machine, tc.instance)
+
+			if !reflect.DeepEqual(machine.Annotations, tc.expect) {
+				t.Fatalf("expected annotations %v, got %v", tc.expect, machine.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+// preTerminateHooks returns the sorted names of the pre-terminate hooks set on
+// a machine, which hold off the termination of its instance until removed.
+func preTerminateHooks(machine *clusterv1.Machine) []string {
+	var hooks []string
+	for key := range machine.Annotations {
+		if strings.HasPrefix(key, v1alpha1.AnnotationPreTerminateHookPrefix) {
+			hooks = append(hooks, strings.TrimPrefix(key, v1alpha1.AnnotationPreTerminateHookPrefix))
+		}
+	}
+	sort.Strings(hooks)
+	return hooks
+}
+
+// anyTerminable returns whether any of the instances still has to be
+// terminated, i.e. isn't shutting down or terminated already.
+func anyTerminable(instances []*v1alpha1.Instance) bool {
+	for _, instance := range instances {
+		switch instance.State {
+		case v1alpha1.InstanceStateShuttingDown, v1alpha1.InstanceStateTerminated:
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestPreTerminateHooks(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expect      []string
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:        "no hooks",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+		},
+		{
+			name: "hooks",
+			annotations: map[string]string{
+				v1alpha1.AnnotationPreTerminateHookPrefix + "license-release": "",
+				v1alpha1.AnnotationPreTerminateHookPrefix + "dns":             "controller",
+				v1alpha1.AnnotationHibernate:                                  "true",
+			},
+			expect: []string{"dns", "license-release"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if hooks := preTerminateHooks(machine); !reflect.DeepEqual(hooks, tc.expect) {
+				t.Fatalf("expected hooks %v, got %v", tc.expect, hooks)
+			}
+		})
+	}
+}
+
+func TestAnyTerminable(t *testing.T) {
+	testCases := []struct {
+		name      string
+		instances []*v1alpha1.Instance
+		expect    bool
+	}{
+		{
+			name: "no instances",
+		},
+		{
+			name: "terminating instances",
+			instances: []*v1alpha1.Instance{
+				{ID: "i-1", State: v1alpha1.InstanceStateShuttingDown},
+				{ID: "i-2", State: v1alpha1.InstanceStateTerminated},
+			},
+		},
+		{
+			name: "running instance",
+			instances: []*v1alpha1.Instance{
+				{ID: "i-1", State: v1alpha1.InstanceStateTerminated},
+				{ID: "i-2", State: v1alpha1.InstanceStateRunning},
+			},
+			expect: true,
+		},
+		{
+			name:      "stopped instance",
+			instances: []*v1alpha1.Instance{{ID: "i-1", State: v1alpha1.InstanceStateStopped}},
+			expect:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if terminable := anyTerminable(tc.instances); terminable != tc.expect {
+				t.Fatalf("expected %t, got %t", tc.expect, terminable)
+			}
+		})
+	}
+}
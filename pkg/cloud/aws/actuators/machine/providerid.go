@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+)
+
+// ProviderIDFormat selects how the provider ID of machines is formatted.
+type ProviderIDFormat string
+
+const (
+	// ProviderIDFormatNoZone formats provider IDs as aws:////<instance-id>,
+	// which older releases set on machines.
+	ProviderIDFormatNoZone ProviderIDFormat = "NoZone"
+
+	// ProviderIDFormatZone formats provider IDs as aws:///<availability-zone>/<instance-id>,
+	// which is what the AWS cloud provider sets on nodes. This is the default.
+	ProviderIDFormatZone ProviderIDFormat = "Zone"
+)
+
+// providerID returns the provider ID of the instance in the actuator's format.
+func (a *Actuator) providerID(instance *v1alpha1.Instance) string {
+	if a.providerIDFormat == ProviderIDFormatZone {
+		return fmt.Sprintf("%s%s/%s", ec2.ProviderIDPrefix, instance.AvailabilityZone, instance.ID)
+	}
+	return fmt.Sprintf("%s/%s", ec2.ProviderIDPrefix, instance.ID)
+}
+
+// reconcileProviderID sets the provider ID of the machine from its instance.
+// A provider ID in another format for the same instance, e.g. after the format
+// option changed, is rewritten so the machine keeps matching its node. A
+// provider ID for any other instance is left alone.
+func (a *Actuator) reconcileProviderID(scope *actuators.MachineScope, instance *v1alpha1.Instance) {
+	desired := a.providerID(instance)
+
+	current := scope.Machine.Spec.ProviderID
+	if current != nil && *current != "" {
+		if *current == desired {
+			return
+		}
+
+		if id, ok := ec2.InstanceIDFromProviderID(*current); !ok || id != instance.ID {
+			return
+		}
+
+		a.log.Info("Migrating machine provider ID to the configured format",
+			"machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "from", *current, "to", desired)
+	}
+
+	scope.Machine.Spec.ProviderID = &desired
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestReconcileProviderID(t *testing.T) {
+	instance := &v1alpha1.Instance{ID: "i-1", AvailabilityZone: "us-east-1a"}
+
+	testCases := []struct {
+		name       string
+		format     ProviderIDFormat
+		providerID *string
+		expect     string
+	}{
+		{
+			name:   "provider id is set",
+			format: ProviderIDFormatNoZone,
+			expect: "aws:////i-1",
+		},
+		{
+			name:   "provider id is set with the availability zone",
+			format: ProviderIDFormatZone,
+			expect: "aws:///us-east-1a/i-1",
+		},
+		{
+			name:       "empty provider id is set",
+			format:     ProviderIDFormatZone,
+			providerID: aws.String(""),
+			expect:     "aws:///us-east-1a/i-1",
+		},
+		{
+			name:       "provider id is migrated to the availability zone format",
+			format:     ProviderIDFormatZone,
+			providerID: aws.String("aws:////i-1"),
+			expect:     "aws:///us-east-1a/i-1",
+		},
+		{
+			name:       "provider id is migrated from the availability zone format",
+			format:     ProviderIDFormatNoZone,
+			providerID: aws.String("aws:///us-east-1a/i-1"),
+			expect:     "aws:////i-1",
+		},
+		{
+			name:       "provider id of another instance is left alone",
+			format:     ProviderIDFormatZone,
+			providerID: aws.String("aws:////i-2"),
+			expect:     "aws:////i-2",
+		},
+		{
+			name:       "provider id of another provider is left alone",
+			format:     ProviderIDFormatZone,
+			providerID: aws.String("gce://project/zone/i-1"),
+			expect:     "gce://project/zone/i-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+					Spec:       clusterv1.MachineSpec{ProviderID: tc.providerID},
+				},
+			}
+
+			a := &Actuator{log: klogr.New(), providerIDFormat: tc.format}
+			a.reconcileProviderID(scope, instance)
+
+			if got := aws.StringValue(scope.Machine.Spec.ProviderID); got != tc.expect {
+				t.Errorf("expected provider id %q, got %q", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestDefaultProviderID(t *testing.T) {
+	a := NewActuator(ActuatorParams{})
+
+	scope := &actuators.MachineScope{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+		},
+	}
+	a.reconcileProviderID(scope, &v1alpha1.Instance{ID: "i-0123456789abcdef0", AvailabilityZone: "us-west-2b"})
+
+	if got, expect := aws.StringValue(scope.Machine.Spec.ProviderID), "aws:///us-west-2b/i-0123456789abcdef0"; got != expect {
+		t.Errorf("expected provider id %q, got %q", expect, got)
+	}
+}
@@ -35,11 +35,18 @@ const (
 	SecurityGroupsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-security-groups"
 )
 
-// Ensures that the security groups of the machine are correct
+// Ensures that the security groups of the primary network interface of the
+// machine include the core and additional security groups, and none of the
+// additional security groups applied previously that were since removed from
+// the spec, replacing them with that set in a single call if they don't.
 // Returns bool, error
 // Bool indicates if changes were made or not, allowing the caller to decide
 // if the machine should be updated.
-func (a *Actuator) ensureSecurityGroups(ec2svc service.EC2MachineInterface, scope *actuators.MachineScope, instanceID string, additional []v1alpha1.AWSResourceReference, existing map[string][]string) (bool, error) {
+func (a *Actuator) ensureSecurityGroups(ec2svc service.EC2MachineInterface, scope *actuators.MachineScope, eniID string, additional []v1alpha1.AWSResourceReference, existing []string) (bool, error) {
+	if eniID == "" {
+		return false, nil
+	}
+
 	annotation, err := a.machineAnnotationJSON(scope.Machine, SecurityGroupsLastAppliedAnnotation)
 	if err != nil {
 		return false, err
@@ -49,19 +56,25 @@ func (a *Actuator) ensureSecurityGroups(ec2svc service.EC2MachineInterface, scop
 	if err != nil {
 		return false, err
 	}
-	changed, ids := a.securityGroupsChanged(annotation, core, additional, existing)
+
+	additionalIDs, err := ec2svc.ResolveSecurityGroupReferences(additional)
+	if err != nil {
+		return false, err
+	}
+
+	changed, ids := a.securityGroupsChanged(annotation, core, additionalIDs, existing)
 	if !changed {
 		return false, nil
 	}
 
-	if err := ec2svc.UpdateInstanceSecurityGroups(instanceID, ids); err != nil {
+	if err := ec2svc.UpdateNetworkInterfaceSecurityGroups(eniID, ids); err != nil {
 		return false, err
 	}
 
 	// Build and store annotation.
-	newAnnotation := make(map[string]interface{}, len(additional))
-	for _, id := range additional {
-		newAnnotation[*id.ID] = struct{}{}
+	newAnnotation := make(map[string]interface{}, len(additionalIDs))
+	for _, id := range additionalIDs {
+		newAnnotation[id] = struct{}{}
 	}
 
 	if err := a.updateMachineAnnotationJSON(scope.Machine, SecurityGroupsLastAppliedAnnotation, newAnnotation); err != nil {
@@ -71,46 +84,49 @@ func (a *Actuator) ensureSecurityGroups(ec2svc service.EC2MachineInterface, scop
 	return true, nil
 }
 
-// securityGroupsChanged determines which security groups to delete and which to add.
-func (a *Actuator) securityGroupsChanged(annotation map[string]interface{}, core []string, additional []v1alpha1.AWSResourceReference, existing map[string][]string) (bool, []string) {
-	state := map[string]bool{}
-	for _, s := range additional {
-		state[*s.ID] = true
+// securityGroupsChanged computes the security groups the primary network
+// interface of the instance should have and whether they differ from the
+// existing ones.
+// The desired set is the existing groups, minus the additional groups that
+// were last applied but are no longer in the spec, plus the core and
+// additional groups. Groups the actuator doesn't own, e.g. ones attached out
+// of band, are preserved.
+func (a *Actuator) securityGroupsChanged(annotation map[string]interface{}, core []string, additional []string, existing []string) (bool, []string) {
+	current := map[string]bool{}
+	for _, id := range existing {
+		current[id] = true
 	}
 
-	// Loop over `annotation`, checking the state for things that were deleted since last time.
-	// If we find something in the `annotation`, but not in the state, we flag it as `false` (not found, deleted).
-	for groupID := range annotation {
-		if _, ok := state[groupID]; !ok {
-			state[groupID] = false
-		}
+	desired := map[string]bool{}
+	for id := range current {
+		desired[id] = true
 	}
 
-	// add (or add back) the core security groups
-	for _, s := range core {
-		state[s] = true
+	// Drop the groups applied last time that are no longer requested.
+	for id := range annotation {
+		delete(desired, id)
 	}
 
-	// Build the security group list.
-	res := []string{}
-	for id, keep := range state {
-		if keep {
-			res = append(res, id)
-		}
+	// Add (or add back) the core and additional security groups.
+	for _, id := range core {
+		desired[id] = true
+	}
+	for _, id := range additional {
+		desired[id] = true
 	}
 
-	for _, actual := range existing {
-		if len(actual) != len(res) {
-			return true, res
-		}
+	res := make([]string, 0, len(desired))
+	for id := range desired {
+		res = append(res, id)
+	}
+	sort.Strings(res)
 
-		// Length is the same, check if the ids are the same too.
-		sort.Strings(actual)
-		sort.Strings(res)
-		for i, id := range res {
-			if actual[i] != id {
-				return true, res
-			}
+	if len(res) != len(current) {
+		return true, res
+	}
+	for _, id := range res {
+		if !current[id] {
+			return true, res
 		}
 	}
 
@@ -0,0 +1,241 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestSecurityGroupsChanged(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotation    map[string]interface{}
+		core          []string
+		additional    []string
+		existing      []string
+		expectChanged bool
+		expectIDs     []string
+	}{
+		{
+			name:      "up to date",
+			core:      []string{"sg-core"},
+			existing:  []string{"sg-core"},
+			expectIDs: []string{"sg-core"},
+		},
+		{
+			name:       "up to date with additional groups",
+			core:       []string{"sg-core"},
+			additional: []string{"sg-extra"},
+			existing:   []string{"sg-extra", "sg-core"},
+			expectIDs:  []string{"sg-core", "sg-extra"},
+		},
+		{
+			name:          "additional group added",
+			core:          []string{"sg-core"},
+			additional:    []string{"sg-extra"},
+			existing:      []string{"sg-core"},
+			expectChanged: true,
+			expectIDs:     []string{"sg-core", "sg-extra"},
+		},
+		{
+			name:          "additional group removed",
+			annotation:    map[string]interface{}{"sg-extra": struct{}{}},
+			core:          []string{"sg-core"},
+			existing:      []string{"sg-core", "sg-extra"},
+			expectChanged: true,
+			expectIDs:     []string{"sg-core"},
+		},
+		{
+			name:          "core group added back",
+			core:          []string{"sg-core"},
+			existing:      []string{},
+			expectChanged: true,
+			expectIDs:     []string{"sg-core"},
+		},
+		{
+			name:       "group attached out of band is kept",
+			annotation: map[string]interface{}{"sg-extra": struct{}{}},
+			core:       []string{"sg-core"},
+			additional: []string{"sg-extra"},
+			existing:   []string{"sg-core", "sg-extra", "sg-other"},
+			expectIDs:  []string{"sg-core", "sg-extra", "sg-other"},
+		},
+		{
+			name:          "group attached out of band is kept when a group is removed",
+			annotation:    map[string]interface{}{"sg-extra": struct{}{}},
+			core:          []string{"sg-core"},
+			existing:      []string{"sg-core", "sg-extra", "sg-other"},
+			expectChanged: true,
+			expectIDs:     []string{"sg-core", "sg-other"},
+		},
+		{
+			name:          "additional group replaced",
+			annotation:    map[string]interface{}{"sg-old": struct{}{}},
+			core:          []string{"sg-core"},
+			additional:    []string{"sg-new"},
+			existing:      []string{"sg-old", "sg-core"},
+			expectChanged: true,
+			expectIDs:     []string{"sg-core", "sg-new"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Actuator{}
+			changed, ids := a.securityGroupsChanged(tc.annotation, tc.core, tc.additional, tc.existing)
+			if changed != tc.expectChanged {
+				t.Fatalf("expected changed to be %v, got %v", tc.expectChanged, changed)
+			}
+			if !reflect.DeepEqual(ids, tc.expectIDs) {
+				t.Fatalf("expected security groups %v, got %v", tc.expectIDs, ids)
+			}
+		})
+	}
+}
+
+func TestEnsureSecurityGroups(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lastApplied string
+		additional  []v1alpha1.AWSResourceReference
+		resolved    []string
+		existing    []string
+		expectIDs   []string
+	}{
+		{
+			name:       "adds a group added to the spec",
+			additional: []v1alpha1.AWSResourceReference{{ID: aws.String("sg-new")}},
+			resolved:   []string{"sg-new"},
+			existing:   []string{"sg-core"},
+			expectIDs:  []string{"sg-core", "sg-new"},
+		},
+		{
+			name:        "removes a group removed from the spec",
+			lastApplied: `{"sg-old":{}}`,
+			existing:    []string{"sg-core", "sg-old"},
+			expectIDs:   []string{"sg-core"},
+		},
+		{
+			name:        "keeps a group attached out of band",
+			lastApplied: `{"sg-old":{}}`,
+			additional:  []v1alpha1.AWSResourceReference{{ID: aws.String("sg-new")}},
+			resolved:    []string{"sg-new"},
+			existing:    []string{"sg-core", "sg-old", "sg-other"},
+			expectIDs:   []string{"sg-core", "sg-new", "sg-other"},
+		},
+		{
+			name:       "does nothing when the groups are up to date",
+			additional: []v1alpha1.AWSResourceReference{{ID: aws.String("sg-new")}},
+			resolved:   []string{"sg-new"},
+			existing:   []string{"sg-new", "sg-core"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil)
+			svc.EXPECT().ResolveSecurityGroupReferences(tc.additional).Return(tc.resolved, nil)
+			if tc.expectIDs != nil {
+				svc.EXPECT().UpdateNetworkInterfaceSecurityGroups("eni-1", tc.expectIDs).Return(nil)
+			}
+
+			machine := &clusterv1.Machine{}
+			if tc.lastApplied != "" {
+				machine.Annotations = map[string]string{SecurityGroupsLastAppliedAnnotation: tc.lastApplied}
+			}
+			scope := &actuators.MachineScope{Machine: machine}
+
+			changed, err := (&Actuator{}).ensureSecurityGroups(svc, scope, "eni-1", tc.additional, tc.existing)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if changed != (tc.expectIDs != nil) {
+				t.Fatalf("expected changed to be %v, got %v", tc.expectIDs != nil, changed)
+			}
+		})
+	}
+}
+
+func TestEnsureSecurityGroupsWithoutPrimaryENI(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	svc := mocks.NewMockEC2Interface(mockCtrl)
+	scope := &actuators.MachineScope{Machine: &clusterv1.Machine{}}
+
+	changed, err := (&Actuator{}).ensureSecurityGroups(svc, scope, "", nil, nil)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if changed {
+		t.Fatal("did not expect the security groups to change")
+	}
+}
+
+func TestEnsureSecurityGroupsConverges(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	additional := []v1alpha1.AWSResourceReference{{ID: aws.String("sg-new")}}
+
+	svc := mocks.NewMockEC2Interface(mockCtrl)
+	svc.EXPECT().GetCoreSecurityGroups(gomock.Any()).Return([]string{"sg-core"}, nil).Times(2)
+	svc.EXPECT().ResolveSecurityGroupReferences(additional).Return([]string{"sg-new"}, nil).Times(2)
+	svc.EXPECT().UpdateNetworkInterfaceSecurityGroups("eni-1", []string{"sg-core", "sg-new", "sg-other"}).Return(nil).Times(1)
+
+	scope := &actuators.MachineScope{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{SecurityGroupsLastAppliedAnnotation: `{"sg-old":{}}`},
+			},
+		},
+	}
+
+	a := &Actuator{}
+
+	// The first reconcile replaces the groups with the desired set in one call,
+	// keeping the group attached out of band.
+	changed, err := a.ensureSecurityGroups(svc, scope, "eni-1", additional, []string{"sg-core", "sg-old", "sg-other"})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the security groups to change")
+	}
+
+	// Once applied, the next reconcile makes no further change.
+	changed, err = a.ensureSecurityGroups(svc, scope, "eni-1", additional, []string{"sg-core", "sg-new", "sg-other"})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if changed {
+		t.Fatal("did not expect the security groups to change")
+	}
+}
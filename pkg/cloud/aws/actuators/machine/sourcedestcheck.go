@@ -0,0 +1,45 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// ensureSourceDestCheck enables or disables source/destination checking of the
+// instance to match the machine spec, once the instance is running. Nothing is
+// changed if the spec doesn't set SourceDestCheck.
+func (a *Actuator) ensureSourceDestCheck(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	desired := scope.MachineConfig.SourceDestCheck
+	if desired == nil || instance.State != v1alpha1.InstanceStateRunning {
+		return nil
+	}
+
+	if instance.SourceDestCheck != nil && *instance.SourceDestCheck == *desired {
+		return nil
+	}
+
+	if err := svc.UpdateInstanceSourceDestCheck(instance.ID, *desired); err != nil {
+		return err
+	}
+
+	instance.SourceDestCheck = aws.Bool(*desired)
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestEnsureSourceDestCheck(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sourceDestCheck *bool
+		instance        v1alpha1.Instance
+		expect          func(m *mocks.MockEC2InterfaceMockRecorder)
+	}{
+		{
+			name: "source/destination check is not set",
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				State:           v1alpha1.InstanceStateRunning,
+				SourceDestCheck: aws.Bool(true),
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:            "source/destination check is unchanged",
+			sourceDestCheck: aws.Bool(false),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				State:           v1alpha1.InstanceStateRunning,
+				SourceDestCheck: aws.Bool(false),
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:            "instance is not running yet",
+			sourceDestCheck: aws.Bool(false),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				State:           v1alpha1.InstanceStatePending,
+				SourceDestCheck: aws.Bool(true),
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:            "source/destination check is disabled",
+			sourceDestCheck: aws.Bool(false),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				State:           v1alpha1.InstanceStateRunning,
+				SourceDestCheck: aws.Bool(true),
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceSourceDestCheck("i-1", false).Return(nil)
+			},
+		},
+		{
+			name:            "source/destination check drifted back on",
+			sourceDestCheck: aws.Bool(true),
+			instance: v1alpha1.Instance{
+				ID:              "i-1",
+				State:           v1alpha1.InstanceStateRunning,
+				SourceDestCheck: aws.Bool(false),
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateInstanceSourceDestCheck("i-1", true).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{SourceDestCheck: tc.sourceDestCheck},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			a := &Actuator{}
+			if err := a.ensureSourceDestCheck(svc, scope, &tc.instance); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
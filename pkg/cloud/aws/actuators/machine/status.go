@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+// createFailure describes how an AWS error code returned while creating
+// an instance is surfaced on the machine.
+type createFailure struct {
+	reason       v1alpha1.MachineFailureReason
+	machineError common.MachineStatusError
+	// retryable failures are requeued, all others are terminal.
+	retryable bool
+}
+
+var createFailures = map[string]createFailure{
+	awserrors.InsufficientInstanceCapacity: {v1alpha1.InsufficientCapacityMachineFailure, common.InsufficientResourcesMachineError, true},
+	awserrors.InstanceLimitExceeded:        {v1alpha1.InsufficientCapacityMachineFailure, common.InsufficientResourcesMachineError, true},
+	awserrors.RequestLimitExceeded:         {v1alpha1.CreateErrorMachineFailure, common.CreateMachineError, true},
+	awserrors.IdempotentParameterMismatch:  {v1alpha1.CreateErrorMachineFailure, common.CreateMachineError, true},
+	awserrors.UnauthorizedOperation:        {v1alpha1.UnauthorizedMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.AuthFailure:                  {v1alpha1.UnauthorizedMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.InvalidParameterValue:        {v1alpha1.CreateErrorMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.InvalidParameterCombination:  {v1alpha1.CreateErrorMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.InvalidAMIIDNotFound:         {v1alpha1.CreateErrorMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.InvalidAMIIDMalformed:        {v1alpha1.CreateErrorMachineFailure, common.InvalidConfigurationMachineError, false},
+
+	awserrors.ReservationCapacityExceeded:           {v1alpha1.CapacityReservationMachineFailure, common.InsufficientResourcesMachineError, false},
+	awserrors.InvalidCapacityReservationIDNotFound:  {v1alpha1.CapacityReservationMachineFailure, common.InvalidConfigurationMachineError, false},
+	awserrors.InvalidCapacityReservationIDMalformed: {v1alpha1.CapacityReservationMachineFailure, common.InvalidConfigurationMachineError, false},
+}
+
+// handleCreateError records an error returned while creating the machine's instance
+// on the machine status and returns the error to hand back to the machine controller.
+// Terminal failures are recorded as such and stop any further attempt to create the instance.
+func handleCreateError(scope *actuators.MachineScope, err error) error {
+	code, _ := awserrors.Code(errors.Cause(err))
+	failure, ok := createFailures[code]
+	message := fmt.Sprintf("failed to create instance: %s: %s", code, awserrors.Message(errors.Cause(err)))
+
+	// Without a subnet to launch in, retrying won't help until the configuration is fixed.
+	if noSubnets, isNoSubnets := errors.Cause(err).(*ec2.NoSubnetsError); isNoSubnets {
+		failure, ok = createFailure{v1alpha1.NoSubnetsMachineFailure, common.InvalidConfigurationMachineError, false}, true
+		message = noSubnets.Error()
+	}
+
+	if !ok {
+		setMachineCondition(scope.MachineStatus, v1alpha1.MachineCreated, corev1.ConditionFalse, string(v1alpha1.CreateErrorMachineFailure), err.Error())
+		return errors.Errorf("failed to create or get machine: %+v", err)
+	}
+
+	// AWS rejects launching into a capacity reservation from another availability
+	// zone as an invalid parameter combination, make it clear what's wrong.
+	if reservationID := targetedCapacityReservation(scope); reservationID != "" {
+		if code == awserrors.InvalidParameterCombination {
+			failure.reason = v1alpha1.CapacityReservationMachineFailure
+		}
+		if failure.reason == v1alpha1.CapacityReservationMachineFailure {
+			message = fmt.Sprintf("failed to create instance in capacity reservation %q: %s: %s", reservationID, code, awserrors.Message(errors.Cause(err)))
+		}
+	}
+	setMachineCondition(scope.MachineStatus, v1alpha1.MachineCreated, corev1.ConditionFalse, string(failure.reason), message)
+
+	if failure.retryable {
+		scope.Info("Retryable error creating instance - requeuing", "code", code)
+		return &controllerError.RequeueAfterError{RequeueAfter: waitForRetryableCreateErrorDuration}
+	}
+
+	scope.Error(err, "Terminal error creating instance", "code", code)
+	setTerminalFailure(scope, failure.reason, failure.machineError, message)
+	return nil
+}
+
+// setTerminalFailure records a failure on the machine that stops any further
+// attempt to create its instance.
+func setTerminalFailure(scope *actuators.MachineScope, reason v1alpha1.MachineFailureReason, machineError common.MachineStatusError, message string) {
+	setMachineCondition(scope.MachineStatus, v1alpha1.MachineCreated, corev1.ConditionFalse, string(reason), message)
+	record.Warnf(scope.Machine, "FailedCreate", "Failed to create instance: %s", message)
+	scope.MachineStatus.FailureReason = &reason
+	scope.MachineStatus.FailureMessage = &message
+	scope.Machine.Status.ErrorReason = &machineError
+	scope.Machine.Status.ErrorMessage = &message
+}
+
+// targetedCapacityReservation returns the ID of the capacity reservation, or
+// the ARN of the capacity reservation resource group, the machine's instance
+// is launched into, if any.
+func targetedCapacityReservation(scope *actuators.MachineScope) string {
+	switch {
+	case scope.MachineConfig == nil:
+		return ""
+	case scope.MachineConfig.CapacityReservationID != nil:
+		return *scope.MachineConfig.CapacityReservationID
+	case scope.MachineConfig.CapacityReservationResourceGroupARN != nil:
+		return *scope.MachineConfig.CapacityReservationResourceGroupARN
+	}
+	return ""
+}
+
+// setMachineCreated marks the machine as created by the given instance.
+func setMachineCreated(status *v1alpha1.AWSMachineProviderStatus, instance *v1alpha1.Instance) {
+	setMachineCondition(status, v1alpha1.MachineCreated, corev1.ConditionTrue, "InstanceCreated", fmt.Sprintf("Created instance %q", instance.ID))
+}
+
+// clearMachineInstance removes any reference to the machine's instance, which no longer exists,
+// and bumps the launch generation so the next instance isn't launched with the same client token.
+// Everything recorded about the instance is reset, so that nothing acts on the resources of the
+// terminated instance, e.g. its network interfaces, and the next instance is set up from scratch.
+func clearMachineInstance(scope *actuators.MachineScope) {
+	scope.MachineStatus.LaunchGeneration++
+	scope.MachineStatus.InstanceID = nil
+	scope.MachineStatus.InstanceState = nil
+	scope.MachineStatus.AMIID = nil
+	scope.MachineStatus.SubnetID = nil
+	scope.MachineStatus.LaunchTime = nil
+	scope.MachineStatus.NetworkInterfaceIDs = nil
+	scope.MachineStatus.TargetGroupARNs = nil
+	scope.MachineStatus.MonitoringState = nil
+	scope.MachineStatus.HTTPTokens = nil
+	scope.MachineStatus.AccountID = nil
+	scope.MachineStatus.ConsoleOutput = nil
+	scope.Machine.Spec.ProviderID = nil
+	setMachineCondition(scope.MachineStatus, v1alpha1.MachineCreated, corev1.ConditionFalse, "InstanceTerminated", "The instance no longer exists")
+}
+
+// setMachineCondition adds or updates the condition of the given type on the machine status.
+func setMachineCondition(status *v1alpha1.AWSMachineProviderStatus, conditionType v1alpha1.AWSMachineProviderConditionType, conditionStatus corev1.ConditionStatus, reason, message string) {
+	now := metav1.NewTime(time.Now())
+	for i := range status.Conditions {
+		c := &status.Conditions[i]
+		if c.Type != conditionType {
+			continue
+		}
+		if c.Status != conditionStatus {
+			c.LastTransitionTime = now
+		}
+		c.Status = conditionStatus
+		c.LastProbeTime = now
+		c.Reason = reason
+		c.Message = message
+		return
+	}
+
+	status.Conditions = append(status.Conditions, v1alpha1.AWSMachineProviderCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/common"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+func TestHandleCreateError(t *testing.T) {
+	tests := []struct {
+		name                  string
+		machineConfig         *v1alpha1.AWSMachineProviderSpec
+		err                   error
+		expectRequeue         bool
+		expectError           bool
+		expectFailureReason   *v1alpha1.MachineFailureReason
+		expectMachineError    *common.MachineStatusError
+		expectConditionReason string
+	}{
+		{
+			name:                  "insufficient capacity is requeued",
+			err:                   errors.Wrap(awserr.New("InsufficientInstanceCapacity", "no capacity", nil), "failed to run instance"),
+			expectRequeue:         true,
+			expectConditionReason: string(v1alpha1.InsufficientCapacityMachineFailure),
+		},
+		{
+			name:                  "unauthorized is terminal",
+			err:                   errors.Wrap(awserr.New("UnauthorizedOperation", "not allowed", nil), "failed to run instance"),
+			expectFailureReason:   failureReason(v1alpha1.UnauthorizedMachineFailure),
+			expectMachineError:    machineError(common.InvalidConfigurationMachineError),
+			expectConditionReason: string(v1alpha1.UnauthorizedMachineFailure),
+		},
+		{
+			name:                  "invalid ami is terminal",
+			err:                   errors.Wrap(awserr.New("InvalidAMIID.NotFound", "no such ami", nil), "failed to run instance"),
+			expectFailureReason:   failureReason(v1alpha1.CreateErrorMachineFailure),
+			expectMachineError:    machineError(common.InvalidConfigurationMachineError),
+			expectConditionReason: string(v1alpha1.CreateErrorMachineFailure),
+		},
+		{
+			name:                  "full capacity reservation is terminal",
+			machineConfig:         &v1alpha1.AWSMachineProviderSpec{CapacityReservationID: aws.String("cr-1")},
+			err:                   errors.Wrap(awserr.New("ReservationCapacityExceeded", "reservation is full", nil), "failed to run instance"),
+			expectFailureReason:   failureReason(v1alpha1.CapacityReservationMachineFailure),
+			expectMachineError:    machineError(common.InsufficientResourcesMachineError),
+			expectConditionReason: string(v1alpha1.CapacityReservationMachineFailure),
+		},
+		{
+			name:                  "capacity reservation in another zone is terminal",
+			machineConfig:         &v1alpha1.AWSMachineProviderSpec{CapacityReservationID: aws.String("cr-1")},
+			err:                   errors.Wrap(awserr.New("InvalidParameterCombination", "wrong availability zone", nil), "failed to run instance"),
+			expectFailureReason:   failureReason(v1alpha1.CapacityReservationMachineFailure),
+			expectMachineError:    machineError(common.InvalidConfigurationMachineError),
+			expectConditionReason: string(v1alpha1.CapacityReservationMachineFailure),
+		},
+		{
+			name:                  "invalid parameter combination without a capacity reservation is terminal",
+			machineConfig:         &v1alpha1.AWSMachineProviderSpec{},
+			err:                   errors.Wrap(awserr.New("InvalidParameterCombination", "invalid", nil), "failed to run instance"),
+			expectFailureReason:   failureReason(v1alpha1.CreateErrorMachineFailure),
+			expectMachineError:    machineError(common.InvalidConfigurationMachineError),
+			expectConditionReason: string(v1alpha1.CreateErrorMachineFailure),
+		},
+		{
+			name:                  "no subnets is terminal",
+			err:                   errors.Wrap(&ec2.NoSubnetsError{Machine: "machine-1", Cluster: "test1", VPC: "vpc-1"}, "failed to create instance"),
+			expectFailureReason:   failureReason(v1alpha1.NoSubnetsMachineFailure),
+			expectMachineError:    machineError(common.InvalidConfigurationMachineError),
+			expectConditionReason: string(v1alpha1.NoSubnetsMachineFailure),
+		},
+		{
+			name:                  "unknown error is returned",
+			err:                   errors.New("something went wrong"),
+			expectError:           true,
+			expectConditionReason: string(v1alpha1.CreateErrorMachineFailure),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := &actuators.MachineScope{
+				Scope:         &actuators.Scope{Logger: klogr.New()},
+				Machine:       &clusterv1.Machine{},
+				MachineConfig: tc.machineConfig,
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+
+			err := handleCreateError(scope, tc.err)
+
+			_, isRequeue := err.(*controllerError.RequeueAfterError)
+			if tc.expectRequeue != isRequeue {
+				t.Errorf("expected requeue %t, got error %v", tc.expectRequeue, err)
+			}
+			if !tc.expectRequeue && tc.expectError != (err != nil) {
+				t.Errorf("expected error %t, got %v", tc.expectError, err)
+			}
+
+			if tc.expectFailureReason == nil && scope.MachineStatus.FailureReason != nil {
+				t.Errorf("expected no failure reason, got %q", *scope.MachineStatus.FailureReason)
+			}
+			if tc.expectFailureReason != nil {
+				if scope.MachineStatus.FailureReason == nil || *scope.MachineStatus.FailureReason != *tc.expectFailureReason {
+					t.Errorf("expected failure reason %q, got %v", *tc.expectFailureReason, scope.MachineStatus.FailureReason)
+				}
+				if scope.MachineStatus.FailureMessage == nil {
+					t.Error("expected a failure message")
+				}
+			}
+
+			if tc.expectMachineError == nil && scope.Machine.Status.ErrorReason != nil {
+				t.Errorf("expected no machine error reason, got %q", *scope.Machine.Status.ErrorReason)
+			}
+			if tc.expectMachineError != nil {
+				if scope.Machine.Status.ErrorReason == nil || *scope.Machine.Status.ErrorReason != *tc.expectMachineError {
+					t.Errorf("expected machine error reason %q, got %v", *tc.expectMachineError, scope.Machine.Status.ErrorReason)
+				}
+			}
+
+			if len(scope.MachineStatus.Conditions) != 1 {
+				t.Fatalf("expected 1 condition, got %d", len(scope.MachineStatus.Conditions))
+			}
+			condition := scope.MachineStatus.Conditions[0]
+			if condition.Type != v1alpha1.MachineCreated || condition.Status != corev1.ConditionFalse || condition.Reason != tc.expectConditionReason {
+				t.Errorf("unexpected condition %+v", condition)
+			}
+		})
+	}
+}
+
+func TestSetMachineCondition(t *testing.T) {
+	status := &v1alpha1.AWSMachineProviderStatus{}
+
+	setMachineCondition(status, v1alpha1.MachineCreated, corev1.ConditionFalse, "CreateError", "failed")
+	transition := status.Conditions[0].LastTransitionTime
+
+	setMachineCondition(status, v1alpha1.MachineCreated, corev1.ConditionFalse, "CreateError", "failed again")
+	if len(status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(status.Conditions))
+	}
+	if status.Conditions[0].LastTransitionTime != transition {
+		t.Error("expected the transition time to be unchanged when the status is unchanged")
+	}
+	if status.Conditions[0].Message != "failed again" {
+		t.Errorf("expected the message to be updated, got %q", status.Conditions[0].Message)
+	}
+
+	setMachineCreated(status, &v1alpha1.Instance{ID: "i-1"})
+	if len(status.Conditions) != 1 || status.Conditions[0].Status != corev1.ConditionTrue {
+		t.Errorf("expected the condition to be true, got %+v", status.Conditions)
+	}
+}
+
+func failureReason(r v1alpha1.MachineFailureReason) *v1alpha1.MachineFailureReason {
+	return &r
+}
+
+func machineError(e common.MachineStatusError) *common.MachineStatusError {
+	return &e
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// instanceIfExists returns the instance of a machine as described by AWS. A
+// stopping or stopped instance is only returned for machines that start or
+// hibernate their stopped instance, other machines consider it gone.
+func instanceIfExists(svc service.EC2MachineInterface, scope *actuators.MachineScope) (*v1alpha1.Instance, error) {
+	instance, err := svc.InstanceIfExists(scope.MachineStatus.InstanceID)
+	if err != nil || instance != nil || !keepsStoppedInstance(scope) {
+		return instance, err
+	}
+
+	return svc.StoppedInstanceIfExists(scope.MachineStatus.InstanceID)
+}
+
+// keepsStoppedInstance returns true if the stopped instance of a machine is
+// still the machine's instance, because the machine starts its stopped
+// instance, has hibernation enabled or is annotated for hibernation.
+func keepsStoppedInstance(scope *actuators.MachineScope) bool {
+	if aws.BoolValue(scope.MachineConfig.StartStoppedInstance) {
+		return true
+	}
+	if opts := scope.MachineConfig.HibernationOptions; opts != nil && opts.Configured {
+		return true
+	}
+	_, ok := scope.Machine.Annotations[v1alpha1.AnnotationHibernate]
+	return ok
+}
+
+// startStoppedInstance starts the stopped instance of a machine that starts
+// its stopped instance, unless the machine is annotated for hibernation.
+func (a *Actuator) startStoppedInstance(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if !aws.BoolValue(scope.MachineConfig.StartStoppedInstance) {
+		return nil
+	}
+	if instance.State != v1alpha1.InstanceStateStopped || scope.Machine.Annotations[v1alpha1.AnnotationHibernate] == "true" {
+		return nil
+	}
+
+	a.log.Info("Starting stopped machine instance", "machine-name", scope.Name(), "machine-namespace", scope.Namespace(), "instance-id", instance.ID)
+	if err := svc.ResumeInstance(instance.ID); err != nil {
+		return err
+	}
+	instance.State = v1alpha1.InstanceStatePending
+	return nil
+}
+
+// isStopped returns true if the instance is stopping or stopped.
+func isStopped(instance *v1alpha1.Instance) bool {
+	return instance.State == v1alpha1.InstanceStateStopping || instance.State == v1alpha1.InstanceStateStopped
+}
+
+// requeueStopped requeues the machine of a stopping or stopped instance until
+// the instance has been started. The stopped instance of a machine annotated
+// for hibernation is left alone.
+func (a *Actuator) requeueStopped(scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if instance.State == v1alpha1.InstanceStateStopped && scope.Machine.Annotations[v1alpha1.AnnotationHibernate] == "true" {
+		return nil
+	}
+
+	return a.requeueAfter(waitForInstanceRunningDuration)
+}
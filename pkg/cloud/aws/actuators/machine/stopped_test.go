@@ -0,0 +1,187 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+func TestInstanceIfExists(t *testing.T) {
+	stopped := &v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateStopped}
+	running := &v1alpha1.Instance{ID: "i-1", State: v1alpha1.InstanceStateRunning}
+
+	testCases := []struct {
+		name                 string
+		startStoppedInstance *bool
+		hibernationOptions   *v1alpha1.HibernationOptions
+		annotations          map[string]string
+		expect               func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectInstance       *v1alpha1.Instance
+	}{
+		{
+			name:                 "running instance",
+			startStoppedInstance: aws.Bool(true),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.InstanceIfExists(aws.String("i-1")).Return(running, nil)
+			},
+			expectInstance: running,
+		},
+		{
+			name: "stopped instance is gone by default",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.InstanceIfExists(aws.String("i-1")).Return(nil, nil)
+			},
+		},
+		{
+			name:                 "stopped instance of a machine starting it",
+			startStoppedInstance: aws.Bool(true),
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.InstanceIfExists(aws.String("i-1")).Return(nil, nil)
+				m.StoppedInstanceIfExists(aws.String("i-1")).Return(stopped, nil)
+			},
+			expectInstance: stopped,
+		},
+		{
+			name:               "stopped instance of a machine with hibernation enabled",
+			hibernationOptions: &v1alpha1.HibernationOptions{Configured: true},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.InstanceIfExists(aws.String("i-1")).Return(nil, nil)
+				m.StoppedInstanceIfExists(aws.String("i-1")).Return(stopped, nil)
+			},
+			expectInstance: stopped,
+		},
+		{
+			name:        "stopped instance of a machine annotated for hibernation",
+			annotations: map[string]string{v1alpha1.AnnotationHibernate: "true"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.InstanceIfExists(aws.String("i-1")).Return(nil, nil)
+				m.StoppedInstanceIfExists(aws.String("i-1")).Return(stopped, nil)
+			},
+			expectInstance: stopped,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default", Annotations: tc.annotations},
+				},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{
+					StartStoppedInstance: tc.startStoppedInstance,
+					HibernationOptions:   tc.hibernationOptions,
+				},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+			}
+
+			instance, err := instanceIfExists(svc, scope)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if instance != tc.expectInstance {
+				t.Fatalf("expected instance %v, got %v", tc.expectInstance, instance)
+			}
+		})
+	}
+}
+
+func TestStartStoppedInstance(t *testing.T) {
+	hibernate := map[string]string{v1alpha1.AnnotationHibernate: "true"}
+
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		state         v1alpha1.InstanceState
+		expect        func(m *mocks.MockEC2InterfaceMockRecorder)
+		expectState   v1alpha1.InstanceState
+		expectRequeue bool
+	}{
+		{
+			name:  "stopped instance is started",
+			state: v1alpha1.InstanceStateStopped,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.ResumeInstance("i-1").Return(nil)
+			},
+			expectState: v1alpha1.InstanceStatePending,
+		},
+		{
+			name:          "stopping instance is waited for",
+			state:         v1alpha1.InstanceStateStopping,
+			expect:        func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectState:   v1alpha1.InstanceStateStopping,
+			expectRequeue: true,
+		},
+		{
+			name:        "hibernating instance is left stopped",
+			annotations: hibernate,
+			state:       v1alpha1.InstanceStateStopped,
+			expect:      func(m *mocks.MockEC2InterfaceMockRecorder) {},
+			expectState: v1alpha1.InstanceStateStopped,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default", Annotations: tc.annotations},
+				},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{StartStoppedInstance: aws.Bool(true)},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			}
+			instance := &v1alpha1.Instance{ID: "i-1", State: tc.state}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.startStoppedInstance(svc, scope, instance); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if instance.State != tc.expectState {
+				t.Fatalf("expected instance state %q, got %q", tc.expectState, instance.State)
+			}
+
+			if !isStopped(instance) {
+				return
+			}
+			_, requeue := a.requeueStopped(scope, instance).(*controllerError.RequeueAfterError)
+			if requeue != tc.expectRequeue {
+				t.Fatalf("expected requeue to be %v, got %v", tc.expectRequeue, requeue)
+			}
+		})
+	}
+}
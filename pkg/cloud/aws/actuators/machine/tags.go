@@ -18,7 +18,14 @@ package machine
 
 // should not need to import the ec2 sdk here
 import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
 	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
@@ -30,13 +37,19 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	TagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
+
+	// nameTag is the tag holding the name of the machine on its instance.
+	nameTag = "Name"
 )
 
 // Ensure that the tags of the machine are correct
 // Returns bool, error
 // Bool indicates if changes were made or not, allowing the caller to decide
 // if the machine should be updated.
-func (a *Actuator) ensureTags(svc service.EC2MachineInterface, machine *clusterv1.Machine, instanceID *string, additionalTags map[string]string) (bool, error) {
+// The Name tag of the instance is also restored from the machine name if it
+// was removed or changed out of band, unless the additional tags set it.
+// The volumes attached to the instance are tagged along with it.
+func (a *Actuator) ensureTags(svc service.EC2MachineInterface, machine *clusterv1.Machine, instance *v1alpha1.Instance, additionalTags map[string]string) (bool, error) {
 	annotation, err := a.machineAnnotationJSON(machine, TagsLastAppliedAnnotation)
 	if err != nil {
 		return false, err
@@ -47,12 +60,29 @@ func (a *Actuator) ensureTags(svc service.EC2MachineInterface, machine *clusterv
 	// moment we send everything, even if only a single tag was created or
 	// upated.
 	changed, created, deleted, newAnnotation := a.tagsChanged(annotation, additionalTags)
+
+	if _, ok := additionalTags[nameTag]; !ok && instance.Tags[nameTag] != machine.Name {
+		a.log.Info("Restoring the Name tag of the machine instance", "machine-name", machine.Name, "machine-namespace", machine.Namespace, "instance-id", instance.ID)
+		created[nameTag] = machine.Name
+		delete(deleted, nameTag)
+		changed = true
+	}
+
 	if changed {
-		err = svc.UpdateResourceTags(instanceID, created, deleted)
+		err = svc.UpdateResourceTags(aws.String(instance.ID), created, deleted)
 		if err != nil {
 			return false, err
 		}
+	}
 
+	// Volumes are checked against the tags they should carry every time, so
+	// volumes attached later or launched untagged are caught up too. Their
+	// encryption tags are fixed in the same pass.
+	if err := a.ensureVolumeTags(svc, instance.ID, volumeTags(machine, instance, additionalTags), deleted); err != nil {
+		return false, err
+	}
+
+	if changed {
 		// We also need to update the annotation if anything changed.
 		err = a.updateMachineAnnotationJSON(machine, TagsLastAppliedAnnotation, newAnnotation)
 		if err != nil {
@@ -63,6 +93,114 @@ func (a *Actuator) ensureTags(svc service.EC2MachineInterface, machine *clusterv
 	return changed, nil
 }
 
+// ensureVolumeTags makes sure each volume attached to the instance carries the
+// given tags and its encryption tags, and none of the removed ones.
+func (a *Actuator) ensureVolumeTags(svc service.EC2MachineInterface, instanceID string, tags map[string]string, removed map[string]string) error {
+	volumes, err := svc.GetInstanceVolumes(instanceID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		create, remove := volumeEncryptionTagsChanged(v)
+		for key, value := range tags {
+			if current, ok := v.Tags[key]; !ok || current != value {
+				create[key] = value
+			}
+		}
+
+		for key := range removed {
+			if _, ok := tags[key]; ok {
+				continue
+			}
+			if current, ok := v.Tags[key]; ok {
+				remove[key] = current
+			}
+		}
+
+		if len(create) == 0 && len(remove) == 0 {
+			continue
+		}
+
+		a.log.V(2).Info("Updating the tags of a volume attached to the machine instance", "instance-id", instanceID, "volume-id", v.ID)
+		if err := svc.UpdateResourceTags(aws.String(v.ID), create, remove); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// volumeTags returns the tags the volumes attached to a machine's instance
+// should carry: the cluster ownership and role tags of the instance, the name
+// of the machine, and the tags managed on the instance.
+func volumeTags(machine *clusterv1.Machine, instance *v1alpha1.Instance, additionalTags map[string]string) map[string]string {
+	tags := map[string]string{nameTag: machine.Name}
+	for key, value := range instance.Tags {
+		if strings.HasPrefix(key, v1alpha1.NameKubernetesAWSCloudProviderPrefix) ||
+			strings.HasPrefix(key, v1alpha1.NameAWSProviderOwned) ||
+			key == v1alpha1.NameAWSClusterAPIRole {
+			tags[key] = value
+		}
+	}
+	for key, value := range additionalTags {
+		tags[key] = value
+	}
+	return tags
+}
+
+// reconcileTags ensures that the tags of the machine's instance and its volumes
+// match the machine spec, unless tag reconciliation is skipped, in which case the
+// tags are only applied when the instance is launched.
+// The machine is requeued if tagging was throttled by AWS.
+func (a *Actuator) reconcileTags(svc service.EC2MachineInterface, scope *actuators.MachineScope, instance *v1alpha1.Instance) error {
+	if a.skipTagReconcile {
+		return nil
+	}
+
+	if _, err := a.ensureTags(svc, scope.Machine, instance, instanceTags(scope.MachineConfig)); err != nil {
+		if awserrors.IsThrottling(err) {
+			a.log.Info("Tagging the machine instance was throttled, requeuing", "machine-name", scope.Machine.Name, "machine-namespace", scope.Machine.Namespace, "instance-id", instance.ID, "error", err.Error())
+			return a.requeueAfter(a.tagThrottlingRequeue)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// instanceTags returns the tags from the machine spec that the actuator
+// manages on the instance: the additional tags and the compliance scope tag.
+func instanceTags(spec *v1alpha1.AWSMachineProviderSpec) map[string]string {
+	if spec.ComplianceScope == nil {
+		return spec.AdditionalTags
+	}
+
+	tags := make(map[string]string, len(spec.AdditionalTags)+1)
+	for k, v := range spec.AdditionalTags {
+		tags[k] = v
+	}
+	tags[v1alpha1.NameAWSComplianceScope] = *spec.ComplianceScope
+
+	return tags
+}
+
+// validateComplianceScope returns an error if the compliance scope isn't one
+// of the allowed ones, when the actuator restricts them.
+func (a *Actuator) validateComplianceScope(scope *string) error {
+	if scope == nil || len(a.allowedComplianceScopes) == 0 {
+		return nil
+	}
+
+	for _, allowed := range a.allowedComplianceScopes {
+		if *scope == allowed {
+			return nil
+		}
+	}
+
+	return errors.Errorf("compliance scope %q is not one of the allowed scopes %q", *scope, a.allowedComplianceScopes)
+}
+
 // tagsChanged determines which tags to delete and which to add.
 func (a *Actuator) tagsChanged(annotation map[string]interface{}, src map[string]string) (bool, map[string]string, map[string]string, map[string]interface{}) {
 	// Bool tracking if we found any changed state.
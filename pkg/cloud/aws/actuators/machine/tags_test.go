@@ -0,0 +1,392 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	controllerError "sigs.k8s.io/cluster-api/pkg/controller/error"
+)
+
+func TestInstanceTags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     v1alpha1.AWSMachineProviderSpec
+		expected map[string]string
+	}{
+		{
+			name: "no compliance scope",
+			spec: v1alpha1.AWSMachineProviderSpec{
+				AdditionalTags: map[string]string{"foo": "bar"},
+			},
+			expected: map[string]string{"foo": "bar"},
+		},
+		{
+			name: "compliance scope without additional tags",
+			spec: v1alpha1.AWSMachineProviderSpec{
+				ComplianceScope: aws.String("pci"),
+			},
+			expected: map[string]string{v1alpha1.NameAWSComplianceScope: "pci"},
+		},
+		{
+			name: "compliance scope with additional tags",
+			spec: v1alpha1.AWSMachineProviderSpec{
+				AdditionalTags:  map[string]string{"foo": "bar"},
+				ComplianceScope: aws.String("hipaa"),
+			},
+			expected: map[string]string{"foo": "bar", v1alpha1.NameAWSComplianceScope: "hipaa"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := instanceTags(&tc.spec)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected tags %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateComplianceScope(t *testing.T) {
+	testCases := []struct {
+		name        string
+		allowed     []string
+		scope       *string
+		expectError bool
+	}{
+		{
+			name: "no compliance scope",
+		},
+		{
+			name:  "any compliance scope is allowed",
+			scope: aws.String("pci"),
+		},
+		{
+			name:    "compliance scope is allowed",
+			allowed: []string{"pci", "hipaa"},
+			scope:   aws.String("hipaa"),
+		},
+		{
+			name:        "compliance scope is not allowed",
+			allowed:     []string{"pci", "hipaa"},
+			scope:       aws.String("sox"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Actuator{allowedComplianceScopes: tc.allowed}
+
+			err := a.validateComplianceScope(tc.scope)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileTags(t *testing.T) {
+	testCases := []struct {
+		name             string
+		skipTagReconcile bool
+		expect           func(m *mocks.MockEC2InterfaceMockRecorder)
+	}{
+		{
+			name: "tags are reconciled",
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"foo": "bar"}, map[string]string{}).Return(nil)
+				m.GetInstanceVolumes("i-1").Return(nil, nil)
+			},
+		},
+		{
+			name:             "tag reconciliation is skipped",
+			skipTagReconcile: true,
+			expect:           func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				Machine:       &clusterv1.Machine{},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{AdditionalTags: map[string]string{"foo": "bar"}},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+			}
+
+			a := &Actuator{skipTagReconcile: tc.skipTagReconcile}
+			if err := a.reconcileTags(svc, scope, &v1alpha1.Instance{ID: "i-1"}); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileTagsSingleVolumePass(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// The volumes are listed once per reconcile, and each volume is updated
+	// once for both its tags and its encryption tags.
+	svc := mocks.NewMockEC2Interface(mockCtrl)
+	svc.EXPECT().UpdateResourceTags(aws.String("i-1"), map[string]string{"foo": "bar"}, map[string]string{}).Return(nil)
+	svc.EXPECT().GetInstanceVolumes("i-1").Return([]*v1alpha1.Volume{{ID: "vol-1", Tags: map[string]string{"Name": "machine-1"}}}, nil).Times(1)
+	svc.EXPECT().UpdateResourceTags(aws.String("vol-1"), map[string]string{
+		"foo":                           "bar",
+		v1alpha1.NameAWSVolumeEncrypted: "false",
+	}, map[string]string{}).Return(nil).Times(1)
+
+	scope := &actuators.MachineScope{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+		},
+		MachineConfig: &v1alpha1.AWSMachineProviderSpec{AdditionalTags: map[string]string{"foo": "bar"}},
+		MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+	}
+
+	a := &Actuator{log: klogr.New()}
+	if err := a.reconcileTags(svc, scope, &v1alpha1.Instance{ID: "i-1", Tags: map[string]string{"Name": "machine-1"}}); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+func TestReconcileTagsThrottling(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requeue       time.Duration
+		err           error
+		expectRequeue time.Duration
+	}{
+		{
+			name:          "request limit exceeded",
+			err:           errors.Wrap(awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil), "failed to create tags"),
+			expectRequeue: waitForTagThrottlingDuration,
+		},
+		{
+			name:          "throttling with a configured requeue",
+			requeue:       time.Minute,
+			err:           awserr.New("Throttling", "Rate exceeded", nil),
+			expectRequeue: time.Minute,
+		},
+		{
+			name: "other error",
+			err:  errors.Wrap(awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil), "failed to create tags"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			svc.EXPECT().UpdateResourceTags(aws.String("i-1"), map[string]string{"foo": "bar"}, map[string]string{}).Return(tc.err)
+
+			scope := &actuators.MachineScope{
+				Machine:       &clusterv1.Machine{},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{AdditionalTags: map[string]string{"foo": "bar"}},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")},
+			}
+
+			a := NewActuator(ActuatorParams{ControlPlaneInitLocker: &fakeControlPlaneInitLocker{}, TagThrottlingRequeue: tc.requeue})
+			err := a.reconcileTags(svc, scope, &v1alpha1.Instance{ID: "i-1"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			requeue, ok := err.(*controllerError.RequeueAfterError)
+			if tc.expectRequeue == 0 {
+				if ok {
+					t.Fatalf("did not expect a requeue, got %v", err)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected a requeue, got %v", err)
+			}
+			if requeue.RequeueAfter != tc.expectRequeue {
+				t.Fatalf("expected a requeue after %v, got %v", tc.expectRequeue, requeue.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestEnsureTagsRestoresName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		instanceTags   map[string]string
+		additionalTags map[string]string
+		expect         func(m *mocks.MockEC2InterfaceMockRecorder)
+	}{
+		{
+			name:         "name tag is present",
+			instanceTags: map[string]string{"Name": "machine-1"},
+			expect:       func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name:         "name tag was removed",
+			instanceTags: map[string]string{"foo": "bar"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"Name": "machine-1"}, map[string]string{}).Return(nil)
+			},
+		},
+		{
+			name:         "name tag was changed",
+			instanceTags: map[string]string{"Name": "renamed"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"Name": "machine-1"}, map[string]string{}).Return(nil)
+			},
+		},
+		{
+			name:           "name tag was removed along with an additional tag change",
+			additionalTags: map[string]string{"foo": "bar"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"Name": "machine-1", "foo": "bar"}, map[string]string{}).Return(nil)
+			},
+		},
+		{
+			name:           "name tag is set by the additional tags",
+			instanceTags:   map[string]string{"Name": "custom"},
+			additionalTags: map[string]string{"Name": "custom"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"Name": "custom"}, map[string]string{}).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			tc.expect(svc.EXPECT())
+			svc.EXPECT().GetInstanceVolumes("i-1").Return(nil, nil)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+			}
+			instance := &v1alpha1.Instance{ID: "i-1", Tags: tc.instanceTags}
+
+			a := &Actuator{log: klogr.New()}
+			if _, err := a.ensureTags(svc, machine, instance, tc.additionalTags); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestEnsureTagsTagsVolumes(t *testing.T) {
+	owned := v1alpha1.ClusterTagKey("test1")
+
+	testCases := []struct {
+		name           string
+		volumes        []*v1alpha1.Volume
+		additionalTags map[string]string
+		lastApplied    string
+		expect         func(m *mocks.MockEC2InterfaceMockRecorder)
+	}{
+		{
+			name: "volumes are tagged",
+			volumes: []*v1alpha1.Volume{
+				{ID: "vol-1"},
+				{ID: "vol-2", Tags: map[string]string{"Name": "machine-1", owned: "owned", v1alpha1.NameAWSClusterAPIRole: "node", "foo": "old", v1alpha1.NameAWSVolumeEncrypted: "false"}},
+			},
+			additionalTags: map[string]string{"foo": "bar"},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{"foo": "bar"}, map[string]string{}).Return(nil)
+				m.UpdateResourceTags(aws.String("vol-1"), map[string]string{"Name": "machine-1", owned: "owned", v1alpha1.NameAWSClusterAPIRole: "node", "foo": "bar", v1alpha1.NameAWSVolumeEncrypted: "false"}, map[string]string{}).Return(nil)
+				m.UpdateResourceTags(aws.String("vol-2"), map[string]string{"foo": "bar"}, map[string]string{}).Return(nil)
+			},
+		},
+		{
+			name: "volumes are up to date",
+			volumes: []*v1alpha1.Volume{
+				{ID: "vol-1", Tags: map[string]string{"Name": "machine-1", owned: "owned", v1alpha1.NameAWSClusterAPIRole: "node", "foo": "bar", "other": "tag", v1alpha1.NameAWSVolumeEncrypted: "false"}},
+			},
+			additionalTags: map[string]string{"foo": "bar"},
+			lastApplied:    `{"foo":"bar"}`,
+			expect:         func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name: "removed tags are removed from volumes",
+			volumes: []*v1alpha1.Volume{
+				{ID: "vol-1", Tags: map[string]string{"Name": "machine-1", owned: "owned", v1alpha1.NameAWSClusterAPIRole: "node", "foo": "bar", v1alpha1.NameAWSVolumeEncrypted: "false"}},
+				{ID: "vol-2", Tags: map[string]string{"Name": "machine-1", owned: "owned", v1alpha1.NameAWSClusterAPIRole: "node", v1alpha1.NameAWSVolumeEncrypted: "false"}},
+			},
+			lastApplied: `{"foo":"bar"}`,
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("i-1"), map[string]string{}, map[string]string{"foo": "bar"}).Return(nil)
+				m.UpdateResourceTags(aws.String("vol-1"), map[string]string{}, map[string]string{"foo": "bar"}).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			svc.EXPECT().GetInstanceVolumes("i-1").Return(tc.volumes, nil)
+			tc.expect(svc.EXPECT())
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: "default"},
+			}
+			if tc.lastApplied != "" {
+				machine.Annotations = map[string]string{TagsLastAppliedAnnotation: tc.lastApplied}
+			}
+			instance := &v1alpha1.Instance{
+				ID: "i-1",
+				Tags: map[string]string{
+					"Name":                           "machine-1",
+					owned:                            "owned",
+					v1alpha1.NameAWSClusterAPIRole:   "node",
+					v1alpha1.NameAWSProviderSpecHash: "hash",
+				},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if _, err := a.ensureTags(svc, machine, instance, tc.additionalTags); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	service "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services"
+)
+
+// reconcileTargetGroups registers the instance of a machine with the target
+// groups of its spec, and deregisters it from the target groups it was
+// registered with that are no longer listed. The target groups are recorded
+// on the machine status, so the list can be changed on existing machines.
+func (a *Actuator) reconcileTargetGroups(svc service.ELBInterface, scope *actuators.MachineScope, instanceID string) error {
+	desired := scope.MachineConfig.TargetGroupARNs
+	registered := scope.MachineStatus.TargetGroupARNs
+
+	remove := difference(registered, desired)
+	if len(remove) > 0 {
+		a.log.Info("Deregistering machine instance from target groups", "instance-id", instanceID, "target-group-arns", remove)
+		if err := svc.DeregisterInstanceFromTargetGroups(instanceID, remove); err != nil {
+			return errors.Wrap(err, "failed to deregister instance from target groups")
+		}
+	}
+
+	add := difference(desired, registered)
+	if len(add) > 0 {
+		a.log.Info("Registering machine instance with target groups", "instance-id", instanceID, "target-group-arns", add)
+		if err := svc.RegisterInstanceWithTargetGroups(instanceID, add); err != nil {
+			return errors.Wrap(err, "failed to register instance with target groups")
+		}
+	}
+
+	if len(desired) == 0 {
+		scope.MachineStatus.TargetGroupARNs = nil
+	} else {
+		scope.MachineStatus.TargetGroupARNs = append([]string(nil), desired...)
+	}
+	return nil
+}
+
+// deregisterTargetGroups deregisters the instances of a machine that is being
+// deleted from the target groups it was registered with, and returns the ones
+// the target groups still list. The target groups stay recorded on the machine
+// status, so the instances can be checked again while they drain.
+func (a *Actuator) deregisterTargetGroups(svc service.ELBInterface, scope *actuators.MachineScope, instanceIDs []string) ([]string, error) {
+	if len(scope.MachineStatus.TargetGroupARNs) == 0 {
+		return nil, nil
+	}
+
+	registered, err := svc.InstancesRegisteredWithTargetGroups(instanceIDs, scope.MachineStatus.TargetGroupARNs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe target groups")
+	}
+
+	for _, instanceID := range registered {
+		a.log.Info("Deregistering machine instance from target groups", "instance-id", instanceID, "target-group-arns", scope.MachineStatus.TargetGroupARNs)
+		if err := svc.DeregisterInstanceFromTargetGroups(instanceID, scope.MachineStatus.TargetGroupARNs); err != nil {
+			return nil, errors.Wrapf(err, "failed to deregister instance %q from target groups", instanceID)
+		}
+	}
+
+	return registered, nil
+}
+
+// difference returns the elements of a that aren't in b, in the order of a.
+func difference(a, b []string) []string {
+	in := map[string]bool{}
+	for _, s := range b {
+		in[s] = true
+	}
+
+	var res []string
+	for _, s := range a {
+		if !in[s] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestReconcileTargetGroups(t *testing.T) {
+	testCases := []struct {
+		name             string
+		desired          []string
+		registered       []string
+		expect           func(m *mocks.MockELBInterfaceMockRecorder)
+		expectRegistered []string
+	}{
+		{
+			name:   "no target groups",
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {},
+		},
+		{
+			name:    "instance is registered",
+			desired: []string{"tg-ingress", "tg-metrics"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.RegisterInstanceWithTargetGroups("i-1", []string{"tg-ingress", "tg-metrics"}).Return(nil)
+			},
+			expectRegistered: []string{"tg-ingress", "tg-metrics"},
+		},
+		{
+			name:             "instance is already registered",
+			desired:          []string{"tg-ingress"},
+			registered:       []string{"tg-ingress"},
+			expect:           func(m *mocks.MockELBInterfaceMockRecorder) {},
+			expectRegistered: []string{"tg-ingress"},
+		},
+		{
+			name:       "target groups changed",
+			desired:    []string{"tg-ingress", "tg-metrics"},
+			registered: []string{"tg-ingress", "tg-legacy"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.DeregisterInstanceFromTargetGroups("i-1", []string{"tg-legacy"}).Return(nil)
+				m.RegisterInstanceWithTargetGroups("i-1", []string{"tg-metrics"}).Return(nil)
+			},
+			expectRegistered: []string{"tg-ingress", "tg-metrics"},
+		},
+		{
+			name:       "target groups removed",
+			registered: []string{"tg-ingress"},
+			expect: func(m *mocks.MockELBInterfaceMockRecorder) {
+				m.DeregisterInstanceFromTargetGroups("i-1", []string{"tg-ingress"}).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockELBInterface(mockCtrl)
+			tc.expect(svc.EXPECT())
+
+			scope := &actuators.MachineScope{
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{TargetGroupARNs: tc.desired},
+				MachineStatus: &v1alpha1.AWSMachineProviderStatus{TargetGroupARNs: tc.registered},
+			}
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.reconcileTargetGroups(svc, scope, "i-1"); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !reflect.DeepEqual(scope.MachineStatus.TargetGroupARNs, tc.expectRegistered) {
+				t.Fatalf("expected target groups %v to be recorded, got %v", tc.expectRegistered, scope.MachineStatus.TargetGroupARNs)
+			}
+		})
+	}
+}
+
+func TestDeregisterTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	svc := mocks.NewMockELBInterface(mockCtrl)
+	svc.EXPECT().InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2", "i-3"}, []string{"tg-legacy"}).Return([]string{"i-1", "i-2"}, nil)
+	svc.EXPECT().DeregisterInstanceFromTargetGroups("i-1", []string{"tg-legacy"}).Return(nil)
+	svc.EXPECT().DeregisterInstanceFromTargetGroups("i-2", []string{"tg-legacy"}).Return(nil)
+
+	// The recorded target groups are deregistered, even if the spec changed.
+	scope := &actuators.MachineScope{
+		MachineConfig: &v1alpha1.AWSMachineProviderSpec{TargetGroupARNs: []string{"tg-ingress"}},
+		MachineStatus: &v1alpha1.AWSMachineProviderStatus{TargetGroupARNs: []string{"tg-legacy"}},
+	}
+
+	a := &Actuator{log: klogr.New()}
+	registered, err := a.deregisterTargetGroups(svc, scope, []string{"i-1", "i-2", "i-3"})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	// Only the instances still listed are deregistered, and waited for.
+	if !reflect.DeepEqual(registered, []string{"i-1", "i-2"}) {
+		t.Fatalf("expected instances [i-1 i-2] to be draining, got %v", registered)
+	}
+	if !reflect.DeepEqual(scope.MachineStatus.TargetGroupARNs, []string{"tg-legacy"}) {
+		t.Fatalf("expected the target groups to stay recorded, got %v", scope.MachineStatus.TargetGroupARNs)
+	}
+}
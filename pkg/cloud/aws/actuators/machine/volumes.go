@@ -0,0 +1,46 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// volumeEncryptionTagsChanged returns the encryption tags to create or update
+// and the ones to remove for the volume to match its encryption state. Volumes
+// are tagged with their encryption state so that audits can rely on the tags
+// without describing every volume.
+func volumeEncryptionTagsChanged(v *v1alpha1.Volume) (map[string]string, map[string]string) {
+	create := map[string]string{}
+	remove := map[string]string{}
+
+	if encrypted := strconv.FormatBool(v.Encrypted); v.Tags[v1alpha1.NameAWSVolumeEncrypted] != encrypted {
+		create[v1alpha1.NameAWSVolumeEncrypted] = encrypted
+	}
+
+	keyID, tagged := v.Tags[v1alpha1.NameAWSVolumeKMSKeyID]
+	switch {
+	case v.KMSKeyID != nil && keyID != *v.KMSKeyID:
+		create[v1alpha1.NameAWSVolumeKMSKeyID] = *v.KMSKeyID
+	case v.KMSKeyID == nil && tagged:
+		remove[v1alpha1.NameAWSVolumeKMSKeyID] = keyID
+	}
+
+	return create, remove
+}
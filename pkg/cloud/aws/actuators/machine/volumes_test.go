@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"k8s.io/klog/klogr"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/mocks"
+)
+
+func TestEnsureVolumeTagsEncryption(t *testing.T) {
+	const keyID = "arn:aws:kms:us-east-1:123456789012:key/abcd"
+
+	testCases := []struct {
+		name    string
+		volumes []*v1alpha1.Volume
+		expect  func(m *mocks.MockEC2InterfaceMockRecorder)
+	}{
+		{
+			name: "tags are up to date",
+			volumes: []*v1alpha1.Volume{
+				{
+					ID:        "vol-1",
+					Encrypted: true,
+					KMSKeyID:  aws.String(keyID),
+					Tags: map[string]string{
+						v1alpha1.NameAWSVolumeEncrypted: "true",
+						v1alpha1.NameAWSVolumeKMSKeyID:  keyID,
+					},
+				},
+				{
+					ID: "vol-2",
+					Tags: map[string]string{
+						v1alpha1.NameAWSVolumeEncrypted: "false",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {},
+		},
+		{
+			name: "missing tags are added",
+			volumes: []*v1alpha1.Volume{
+				{
+					ID:        "vol-1",
+					Encrypted: true,
+					KMSKeyID:  aws.String(keyID),
+				},
+				{
+					ID: "vol-2",
+					Tags: map[string]string{
+						"Name": "data",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("vol-1"), map[string]string{
+					v1alpha1.NameAWSVolumeEncrypted: "true",
+					v1alpha1.NameAWSVolumeKMSKeyID:  keyID,
+				}, map[string]string{}).Return(nil)
+				m.UpdateResourceTags(aws.String("vol-2"), map[string]string{
+					v1alpha1.NameAWSVolumeEncrypted: "false",
+				}, map[string]string{}).Return(nil)
+			},
+		},
+		{
+			name: "stale tags are corrected",
+			volumes: []*v1alpha1.Volume{
+				{
+					ID:        "vol-1",
+					Encrypted: true,
+					KMSKeyID:  aws.String(keyID),
+					Tags: map[string]string{
+						v1alpha1.NameAWSVolumeEncrypted: "false",
+						v1alpha1.NameAWSVolumeKMSKeyID:  "old-key",
+					},
+				},
+				{
+					ID: "vol-2",
+					Tags: map[string]string{
+						v1alpha1.NameAWSVolumeEncrypted: "false",
+						v1alpha1.NameAWSVolumeKMSKeyID:  "old-key",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2InterfaceMockRecorder) {
+				m.UpdateResourceTags(aws.String("vol-1"), map[string]string{
+					v1alpha1.NameAWSVolumeEncrypted: "true",
+					v1alpha1.NameAWSVolumeKMSKeyID:  keyID,
+				}, map[string]string{}).Return(nil)
+				m.UpdateResourceTags(aws.String("vol-2"), map[string]string{}, map[string]string{
+					v1alpha1.NameAWSVolumeKMSKeyID: "old-key",
+				}).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			svc := mocks.NewMockEC2Interface(mockCtrl)
+			svc.EXPECT().GetInstanceVolumes("i-1").Return(tc.volumes, nil)
+			tc.expect(svc.EXPECT())
+
+			a := &Actuator{log: klogr.New()}
+			if err := a.ensureVolumeTags(svc, "i-1", nil, nil); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationclient "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/certificates"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+)
+
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;update
+
+// ValidatingWebhookPath is the path the validating webhook of machines is served on.
+const ValidatingWebhookPath = "/validate-cluster-k8s-io-v1alpha1-machine"
+
+const (
+	webhookCertFile = "tls.crt"
+	webhookKeyFile  = "tls.key"
+	webhookCAFile   = "ca.crt"
+)
+
+// NewValidatingWebhook returns the admission webhook that rejects updates of
+// machines changing immutable fields of their instance, so that they fail on
+// apply rather than when the machine is reconciled.
+func NewValidatingWebhook() *admission.Webhook {
+	return &admission.Webhook{
+		Name: "validation.machine.awsprovider.k8s.io",
+		Type: types.WebhookTypeValidating,
+		Path: ValidatingWebhookPath,
+		Rules: []admissionregistrationv1beta1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1beta1.OperationType{admissionregistrationv1beta1.Update},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{clusterv1.SchemeGroupVersion.Group},
+					APIVersions: []string{clusterv1.SchemeGroupVersion.Version},
+					Resources:   []string{"machines"},
+				},
+			},
+		},
+		Handlers: []admission.Handler{admission.HandlerFunc(validateMachineUpdate)},
+	}
+}
+
+// validateMachineUpdate denies updates of machines changing immutable fields.
+// Machines whose provider spec is set from a machine class are only checked
+// when reconciled.
+func validateMachineUpdate(_ context.Context, req atypes.Request) atypes.Response {
+	if req.AdmissionRequest.Operation != admissionv1beta1.Update {
+		return admission.ValidationResponse(true, "")
+	}
+
+	oldMachine, newMachine := &clusterv1.Machine{}, &clusterv1.Machine{}
+	if err := json.Unmarshal(req.AdmissionRequest.OldObject.Raw, oldMachine); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, errors.Wrap(err, "failed to decode old machine"))
+	}
+	if err := json.Unmarshal(req.AdmissionRequest.Object.Raw, newMachine); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, errors.Wrap(err, "failed to decode machine"))
+	}
+
+	if oldMachine.Spec.ProviderSpec.Value == nil || newMachine.Spec.ProviderSpec.Value == nil {
+		return admission.ValidationResponse(true, "")
+	}
+
+	oldSpec, err := decodeMachineProviderSpec(oldMachine)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	newSpec, err := decodeMachineProviderSpec(newMachine)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	status, err := v1alpha1.MachineStatusFromProviderStatus(oldMachine.Status.ProviderStatus)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, errors.Wrapf(err, "failed to decode provider status of machine %q", oldMachine.Name))
+	}
+
+	if errs := ValidateImmutableFieldUpdate(oldSpec, newSpec, status); len(errs) > 0 {
+		return admission.ValidationResponse(false, errors.Wrapf(errs, "found attempt to change immutable state for machine %q", newMachine.Name).Error())
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+func decodeMachineProviderSpec(machine *clusterv1.Machine) (*v1alpha1.AWSMachineProviderSpec, error) {
+	spec := &v1alpha1.AWSMachineProviderSpec{}
+	if err := json.Unmarshal(machine.Spec.ProviderSpec.Value.Raw, spec); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode provider spec of machine %q", machine.Name)
+	}
+	return spec, nil
+}
+
+// ProvisionWebhookCertificate writes a serving certificate for the given
+// Service fronting the webhook to certDir, signed by a new self-signed CA,
+// unless certDir already holds one. It returns the CA certificate the API
+// server verifies the webhook with, or nil if the certificate in certDir was
+// provided without one.
+func ProvisionWebhookCertificate(certDir, serviceNamespace, serviceName string) ([]byte, error) {
+	if _, err := os.Stat(filepath.Join(certDir, webhookCertFile)); err == nil {
+		caBundle, err := ioutil.ReadFile(filepath.Join(certDir, webhookCAFile))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return caBundle, err
+	}
+
+	caCert, caKey, err := certificates.NewCertificateAuthority()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook certificate authority")
+	}
+
+	key, err := certificates.NewPrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook private key")
+	}
+
+	cfg := certificates.Config{
+		CommonName: fmt.Sprintf("%s.%s.svc", serviceName, serviceNamespace),
+		AltNames: certificates.AltNames{
+			DNSNames: []string{
+				serviceName,
+				fmt.Sprintf("%s.%s", serviceName, serviceNamespace),
+				fmt.Sprintf("%s.%s.svc", serviceName, serviceNamespace),
+			},
+		},
+		Usages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	cert, err := cfg.NewSignedCert(key, caCert, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook certificate")
+	}
+
+	caBundle := certificates.EncodeCertPEM(caCert)
+	files := map[string][]byte{
+		webhookCertFile: certificates.EncodeCertPEM(cert),
+		webhookKeyFile:  certificates.EncodePrivateKeyPEM(key),
+		webhookCAFile:   caBundle,
+	}
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create webhook certificate directory %q", certDir)
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(certDir, name), data, 0600); err != nil {
+			return nil, errors.Wrapf(err, "failed to write webhook certificate file %q", name)
+		}
+	}
+
+	return caBundle, nil
+}
+
+// InjectWebhookCABundle sets the CA bundle of the webhooks of the given
+// ValidatingWebhookConfiguration, so that the API server trusts the
+// certificate the webhook is served with.
+func InjectWebhookCABundle(client admissionregistrationclient.ValidatingWebhookConfigurationInterface, name string, caBundle []byte) error {
+	config, err := client.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get validating webhook configuration %q", name)
+	}
+
+	for i := range config.Webhooks {
+		config.Webhooks[i].ClientConfig.CABundle = caBundle
+	}
+
+	if _, err := client.Update(config); err != nil {
+		return errors.Wrapf(err, "failed to update validating webhook configuration %q", name)
+	}
+
+	return nil
+}
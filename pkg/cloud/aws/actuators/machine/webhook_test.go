@@ -0,0 +1,383 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	admissionregistrationclient "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+)
+
+func TestValidatingWebhook(t *testing.T) {
+	launched := &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")}
+
+	testCases := []struct {
+		name          string
+		operation     admissionv1beta1.Operation
+		oldSpec       *v1alpha1.AWSMachineProviderSpec
+		newSpec       *v1alpha1.AWSMachineProviderSpec
+		status        *v1alpha1.AWSMachineProviderStatus
+		expectAllowed bool
+		expectFields  []string
+	}{
+		{
+			name:          "unchanged spec",
+			operation:     admissionv1beta1.Update,
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large", KeyName: "key"},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large", KeyName: "key"},
+			status:        launched,
+			expectAllowed: true,
+		},
+		{
+			name:          "mutable field changed",
+			operation:     admissionv1beta1.Update,
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large"},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large", AdditionalTags: map[string]string{"team": "a"}},
+			status:        launched,
+			expectAllowed: true,
+		},
+		{
+			name:         "immutable fields changed",
+			operation:    admissionv1beta1.Update,
+			oldSpec:      &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large", KeyName: "key", Subnet: &v1alpha1.AWSResourceReference{ID: aws.String("subnet-1")}},
+			newSpec:      &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge", KeyName: "other-key", Subnet: &v1alpha1.AWSResourceReference{ID: aws.String("subnet-2")}},
+			status:       launched,
+			expectFields: []string{"instanceType", "keyName", "subnet.id"},
+		},
+		{
+			name:          "iam instance profile set by arn",
+			operation:     admissionv1beta1.Update,
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{IAMInstanceProfile: "nodes"},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{IAMInstanceProfile: "arn:aws:iam::123456789012:instance-profile/nodes"},
+			status:        launched,
+			expectAllowed: true,
+		},
+		{
+			name:          "optional field set",
+			operation:     admissionv1beta1.Update,
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{Tenancy: "dedicated", RootDeviceSize: 20},
+			status:        launched,
+			expectAllowed: true,
+		},
+		{
+			name:         "looked up ami replaced",
+			operation:    admissionv1beta1.Update,
+			oldSpec:      &v1alpha1.AWSMachineProviderSpec{},
+			newSpec:      &v1alpha1.AWSMachineProviderSpec{AMI: v1alpha1.AWSResourceReference{ID: aws.String("ami-2")}},
+			status:       &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1"), AMIID: aws.String("ami-1")},
+			expectFields: []string{"ami"},
+		},
+		{
+			name:          "instance not created yet",
+			operation:     admissionv1beta1.Update,
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large"},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge"},
+			status:        &v1alpha1.AWSMachineProviderStatus{},
+			expectAllowed: true,
+		},
+		{
+			name:          "spec from a machine class",
+			operation:     admissionv1beta1.Update,
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge"},
+			status:        launched,
+			expectAllowed: true,
+		},
+		{
+			name:          "creation",
+			operation:     admissionv1beta1.Create,
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge"},
+			status:        launched,
+			expectAllowed: true,
+		},
+	}
+
+	wh := NewValidatingWebhook()
+	if err := wh.Validate(); err != nil {
+		t.Fatalf("invalid webhook: %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &admissionv1beta1.AdmissionRequest{
+				UID:       "1",
+				Operation: tc.operation,
+				Object:    encodeTestMachine(t, tc.newSpec, tc.status),
+				OldObject: encodeTestMachine(t, tc.oldSpec, tc.status),
+			}
+
+			resp := wh.Handle(context.Background(), atypes.Request{AdmissionRequest: req})
+			if resp.Response.Allowed != tc.expectAllowed {
+				t.Fatalf("expected allowed to be %v, got %+v", tc.expectAllowed, resp.Response.Result)
+			}
+			for _, field := range tc.expectFields {
+				if !strings.Contains(string(resp.Response.Result.Reason), field+" cannot be mutated") {
+					t.Errorf("expected the response to reject changing %s, got %q", field, resp.Response.Result.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookAdmissionReview(t *testing.T) {
+	certDir, err := ioutil.TempDir("", "webhook-cert")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(certDir)
+
+	caBundle, err := ProvisionWebhookCertificate(certDir, "aws-provider-system", "aws-provider-webhook-service")
+	if err != nil {
+		t.Fatalf("failed to provision webhook certificate: %v", err)
+	}
+	cert, err := tls.LoadX509KeyPair(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		t.Fatalf("failed to load webhook certificate: %v", err)
+	}
+
+	wh := NewValidatingWebhook()
+	mux := http.NewServeMux()
+	mux.Handle(wh.GetPath(), wh.Handler())
+	srv := httptest.NewUnstartedServer(mux)
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// Verify the webhook the way the API server does, by the Service name and
+	// the CA bundle of the webhook configuration.
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		t.Fatal("failed to parse the webhook CA bundle")
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				ServerName: "aws-provider-webhook-service.aws-provider-system.svc",
+			},
+		},
+	}
+
+	launched := &v1alpha1.AWSMachineProviderStatus{InstanceID: aws.String("i-1")}
+
+	testCases := []struct {
+		name          string
+		oldSpec       *v1alpha1.AWSMachineProviderSpec
+		newSpec       *v1alpha1.AWSMachineProviderSpec
+		expectAllowed bool
+		expectReason  string
+	}{
+		{
+			name:          "mutable field changed",
+			oldSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large"},
+			newSpec:       &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large", AdditionalTags: map[string]string{"team": "a"}},
+			expectAllowed: true,
+		},
+		{
+			name:         "immutable field changed",
+			oldSpec:      &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.large"},
+			newSpec:      &v1alpha1.AWSMachineProviderSpec{InstanceType: "m5.xlarge"},
+			expectReason: "instanceType cannot be mutated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			review := admissionv1beta1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: admissionv1beta1.SchemeGroupVersion.String(),
+					Kind:       "AdmissionReview",
+				},
+				Request: &admissionv1beta1.AdmissionRequest{
+					UID: "review-1",
+					Kind: metav1.GroupVersionKind{
+						Group:   clusterv1.SchemeGroupVersion.Group,
+						Version: clusterv1.SchemeGroupVersion.Version,
+						Kind:    "Machine",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    clusterv1.SchemeGroupVersion.Group,
+						Version:  clusterv1.SchemeGroupVersion.Version,
+						Resource: "machines",
+					},
+					Name:      "machine",
+					Namespace: "default",
+					Operation: admissionv1beta1.Update,
+					Object:    encodeTestMachine(t, tc.newSpec, launched),
+					OldObject: encodeTestMachine(t, tc.oldSpec, launched),
+				},
+			}
+			body, err := json.Marshal(review)
+			if err != nil {
+				t.Fatalf("failed to encode admission review: %v", err)
+			}
+
+			resp, err := client.Post(srv.URL+ValidatingWebhookPath, "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to call webhook: %v", err)
+			}
+			defer resp.Body.Close()
+
+			out := admissionv1beta1.AdmissionReview{}
+			if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+				t.Fatalf("failed to decode admission review: %v", err)
+			}
+			if out.Response == nil {
+				t.Fatal("expected the admission review to have a response")
+			}
+			if out.Response.UID != review.Request.UID {
+				t.Errorf("expected response UID %q, got %q", review.Request.UID, out.Response.UID)
+			}
+			if out.Response.Allowed != tc.expectAllowed {
+				t.Fatalf("expected allowed to be %v, got %+v", tc.expectAllowed, out.Response.Result)
+			}
+			if tc.expectReason != "" && !strings.Contains(string(out.Response.Result.Reason), tc.expectReason) {
+				t.Errorf("expected the response reason to contain %q, got %q", tc.expectReason, out.Response.Result.Reason)
+			}
+		})
+	}
+}
+
+func TestProvisionWebhookCertificate(t *testing.T) {
+	certDir, err := ioutil.TempDir("", "webhook-cert")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(certDir)
+
+	caBundle, err := ProvisionWebhookCertificate(certDir, "ns", "svc")
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if len(caBundle) == 0 {
+		t.Fatal("expected a CA bundle")
+	}
+
+	// A certificate already in the directory is kept, e.g. after a restart.
+	again, err := ProvisionWebhookCertificate(certDir, "ns", "svc")
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if !bytes.Equal(again, caBundle) {
+		t.Fatal("expected the existing certificate to be kept")
+	}
+
+	// A certificate provided without its CA isn't replaced.
+	if err := os.Remove(filepath.Join(certDir, "ca.crt")); err != nil {
+		t.Fatalf("failed to remove CA certificate: %v", err)
+	}
+	provided, err := ProvisionWebhookCertificate(certDir, "ns", "svc")
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if provided != nil {
+		t.Fatalf("did not expect a CA bundle for a provided certificate, got %q", provided)
+	}
+}
+
+func TestInjectWebhookCABundle(t *testing.T) {
+	configs := &memoryValidatingWebhookConfigurations{
+		configs: map[string]*admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+			"webhooks": {
+				ObjectMeta: metav1.ObjectMeta{Name: "webhooks"},
+				Webhooks: []admissionregistrationv1beta1.Webhook{
+					{Name: "validation.machine.awsprovider.k8s.io"},
+				},
+			},
+		},
+	}
+
+	if err := InjectWebhookCABundle(configs, "webhooks", []byte("ca")); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if caBundle := configs.configs["webhooks"].Webhooks[0].ClientConfig.CABundle; !reflect.DeepEqual(caBundle, []byte("ca")) {
+		t.Fatalf("expected the CA bundle to be set, got %q", caBundle)
+	}
+
+	if err := InjectWebhookCABundle(configs, "missing", []byte("ca")); err == nil {
+		t.Fatal("expected an error for a missing webhook configuration")
+	}
+}
+
+type memoryValidatingWebhookConfigurations struct {
+	admissionregistrationclient.ValidatingWebhookConfigurationInterface
+	configs map[string]*admissionregistrationv1beta1.ValidatingWebhookConfiguration
+}
+
+func (m *memoryValidatingWebhookConfigurations) Get(name string, _ metav1.GetOptions) (*admissionregistrationv1beta1.ValidatingWebhookConfiguration, error) {
+	config, ok := m.configs[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(admissionregistrationv1beta1.Resource("validatingwebhookconfigurations"), name)
+	}
+	return config.DeepCopy(), nil
+}
+
+func (m *memoryValidatingWebhookConfigurations) Update(config *admissionregistrationv1beta1.ValidatingWebhookConfiguration) (*admissionregistrationv1beta1.ValidatingWebhookConfiguration, error) {
+	m.configs[config.Name] = config.DeepCopy()
+	return config, nil
+}
+
+func encodeTestMachine(t *testing.T, spec *v1alpha1.AWSMachineProviderSpec, status *v1alpha1.AWSMachineProviderStatus) runtime.RawExtension {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+	}
+
+	if spec == nil {
+		machine.Spec.ProviderSpec.ValueFrom = &clusterv1.ProviderSpecSource{
+			MachineClass: &clusterv1.MachineClassRef{Provider: "aws"},
+		}
+	} else {
+		value, err := v1alpha1.EncodeMachineSpec(spec)
+		if err != nil {
+			t.Fatalf("failed to encode machine spec: %v", err)
+		}
+		machine.Spec.ProviderSpec.Value = value
+	}
+
+	providerStatus, err := v1alpha1.EncodeMachineStatus(status)
+	if err != nil {
+		t.Fatalf("failed to encode machine status: %v", err)
+	}
+	machine.Status.ProviderStatus = providerStatus
+
+	raw, err := json.Marshal(machine)
+	if err != nil {
+		t.Fatalf("failed to encode machine: %v", err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"crypto/tls"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// tlsHandshakeTimeout is how long to wait for the control plane to complete a
+// TLS handshake when checking whether its certificate can be verified.
+const tlsHandshakeTimeout = 10 * time.Second
+
+// workloadClientCache holds the clients of the control planes of workload
+// clusters, keyed by cluster UID, so that they aren't rebuilt on every
+// reconcile. The zero value is an empty cache.
+type workloadClientCache struct {
+	lock    sync.Mutex
+	clients map[types.UID]corev1.CoreV1Interface
+}
+
+// get returns the cached client of the cluster, if any.
+func (c *workloadClientCache) get(uid types.UID) (corev1.CoreV1Interface, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	client, ok := c.clients[uid]
+	return client, ok
+}
+
+// set caches the client of the cluster.
+func (c *workloadClientCache) set(uid types.UID, client corev1.CoreV1Interface) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.clients == nil {
+		c.clients = map[types.UID]corev1.CoreV1Interface{}
+	}
+	c.clients[uid] = client
+}
+
+// invalidate drops the cached client of the cluster, so that it's rebuilt the
+// next time it's needed.
+func (c *workloadClientCache) invalidate(uid types.UID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.clients, uid)
+}
+
+// workloadClientConfig returns the config of a client for the control plane of
+// a workload cluster at controlPlaneURL, from the kubeconfig of the cluster.
+// The control plane certificate is verified against the CA of the kubeconfig,
+// and the CA bundle of the actuator if one is configured. If it can't be
+// verified, e.g. because it doesn't include the load balancer DNS name, and the
+// insecure fallback is enabled, a config that skips verification is returned
+// instead.
+func (a *Actuator) workloadClientConfig(controlPlaneURL, kubeConfig string) (*rest.Config, error) {
+	config, err := clientcmd.BuildConfigFromKubeconfigGetter(controlPlaneURL, func() (*clientcmdapi.Config, error) {
+		return clientcmd.Load([]byte(kubeConfig))
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client config for cluster at %q", controlPlaneURL)
+	}
+
+	if len(a.workloadClusterCABundle) > 0 {
+		config.CAData = append(append([]byte{}, config.CAData...), '\n')
+		config.CAData = append(config.CAData, a.workloadClusterCABundle...)
+	}
+
+	if !a.workloadClusterInsecureFallback {
+		return config, nil
+	}
+
+	verifyErr := tlsHandshake(config)
+	if verifyErr == nil {
+		return config, nil
+	}
+
+	insecure := rest.CopyConfig(config)
+	insecure.Insecure = true
+	insecure.CAData = nil
+	insecure.CAFile = ""
+
+	// If the control plane can't be reached at all, there's nothing to fall
+	// back from: keep verifying and let the caller fail on the secure client.
+	if err := tlsHandshake(insecure); err != nil {
+		return config, nil
+	}
+
+	a.log.Info("WARNING: the control plane certificate could not be verified, falling back to skipping TLS verification",
+		"url", controlPlaneURL, "reason", verifyErr.Error())
+	return insecure, nil
+}
+
+// tlsHandshake completes a TLS handshake with the host of the config.
+func tlsHandshake(config *rest.Config) error {
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse host %q", config.Host)
+	}
+	if u.Scheme != "https" {
+		return nil
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		return err
+	}
+	if tlsConfig == nil {
+		// Without any TLS options, the client verifies against the system roots.
+		tlsConfig = &tls.Config{}
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: tlsHandshakeTimeout}, "tcp", u.Host, tlsConfig)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
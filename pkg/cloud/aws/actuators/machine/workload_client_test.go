@@ -0,0 +1,155 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/klogr"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestCoreV1ClientCache(t *testing.T) {
+	client, err := corev1.NewForConfig(&rest.Config{Host: "https://example.com:6443"})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "cluster-uid"}}
+
+	a := &Actuator{}
+	a.workloadClients.set(cluster.UID, client)
+
+	// The actuator has no deployer to look up the control plane with, so
+	// this only succeeds if the cached client is returned.
+	cached, err := a.coreV1Client(cluster)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if cached != client {
+		t.Fatal("expected the cached client")
+	}
+
+	if _, ok := a.workloadClients.get("other-uid"); ok {
+		t.Fatal("did not expect a client for another cluster")
+	}
+
+	a.workloadClients.invalidate(cluster.UID)
+	if _, ok := a.workloadClients.get(cluster.UID); ok {
+		t.Fatal("expected the client to be invalidated")
+	}
+}
+
+func TestWorkloadClientConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	unreachable := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachableURL := unreachable.URL
+	unreachable.Close()
+
+	testCases := []struct {
+		name             string
+		url              string
+		kubeconfigCA     []byte
+		caBundle         []byte
+		insecureFallback bool
+		expectInsecure   bool
+		expectVerified   bool
+	}{
+		{
+			name:             "verified by the kubeconfig CA",
+			url:              server.URL,
+			kubeconfigCA:     serverCA,
+			insecureFallback: true,
+			expectVerified:   true,
+		},
+		{
+			name:             "verified by the CA bundle",
+			url:              server.URL,
+			caBundle:         serverCA,
+			insecureFallback: true,
+			expectVerified:   true,
+		},
+		{
+			name: "not verified without fallback",
+			url:  server.URL,
+		},
+		{
+			name:             "not verified with fallback",
+			url:              server.URL,
+			insecureFallback: true,
+			expectInsecure:   true,
+		},
+		{
+			name:             "unreachable with fallback",
+			url:              unreachableURL,
+			insecureFallback: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeConfig := clientcmdapi.NewConfig()
+			kubeConfig.Clusters["workload"] = &clientcmdapi.Cluster{
+				Server:                   tc.url,
+				CertificateAuthorityData: tc.kubeconfigCA,
+			}
+			kubeConfig.AuthInfos["admin"] = &clientcmdapi.AuthInfo{Token: "token"}
+			kubeConfig.Contexts["workload"] = &clientcmdapi.Context{Cluster: "workload", AuthInfo: "admin"}
+			kubeConfig.CurrentContext = "workload"
+
+			data, err := clientcmd.Write(*kubeConfig)
+			if err != nil {
+				t.Fatalf("failed to write kubeconfig: %v", err)
+			}
+
+			a := &Actuator{
+				log:                             klogr.New(),
+				workloadClusterCABundle:         tc.caBundle,
+				workloadClusterInsecureFallback: tc.insecureFallback,
+			}
+
+			config, err := a.workloadClientConfig(tc.url, string(data))
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if config.Insecure != tc.expectInsecure {
+				t.Fatalf("expected insecure to be %v, got %v", tc.expectInsecure, config.Insecure)
+			}
+			if tc.expectInsecure && len(config.CAData) > 0 {
+				t.Fatal("expected no CA data on an insecure config")
+			}
+			if tc.expectVerified {
+				if err := tlsHandshake(config); err != nil {
+					t.Fatalf("expected the control plane certificate to be verified: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -169,7 +169,7 @@ func (m *MachineScope) Close() {
 	}
 
 	// Do not update status if the statuses are the same
-	if reflect.DeepEqual(m.MachineStatus, oldStatus) {
+	if reflect.DeepEqual(m.MachineStatus, oldStatus) && reflect.DeepEqual(m.Machine.Status, m.MachineCopy.Status) {
 		return
 	}
 
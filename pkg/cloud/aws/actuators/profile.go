@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"sync"
+)
+
+var (
+	profileMu sync.Mutex
+	profile   string
+)
+
+// SetProfile sets the named profile of the AWS shared config and credentials
+// files that sessions created from then on use, e.g. for local testing. An
+// empty name uses the default credential chain. It is meant to be called once
+// on startup, before any scope is created.
+func SetProfile(name string) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	profile = name
+}
+
+func currentProfile() string {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	return profile
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+func TestSessionForClusterProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "aws-profile")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	credentials := filepath.Join(dir, "credentials")
+	contents := "[default]\naws_access_key_id = default-key\naws_secret_access_key = default-secret\n\n" +
+		"[testing]\naws_access_key_id = testing-key\naws_secret_access_key = testing-secret\n"
+	if err := ioutil.WriteFile(credentials, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write credentials file: %v", err)
+	}
+
+	env := map[string]string{
+		"AWS_SHARED_CREDENTIALS_FILE": credentials,
+		"AWS_CONFIG_FILE":             filepath.Join(dir, "config"),
+		"AWS_ACCESS_KEY_ID":           "",
+		"AWS_SECRET_ACCESS_KEY":       "",
+		"AWS_SESSION_TOKEN":           "",
+		"AWS_PROFILE":                 "",
+		"AWS_DEFAULT_PROFILE":         "",
+	}
+	for k, v := range env {
+		old, ok := os.LookupEnv(k)
+		if ok {
+			defer os.Setenv(k, old)
+		} else {
+			defer os.Unsetenv(k)
+		}
+		if v == "" {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, v)
+		}
+	}
+	defer SetProfile("")
+
+	testCases := []struct {
+		name      string
+		profile   string
+		expectKey string
+	}{
+		{
+			name:      "default profile",
+			expectKey: "default-key",
+		},
+		{
+			name:      "named profile",
+			profile:   "testing",
+			expectKey: "testing-key",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetProfile(tc.profile)
+
+			s, err := sessionForCluster(&v1alpha1.AWSClusterProviderSpec{Region: "eu-west-3"})
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			creds, err := s.Config.Credentials.Get()
+			if err != nil {
+				t.Fatalf("failed to get credentials: %v", err)
+			}
+			if creds.AccessKeyID != tc.expectKey {
+				t.Fatalf("expected access key %q, got %q", tc.expectKey, creds.AccessKeyID)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a throttled or failed AWS API call is retried.
+	DefaultMaxRetries = 8
+
+	// UseDefaultMaxRetries can be set as the MaxRetries of a Retryer to retry
+	// calls DefaultMaxRetries times.
+	UseDefaultMaxRetries = -1
+
+	// DefaultMinRetryDelay is the default delay before the first retry of an AWS API call.
+	DefaultMinRetryDelay = 500 * time.Millisecond
+
+	// DefaultMaxRetryDelay is the default cap on the delay between retries of an AWS API call.
+	DefaultMaxRetryDelay = 20 * time.Second
+)
+
+var (
+	retryerMu sync.Mutex
+	retryer   = NewRetryer(RetryerParams{MaxRetries: UseDefaultMaxRetries})
+)
+
+// RetryerParams defines the input parameters used to create a new Retryer.
+type RetryerParams struct {
+	// MaxRetries is the number of times a call is retried, zero to not retry calls.
+	// Negative values, e.g. UseDefaultMaxRetries, default to DefaultMaxRetries.
+	MaxRetries int
+	// MinDelay is the delay before the first retry. Defaults to DefaultMinRetryDelay.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between retries. Defaults to DefaultMaxRetryDelay.
+	MaxDelay time.Duration
+}
+
+// Retryer retries AWS API calls that were throttled, e.g. with RequestLimitExceeded,
+// or failed with a transient error, backing off exponentially up to a cap.
+type Retryer struct {
+	client.DefaultRetryer
+
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+// NewRetryer returns a Retryer for the given parameters.
+func NewRetryer(params RetryerParams) Retryer {
+	r := Retryer{
+		DefaultRetryer: client.DefaultRetryer{NumMaxRetries: params.MaxRetries},
+		minDelay:       params.MinDelay,
+		maxDelay:       params.MaxDelay,
+	}
+
+	if r.NumMaxRetries < 0 {
+		r.NumMaxRetries = DefaultMaxRetries
+	}
+	if r.minDelay <= 0 {
+		r.minDelay = DefaultMinRetryDelay
+	}
+	if r.maxDelay <= 0 {
+		r.maxDelay = DefaultMaxRetryDelay
+	}
+	if r.maxDelay < r.minDelay {
+		r.maxDelay = r.minDelay
+	}
+
+	return r
+}
+
+// RetryRules returns the delay before retrying the request: the minimum delay
+// doubled for every previous retry, jittered down by up to half, and capped.
+func (r Retryer) RetryRules(req *request.Request) time.Duration {
+	delay := r.maxDelay
+	if req.RetryCount < 32 {
+		if d := r.minDelay << uint(req.RetryCount); d > 0 && d < r.maxDelay {
+			delay = d
+		}
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// SetRetryer sets the retryer of the AWS clients created from then on. It is
+// meant to be called once on startup, before any scope is created.
+func SetRetryer(r Retryer) {
+	retryerMu.Lock()
+	defer retryerMu.Unlock()
+	retryer = r
+}
+
+func currentRetryer() Retryer {
+	retryerMu.Lock()
+	defer retryerMu.Unlock()
+	return retryer
+}
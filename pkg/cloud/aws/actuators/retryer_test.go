@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestNewRetryer(t *testing.T) {
+	r := NewRetryer(RetryerParams{MaxRetries: UseDefaultMaxRetries})
+	if r.MaxRetries() != DefaultMaxRetries || r.minDelay != DefaultMinRetryDelay || r.maxDelay != DefaultMaxRetryDelay {
+		t.Fatalf("expected default retryer settings, got %d retries, delays %v to %v", r.MaxRetries(), r.minDelay, r.maxDelay)
+	}
+
+	r = NewRetryer(RetryerParams{MaxRetries: 3, MinDelay: time.Second, MaxDelay: time.Millisecond})
+	if r.MaxRetries() != 3 || r.minDelay != time.Second || r.maxDelay != time.Second {
+		t.Fatalf("expected configured retryer settings, got %d retries, delays %v to %v", r.MaxRetries(), r.minDelay, r.maxDelay)
+	}
+
+	r = NewRetryer(RetryerParams{MaxRetries: 0})
+	if r.MaxRetries() != 0 {
+		t.Fatalf("expected retries to be disabled, got %d retries", r.MaxRetries())
+	}
+}
+
+func TestRetryerRetryRules(t *testing.T) {
+	r := NewRetryer(RetryerParams{MinDelay: time.Second, MaxDelay: 10 * time.Second})
+
+	testCases := []struct {
+		retryCount int
+		min        time.Duration
+		max        time.Duration
+	}{
+		{retryCount: 0, min: 500 * time.Millisecond, max: time.Second},
+		{retryCount: 2, min: 2 * time.Second, max: 4 * time.Second},
+		{retryCount: 4, min: 5 * time.Second, max: 10 * time.Second},
+		{retryCount: 100, min: 5 * time.Second, max: 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		for i := 0; i < 100; i++ {
+			delay := r.RetryRules(&request.Request{RetryCount: tc.retryCount})
+			if delay < tc.min || delay > tc.max {
+				t.Fatalf("retry %d: expected a delay between %v and %v, got %v", tc.retryCount, tc.min, tc.max, delay)
+			}
+		}
+	}
+}
+
+func TestRetryerShouldRetryThrottling(t *testing.T) {
+	r := NewRetryer(RetryerParams{MaxRetries: UseDefaultMaxRetries})
+
+	req := &request.Request{
+		HTTPResponse: &http.Response{StatusCode: http.StatusServiceUnavailable},
+		Error:        awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil),
+	}
+	if !r.ShouldRetry(req) {
+		t.Fatal("expected throttled request to be retried")
+	}
+
+	req = &request.Request{
+		HTTPResponse: &http.Response{StatusCode: http.StatusBadRequest},
+		Error:        awserr.New("InvalidParameterValue", "", nil),
+	}
+	if r.ShouldRetry(req) {
+		t.Fatal("expected invalid request not to be retried")
+	}
+}
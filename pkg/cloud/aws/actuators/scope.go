@@ -22,9 +22,15 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,20 +45,59 @@ var (
 	sessionCache sync.Map
 )
 
-const apiEndpointPort = 6443
+// DefaultAPIServerPort is the port the kube-apiserver of control plane
+// machines binds to, kubeadm's default, and the API server load balancer
+// listens on, unless the cluster sets another one.
+const DefaultAPIServerPort = 6443
 
-func sessionForRegion(region string) (*session.Session, error) {
-	s, ok := sessionCache.Load(region)
+// APIServerPort returns the port the API server of the cluster with the given
+// provider spec is served on.
+func APIServerPort(config *v1alpha1.AWSClusterProviderSpec) int64 {
+	if config != nil && config.APIServerPort > 0 {
+		return config.APIServerPort
+	}
+	return DefaultAPIServerPort
+}
+
+// sessionForCluster returns the AWS session for the region of the cluster,
+// resolving service endpoints with the partition and endpoint overrides of
+// the cluster if any, and credentials from the named profile set with
+// SetProfile if any. Sessions are shared by clusters with the same settings.
+func sessionForCluster(clusterConfig *v1alpha1.AWSClusterProviderSpec) (*session.Session, error) {
+	profile := currentProfile()
+	key, err := json.Marshal([]interface{}{clusterConfig.Region, clusterConfig.Partition, clusterConfig.ServiceEndpoints, profile})
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := sessionCache.Load(string(key))
 	if ok {
 		return s.(*session.Session), nil
 	}
 
-	ns, err := session.NewSession(aws.NewConfig().WithRegion(region))
+	config := aws.NewConfig().WithRegion(clusterConfig.Region)
+	if clusterConfig.Partition != "" || len(clusterConfig.ServiceEndpoints) > 0 {
+		resolver, err := endpointResolver(clusterConfig.Partition, clusterConfig.ServiceEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		config = config.WithEndpointResolver(resolver)
+	}
+
+	opts := session.Options{
+		Config: *request.WithRetryer(config, currentRetryer()),
+	}
+	if profile != "" {
+		opts.Profile = profile
+		opts.SharedConfigState = session.SharedConfigEnable
+	}
+
+	ns, err := session.NewSessionWithOptions(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionCache.Store(region, ns)
+	sessionCache.Store(string(key), ns)
 	return ns, nil
 }
 
@@ -81,7 +126,7 @@ func NewScope(params ScopeParams) (*Scope, error) {
 		return nil, errors.Errorf("failed to load cluster provider status: %v", err)
 	}
 
-	session, err := sessionForRegion(clusterConfig.Region)
+	session, err := sessionForCluster(clusterConfig)
 	if err != nil {
 		return nil, errors.Errorf("failed to create aws session: %v", err)
 	}
@@ -94,6 +139,26 @@ func NewScope(params ScopeParams) (*Scope, error) {
 		params.AWSClients.ELB = elb.New(session)
 	}
 
+	if params.AWSClients.ELBV2 == nil {
+		params.AWSClients.ELBV2 = elbv2.New(session)
+	}
+
+	if params.AWSClients.Route53 == nil {
+		params.AWSClients.Route53 = route53.New(session)
+	}
+
+	if params.AWSClients.SSM == nil {
+		params.AWSClients.SSM = ssm.New(session)
+	}
+
+	if params.AWSClients.AutoScaling == nil {
+		params.AWSClients.AutoScaling = autoscaling.New(session)
+	}
+
+	if params.AWSClients.STS == nil {
+		params.AWSClients.STS = sts.New(session)
+	}
+
 	var clusterClient client.ClusterInterface
 	if params.Client != nil {
 		clusterClient = params.Client.Clusters(params.Cluster.Namespace)
@@ -111,6 +176,7 @@ func NewScope(params ScopeParams) (*Scope, error) {
 		ClusterConfig: clusterConfig,
 		ClusterStatus: clusterStatus,
 		Logger:        params.Logger.WithName(params.Cluster.APIVersion).WithName(params.Cluster.Namespace).WithName(params.Cluster.Name),
+		session:       session,
 	}, nil
 }
 
@@ -124,6 +190,10 @@ type Scope struct {
 	ClusterConfig *v1alpha1.AWSClusterProviderSpec
 	ClusterStatus *v1alpha1.AWSClusterProviderStatus
 	logr.Logger
+
+	// session is the AWS session the clients were created from, shared by
+	// scopes with the same settings.
+	session *session.Session
 }
 
 // Network returns the cluster network object.
@@ -146,6 +216,11 @@ func (s *Scope) SecurityGroups() map[v1alpha1.SecurityGroupRole]*v1alpha1.Securi
 	return s.ClusterStatus.Network.SecurityGroups
 }
 
+// APIServerPort returns the port the API server of the cluster is served on.
+func (s *Scope) APIServerPort() int64 {
+	return APIServerPort(s.ClusterConfig)
+}
+
 // Name returns the cluster name.
 func (s *Scope) Name() string {
 	return s.Cluster.Name
@@ -209,7 +284,7 @@ func (s *Scope) Close() {
 		s.Cluster.Status.APIEndpoints = []clusterv1.APIEndpoint{
 			{
 				Host: s.ClusterStatus.Network.APIServerELB.DNSName,
-				Port: apiEndpointPort,
+				Port: int(s.APIServerPort()),
 			},
 		}
 	}
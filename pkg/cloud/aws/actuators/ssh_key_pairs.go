@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuators
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	apicorev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	// SSHKeyPairLabelName is the label set on the Secrets holding the private keys of the SSH key pairs
+	// created for a cluster.
+	SSHKeyPairLabelName = "aws.cluster.sigs.k8s.io/ssh-key-pair"
+
+	// SSHKeyPairNameAnnotation is the annotation recording the name of the SSH key pair on its Secret.
+	SSHKeyPairNameAnnotation = "aws.cluster.sigs.k8s.io/ssh-key-pair-name"
+)
+
+// SSHKeyPairSecretName returns the name of the Secret holding the private key of an SSH key pair created for
+// the cluster.
+func SSHKeyPairSecretName(cluster *v1alpha1.Cluster, keyName string) string {
+	return fmt.Sprintf("%s-ssh-key-%s", cluster.Name, keyName)
+}
+
+// SSHKeyPairLabels returns the labels of the Secrets holding the private keys of SSH key pairs, which associate
+// them with the cluster.
+func SSHKeyPairLabels(cluster *v1alpha1.Cluster) map[string]string {
+	return map[string]string{
+		v1alpha1.MachineClusterLabelName: cluster.Name,
+		SSHKeyPairLabelName:              "true",
+	}
+}
+
+// FindSSHKeyPairSecrets returns the Secrets holding the private keys of the SSH key pairs created for the cluster.
+func FindSSHKeyPairSecrets(client corev1.SecretsGetter, cluster *v1alpha1.Cluster) ([]apicorev1.Secret, error) {
+	options := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=true", v1alpha1.MachineClusterLabelName, cluster.Name, SSHKeyPairLabelName),
+	}
+
+	list, err := client.Secrets(cluster.Namespace).List(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list SSH key pair secrets for cluster %q", cluster.Name)
+	}
+
+	return list.Items, nil
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package converters
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 )
 
@@ -42,6 +44,48 @@ func SDKToInstance(v *ec2.Instance) *v1alpha1.Instance {
 		EBSOptimized: v.EbsOptimized,
 	}
 
+	if v.LaunchTime != nil {
+		i.LaunchTime = &metav1.Time{Time: *v.LaunchTime}
+	}
+
+	i.SecondaryPrivateIPs = SecondaryPrivateIPs(v)
+	i.NetworkInterfaceIDs = NetworkInterfaceIDs(v)
+
+	if v.Placement != nil {
+		i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+		i.Tenancy = aws.StringValue(v.Placement.Tenancy)
+		i.HostID = v.Placement.HostId
+		i.Affinity = aws.StringValue(v.Placement.Affinity)
+	}
+
+	i.SourceDestCheck = v.SourceDestCheck
+
+	if v.HibernationOptions != nil {
+		i.HibernationConfigured = aws.BoolValue(v.HibernationOptions.Configured)
+	}
+
+	i.CapacityReservationID = v.CapacityReservationId
+	if v.CapacityReservationSpecification != nil {
+		i.CapacityReservationPreference = v.CapacityReservationSpecification.CapacityReservationPreference
+		if target := v.CapacityReservationSpecification.CapacityReservationTarget; target != nil {
+			i.CapacityReservationResourceGroupARN = target.CapacityReservationResourceGroupArn
+		}
+	}
+
+	if v.MetadataOptions != nil && v.MetadataOptions.HttpTokens != nil {
+		i.HTTPTokens = v1alpha1.HTTPTokensState(*v.MetadataOptions.HttpTokens)
+	}
+
+	if v.Monitoring != nil && v.Monitoring.State != nil {
+		i.MonitoringState = *v.Monitoring.State
+		switch i.MonitoringState {
+		case ec2.MonitoringStateEnabled, ec2.MonitoringStatePending:
+			i.Monitoring = aws.Bool(true)
+		default:
+			i.Monitoring = aws.Bool(false)
+		}
+	}
+
 	// Extract IAM Instance Profile name from ARN
 	// TODO: Handle this comparison more safely, perhaps by querying IAM for the
 	// instance profile ARN and comparing to the ARN returned by EC2
@@ -62,3 +106,42 @@ func SDKToInstance(v *ec2.Instance) *v1alpha1.Instance {
 
 	return i
 }
+
+// SecondaryPrivateIPs returns the secondary private IPv4 addresses of the
+// primary network interface of an EC2 instance.
+func SecondaryPrivateIPs(v *ec2.Instance) []string {
+	var ips []string
+	for _, eni := range v.NetworkInterfaces {
+		if eni.Attachment == nil || aws.Int64Value(eni.Attachment.DeviceIndex) != 0 {
+			continue
+		}
+
+		for _, ip := range eni.PrivateIpAddresses {
+			if !aws.BoolValue(ip.Primary) {
+				ips = append(ips, aws.StringValue(ip.PrivateIpAddress))
+			}
+		}
+	}
+	return ips
+}
+
+// NetworkInterfaceIDs returns the IDs of the network interfaces attached to an
+// EC2 instance, ordered by device index.
+func NetworkInterfaceIDs(v *ec2.Instance) []string {
+	enis := make([]*ec2.InstanceNetworkInterface, 0, len(v.NetworkInterfaces))
+	for _, eni := range v.NetworkInterfaces {
+		if eni.Attachment != nil {
+			enis = append(enis, eni)
+		}
+	}
+
+	sort.Slice(enis, func(i, j int) bool {
+		return aws.Int64Value(enis[i].Attachment.DeviceIndex) < aws.Int64Value(enis[j].Attachment.DeviceIndex)
+	})
+
+	var ids []string
+	for _, eni := range enis {
+		ids = append(ids, aws.StringValue(eni.NetworkInterfaceId))
+	}
+	return ids
+}
@@ -135,3 +135,20 @@ func (ec2Filters) SubnetStates(states ...string) *ec2.Filter {
 		Values: aws.StringSlice(states),
 	}
 }
+
+// HostResourceGroup returns a filter based on the host resource group tag of
+// dedicated hosts.
+func (ec2Filters) HostResourceGroup(name string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(fmt.Sprintf("tag:%s", v1alpha1.NameAWSHostResourceGroup)),
+		Values: aws.StringSlice([]string{name}),
+	}
+}
+
+// AvailabilityZone returns a filter based on the availability zone of the resource.
+func (ec2Filters) AvailabilityZone(zone string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("availability-zone"),
+		Values: aws.StringSlice([]string{zone}),
+	}
+}
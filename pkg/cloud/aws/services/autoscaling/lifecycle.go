@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+)
+
+const defaultLifecycleActionResult = "CONTINUE"
+
+// CompleteLifecycleAction completes the pending lifecycle action of the hook
+// for the instance, so the Auto Scaling group carries on with its launch.
+// A lifecycle action that is no longer pending, because it already completed
+// or timed out, is not an error.
+func (s *Service) CompleteLifecycleAction(instanceID string, hook *v1alpha1.LifecycleHook) error {
+	result := hook.Result
+	if result == "" {
+		result = defaultLifecycleActionResult
+	}
+
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(hook.AutoScalingGroupName),
+		LifecycleHookName:     aws.String(hook.Name),
+		InstanceId:            aws.String(instanceID),
+		LifecycleActionResult: aws.String(result),
+	}
+
+	if _, err := s.scope.AutoScaling.CompleteLifecycleAction(input); err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.ValidationError {
+			s.scope.V(2).Info("No pending lifecycle action to complete", "instance-id", instanceID, "lifecycle-hook", hook.Name, "reason", awserrors.Message(err))
+			return nil
+		}
+		return errors.Wrapf(err, "failed to complete lifecycle action of hook %q for instance %q", hook.Name, instanceID)
+	}
+
+	s.scope.V(2).Info("Completed lifecycle action", "instance-id", instanceID, "lifecycle-hook", hook.Name, "result", result)
+	return nil
+}
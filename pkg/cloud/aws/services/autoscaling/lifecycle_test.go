@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/autoscaling/mock_autoscalingiface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestCompleteLifecycleAction(t *testing.T) {
+	testCases := []struct {
+		name        string
+		hook        *v1alpha1.LifecycleHook
+		expect      func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder)
+		expectError bool
+	}{
+		{
+			name: "completes with CONTINUE by default",
+			hook: &v1alpha1.LifecycleHook{
+				AutoScalingGroupName: "workers",
+				Name:                 "bootstrap",
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(&autoscaling.CompleteLifecycleActionInput{
+					AutoScalingGroupName:  aws.String("workers"),
+					LifecycleHookName:     aws.String("bootstrap"),
+					InstanceId:            aws.String("i-1"),
+					LifecycleActionResult: aws.String("CONTINUE"),
+				}).Return(&autoscaling.CompleteLifecycleActionOutput{}, nil)
+			},
+		},
+		{
+			name: "completes with the configured result",
+			hook: &v1alpha1.LifecycleHook{
+				AutoScalingGroupName: "workers",
+				Name:                 "bootstrap",
+				Result:               "ABANDON",
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(&autoscaling.CompleteLifecycleActionInput{
+					AutoScalingGroupName:  aws.String("workers"),
+					LifecycleHookName:     aws.String("bootstrap"),
+					InstanceId:            aws.String("i-1"),
+					LifecycleActionResult: aws.String("ABANDON"),
+				}).Return(&autoscaling.CompleteLifecycleActionOutput{}, nil)
+			},
+		},
+		{
+			name: "no pending lifecycle action is not an error",
+			hook: &v1alpha1.LifecycleHook{
+				AutoScalingGroupName: "workers",
+				Name:                 "bootstrap",
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(gomock.Any()).
+					Return(nil, awserr.New("ValidationError", "No active Lifecycle Action found with instance ID i-1", nil))
+			},
+		},
+		{
+			name: "other errors are returned",
+			hook: &v1alpha1.LifecycleHook{
+				AutoScalingGroupName: "workers",
+				Name:                 "bootstrap",
+			},
+			expect: func(m *mock_autoscalingiface.MockAutoScalingAPIMockRecorder) {
+				m.CompleteLifecycleAction(gomock.Any()).
+					Return(nil, awserr.New("AccessDenied", "", nil))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			autoscalingMock := mock_autoscalingiface.NewMockAutoScalingAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					AutoScaling: autoscalingMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(autoscalingMock.EXPECT())
+			s := NewService(scope)
+			err = s.CompleteLifecycleAction("i-1", tc.hook)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
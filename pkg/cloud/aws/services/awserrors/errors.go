@@ -20,13 +20,35 @@ import (
 	"net/http"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
 )
 
 const (
-	AuthFailure        = "AuthFailure"
-	InUseIPAddress     = "InvalidIPAddress.InUse"
-	GroupNotFound      = "InvalidGroup.NotFound"
-	PermissionNotFound = "InvalidPermission.NotFound"
+	AuthFailure                  = "AuthFailure"
+	InUseIPAddress               = "InvalidIPAddress.InUse"
+	GroupNotFound                = "InvalidGroup.NotFound"
+	PermissionNotFound           = "InvalidPermission.NotFound"
+	InsufficientInstanceCapacity = "InsufficientInstanceCapacity"
+	InstanceLimitExceeded        = "InstanceLimitExceeded"
+	RequestLimitExceeded         = "RequestLimitExceeded"
+	UnauthorizedOperation        = "UnauthorizedOperation"
+	InvalidParameterValue        = "InvalidParameterValue"
+	InvalidParameterCombination  = "InvalidParameterCombination"
+	InvalidAMIIDNotFound         = "InvalidAMIID.NotFound"
+	InvalidAMIIDMalformed        = "InvalidAMIID.Malformed"
+	DryRunOperation              = "DryRunOperation"
+	IdempotentParameterMismatch  = "IdempotentParameterMismatch"
+	InvalidKeyPairNotFound       = "InvalidKeyPair.NotFound"
+	InvalidInstanceIDNotFound    = "InvalidInstanceID.NotFound"
+
+	InvalidNetworkInterfaceIDNotFound = "InvalidNetworkInterfaceID.NotFound"
+
+	ReservationCapacityExceeded           = "ReservationCapacityExceeded"
+	InvalidCapacityReservationIDNotFound  = "InvalidCapacityReservationId.NotFound"
+	InvalidCapacityReservationIDMalformed = "InvalidCapacityReservationId.Malformed"
+
+	ValidationError = "ValidationError"
 )
 
 var _ error = &EC2Error{}
@@ -83,6 +105,19 @@ func NewFailedDependency(err error) error {
 	}
 }
 
+// NewTimeout returns a new error which indicates that an operation didn't complete in time.
+func NewTimeout(err error) error {
+	return &EC2Error{
+		err:  err,
+		Code: http.StatusRequestTimeout,
+	}
+}
+
+// IsTimeout returns true if the error was created by NewTimeout.
+func IsTimeout(err error) bool {
+	return ReasonForError(err) == http.StatusRequestTimeout
+}
+
 // IsFailedDependency checks if the error is pf http.StatusFailedDependency
 func IsFailedDependency(err error) bool {
 	if ReasonForError(err) == http.StatusFailedDependency {
@@ -104,6 +139,12 @@ func IsConflict(err error) bool {
 	return ReasonForError(err) == http.StatusConflict
 }
 
+// IsThrottling returns true if the error, or the error it wraps, is an AWS
+// throttling error, e.g. RequestLimitExceeded.
+func IsThrottling(err error) bool {
+	return request.IsErrorThrottle(errors.Cause(err))
+}
+
 // IsSDKError returns true if the error is of type awserr.Error.
 func IsSDKError(err error) (ok bool) {
 	_, ok = err.(awserr.Error)
@@ -114,7 +155,7 @@ func IsSDKError(err error) (ok bool) {
 func IsInvalidNotFoundError(err error) bool {
 	if code, ok := Code(err); ok {
 		switch code {
-		case "InvalidVpcID.NotFound":
+		case "InvalidVpcID.NotFound", InvalidInstanceIDNotFound:
 			return true
 		}
 	}
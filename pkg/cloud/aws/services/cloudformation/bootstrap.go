@@ -146,11 +146,13 @@ func controllersPolicy(accountID string) *iam.PolicyDocument {
 				Effect:   iam.EffectAllow,
 				Resource: iam.Resources{"*"},
 				Action: iam.Actions{
+					"autoscaling:CompleteLifecycleAction",
 					"ec2:AllocateAddress",
 					"ec2:AssociateRouteTable",
 					"ec2:AttachInternetGateway",
 					"ec2:AuthorizeSecurityGroupIngress",
 					"ec2:CreateInternetGateway",
+					"ec2:CreateKeyPair",
 					"ec2:CreateNatGateway",
 					"ec2:CreateRoute",
 					"ec2:CreateRouteTable",
@@ -160,6 +162,8 @@ func controllersPolicy(accountID string) *iam.PolicyDocument {
 					"ec2:CreateVpc",
 					"ec2:ModifyVpcAttribute",
 					"ec2:DeleteInternetGateway",
+					"ec2:DeleteKeyPair",
+					"ec2:DeleteNetworkInterface",
 					"ec2:DeleteNatGateway",
 					"ec2:DeleteRouteTable",
 					"ec2:DeleteSecurityGroup",
@@ -169,9 +173,12 @@ func controllersPolicy(accountID string) *iam.PolicyDocument {
 					"ec2:DescribeAccountAttributes",
 					"ec2:DescribeAddresses",
 					"ec2:DescribeAvailabilityZones",
+					"ec2:DescribeInstanceCreditSpecifications",
 					"ec2:DescribeInstances",
 					"ec2:DescribeInternetGateways",
+					"ec2:DescribeHosts",
 					"ec2:DescribeImages",
+					"ec2:DescribeKeyPairs",
 					"ec2:DescribeNatGateways",
 					"ec2:DescribeNetworkInterfaces",
 					"ec2:DescribeRouteTables",
@@ -181,20 +188,37 @@ func controllersPolicy(accountID string) *iam.PolicyDocument {
 					"ec2:DescribeVolumes",
 					"ec2:DetachInternetGateway",
 					"ec2:DisassociateRouteTable",
+					"ec2:ImportKeyPair",
 					"ec2:ModifyInstanceAttribute",
+					"ec2:ModifyInstanceCreditSpecification",
+					"ec2:ModifyInstanceMetadataOptions",
 					"ec2:ModifyNetworkInterfaceAttribute",
 					"ec2:ModifySubnetAttribute",
+					"ec2:MonitorInstances",
 					"ec2:ReleaseAddress",
 					"ec2:RevokeSecurityGroupIngress",
 					"ec2:RunInstances",
+					"ec2:StartInstances",
+					"ec2:StopInstances",
 					"ec2:TerminateInstances",
+					"ec2:UnmonitorInstances",
 					"elasticloadbalancing:CreateLoadBalancer",
+					"elasticloadbalancing:CreateLoadBalancerListeners",
 					"elasticloadbalancing:ConfigureHealthCheck",
 					"elasticloadbalancing:DeleteLoadBalancer",
+					"elasticloadbalancing:DeleteLoadBalancerListeners",
+					"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+					"elasticloadbalancing:DeregisterTargets",
+					"elasticloadbalancing:DescribeInstanceHealth",
 					"elasticloadbalancing:DescribeLoadBalancers",
 					"elasticloadbalancing:DescribeLoadBalancerAttributes",
+					"elasticloadbalancing:DescribeTargetHealth",
 					"elasticloadbalancing:ModifyLoadBalancerAttributes",
 					"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+					"elasticloadbalancing:RegisterTargets",
+					"route53:ChangeResourceRecordSets",
+					"route53:ListResourceRecordSets",
+					"ssm:GetParameter",
 				},
 			},
 			{
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// elasticInferenceAcceleratorType matches the types of Elastic Inference accelerators.
+var elasticInferenceAcceleratorType = regexp.MustCompile(`^eia[0-9]+\.[a-z0-9]+$`)
+
+// acceleratedInstanceFamily matches the accelerated computing instance
+// families, e.g. p3, g4dn, f1 or inf1.
+var acceleratedInstanceFamily = regexp.MustCompile(`^([pgf][0-9]|inf[0-9])`)
+
+// SupportsElasticInference returns true if Elastic Inference accelerators can
+// be attached to instances of the type. Accelerated computing (GPU, FPGA and
+// Inferentia) and bare metal instances don't support them.
+func SupportsElasticInference(instanceType string) bool {
+	parts := strings.SplitN(instanceType, ".", 2)
+	if acceleratedInstanceFamily.MatchString(parts[0]) {
+		return false
+	}
+	return len(parts) < 2 || !strings.HasPrefix(parts[1], "metal")
+}
+
+// ValidateElasticInferenceAccelerators returns an error if the Elastic
+// Inference accelerators are invalid, or can't be attached to instances of
+// the type.
+func ValidateElasticInferenceAccelerators(instanceType string, accelerators []v1alpha1.ElasticInferenceAccelerator) error {
+	if len(accelerators) == 0 {
+		return nil
+	}
+
+	if !SupportsElasticInference(instanceType) {
+		return errors.Errorf("elastic inference accelerators can't be attached to %q instances", instanceType)
+	}
+
+	for _, a := range accelerators {
+		if !elasticInferenceAcceleratorType.MatchString(a.Type) {
+			return errors.Errorf("invalid elastic inference accelerator type %q", a.Type)
+		}
+		if a.Count < 0 {
+			return errors.Errorf("invalid count %d for elastic inference accelerator %q", a.Count, a.Type)
+		}
+	}
+
+	return nil
+}
+
+// elasticInferenceAccelerators returns the accelerators to request at launch,
+// one per accelerator to attach.
+func elasticInferenceAccelerators(accelerators []v1alpha1.ElasticInferenceAccelerator) []*ec2.ElasticInferenceAccelerator {
+	var out []*ec2.ElasticInferenceAccelerator
+	for _, a := range accelerators {
+		for n := int64(0); n < acceleratorCount(a); n++ {
+			out = append(out, &ec2.ElasticInferenceAccelerator{
+				Type: aws.String(a.Type),
+			})
+		}
+	}
+	return out
+}
+
+func acceleratorCount(a v1alpha1.ElasticInferenceAccelerator) int64 {
+	if a.Count == 0 {
+		return 1
+	}
+	return a.Count
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+func TestValidateElasticInferenceAccelerators(t *testing.T) {
+	testCases := []struct {
+		name         string
+		instanceType string
+		accelerators []v1alpha1.ElasticInferenceAccelerator
+		expectError  bool
+	}{
+		{
+			name:         "not set on a gpu instance",
+			instanceType: "p3.2xlarge",
+		},
+		{
+			name:         "one accelerator on a c5 instance",
+			instanceType: "c5.large",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.medium"}},
+		},
+		{
+			name:         "several accelerators on an m5 instance",
+			instanceType: "m5.xlarge",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.small", Count: 2}, {Type: "eia1.large"}},
+		},
+		{
+			name:         "gpu instance",
+			instanceType: "g4dn.xlarge",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.medium"}},
+			expectError:  true,
+		},
+		{
+			name:         "inferentia instance",
+			instanceType: "inf1.xlarge",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.medium"}},
+			expectError:  true,
+		},
+		{
+			name:         "bare metal instance",
+			instanceType: "m5.metal",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.medium"}},
+			expectError:  true,
+		},
+		{
+			name:         "invalid type",
+			instanceType: "c5.large",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "p3.2xlarge"}},
+			expectError:  true,
+		},
+		{
+			name:         "negative count",
+			instanceType: "c5.large",
+			accelerators: []v1alpha1.ElasticInferenceAccelerator{{Type: "eia1.medium", Count: -1}},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateElasticInferenceAccelerators(tc.instanceType, tc.accelerators)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestElasticInferenceAccelerators(t *testing.T) {
+	out := elasticInferenceAccelerators([]v1alpha1.ElasticInferenceAccelerator{
+		{Type: "eia1.small", Count: 2},
+		{Type: "eia1.large"},
+	})
+
+	expected := []string{"eia1.small", "eia1.small", "eia1.large"}
+	if len(out) != len(expected) {
+		t.Fatalf("expected %d accelerators, got %v", len(expected), out)
+	}
+	for i, e := range expected {
+		if aws.StringValue(out[i].Type) != e {
+			t.Fatalf("expected accelerator %d to be %q, got %q", i, e, aws.StringValue(out[i].Type))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/filter"
+)
+
+// ProviderIDPrefix is the prefix of AWS provider IDs.
+const ProviderIDPrefix = "aws:///"
+
+// InstanceIDFromProviderID returns the instance ID of an AWS provider ID,
+// with or without the availability zone.
+func InstanceIDFromProviderID(providerID string) (string, bool) {
+	if !strings.HasPrefix(providerID, ProviderIDPrefix) {
+		return "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(providerID, ProviderIDPrefix), "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// adoptInstance imports the pre-existing instance of a machine annotated with
+// AnnotationAdoptInstance. The instance of the machine's provider ID is adopted
+// if it has one. Otherwise the only running instance in the cluster VPC named
+// after the machine is: no or several matches are an error rather than a
+// reason to launch or guess.
+func (s *Service) adoptInstance(machine *actuators.MachineScope) (*v1alpha1.Instance, error) {
+	if providerID := machine.Machine.Spec.ProviderID; providerID != nil && *providerID != "" {
+		id, ok := InstanceIDFromProviderID(*providerID)
+		if !ok {
+			return nil, errors.Errorf("failed to adopt instance for machine %q: provider id %q is not an AWS provider id", machine.Name(), *providerID)
+		}
+		return s.importInstance(machine, id)
+	}
+
+	s.scope.V(2).Info("Looking for an instance to adopt by tags", "name", machine.Name())
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.Name(machine.Name()),
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+		},
+	}
+
+	var ids []string
+	err := s.scope.EC2.DescribeInstancesPages(input, func(out *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, res := range out.Reservations {
+			for _, inst := range res.Instances {
+				ids = append(ids, aws.StringValue(inst.InstanceId))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instances to adopt for machine %q", machine.Name())
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, errors.Errorf("failed to adopt instance for machine %q: no running instance named %q found in vpc %q", machine.Name(), machine.Name(), s.scope.VPC().ID)
+	case 1:
+		return s.importInstance(machine, ids[0])
+	default:
+		return nil, errors.Errorf("failed to adopt instance for machine %q: found %d running instances named %q (%s), set the provider id to pick one",
+			machine.Name(), len(ids), machine.Name(), strings.Join(ids, ", "))
+	}
+}
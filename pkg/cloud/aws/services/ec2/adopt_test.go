@@ -0,0 +1,237 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestInstanceIDFromProviderID(t *testing.T) {
+	testCases := []struct {
+		providerID string
+		expectID   string
+		expectOK   bool
+	}{
+		{providerID: "aws:////i-1", expectID: "i-1", expectOK: true},
+		{providerID: "aws:///us-east-1a/i-1", expectID: "i-1", expectOK: true},
+		{providerID: "aws:///us-east-1a/"},
+		{providerID: "aws:///i-1"},
+		{providerID: "gce://project/zone/i-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.providerID, func(t *testing.T) {
+			id, ok := InstanceIDFromProviderID(tc.providerID)
+			if id != tc.expectID || ok != tc.expectOK {
+				t.Fatalf("expected (%q, %v), got (%q, %v)", tc.expectID, tc.expectOK, id, ok)
+			}
+		})
+	}
+}
+
+func TestCreateOrGetMachineAdopt(t *testing.T) {
+	runningInstance := func(id string, tags map[string]string) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:   aws.String(id),
+			InstanceType: aws.String("m5.large"),
+			SubnetId:     aws.String("subnet-1"),
+			ImageId:      aws.String("ami-1"),
+			State: &ec2.InstanceState{
+				Name: aws.String(ec2.InstanceStateNameRunning),
+			},
+			Tags: converters.MapToTags(tags),
+		}
+	}
+	describeByTags := func(m *mock_ec2iface.MockEC2APIMockRecorder, instances ...*ec2.Instance) {
+		m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+			Do(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+				fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, true)
+			}).
+			Return(nil)
+	}
+	expectImport := func(m *mock_ec2iface.MockEC2APIMockRecorder, id string) {
+		m.DescribeInstances(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{})).
+			Do(func(input *ec2.DescribeInstancesInput) {
+				if len(input.InstanceIds) != 1 || aws.StringValue(input.InstanceIds[0]) != id {
+					t.Fatalf("expected instance %q to be looked up, got %v", id, input.InstanceIds)
+				}
+			}).
+			Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{runningInstance(id, map[string]string{"Name": "machine-1"})}},
+				},
+			}, nil)
+		m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+			Return(&ec2.CreateTagsOutput{}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		providerID  *string
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectID    string
+		expectError string
+	}{
+		{
+			name:        "instance of the provider id is adopted",
+			annotations: map[string]string{v1alpha1.AnnotationAdoptInstance: "true"},
+			providerID:  aws.String("aws:///us-east-1a/i-provider"),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeByTags(m)
+				expectImport(m, "i-provider")
+			},
+			expectID: "i-provider",
+		},
+		{
+			name:        "only instance named after the machine is adopted",
+			annotations: map[string]string{v1alpha1.AnnotationAdoptInstance: "true"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+						Return(nil),
+					m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+						Do(func(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+							for _, f := range input.Filters {
+								if aws.StringValue(f.Name) == "tag:"+v1alpha1.ClusterTagKey("test1") {
+									t.Fatalf("expected instances to adopt to be looked up regardless of ownership, got filter %v", f)
+								}
+							}
+							fn(&ec2.DescribeInstancesOutput{
+								Reservations: []*ec2.Reservation{
+									{Instances: []*ec2.Instance{runningInstance("i-tagged", map[string]string{"Name": "machine-1"})}},
+								},
+							}, true)
+						}).
+						Return(nil),
+				)
+				expectImport(m, "i-tagged")
+			},
+			expectID: "i-tagged",
+		},
+		{
+			name:        "several instances named after the machine are not adopted",
+			annotations: map[string]string{v1alpha1.AnnotationAdoptInstance: "true"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+						Return(nil),
+					m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+						Do(func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+							fn(&ec2.DescribeInstancesOutput{
+								Reservations: []*ec2.Reservation{
+									{Instances: []*ec2.Instance{
+										runningInstance("i-a", map[string]string{"Name": "machine-1"}),
+										runningInstance("i-b", map[string]string{"Name": "machine-1"}),
+									}},
+								},
+							}, true)
+						}).
+						Return(nil),
+				)
+			},
+			expectError: "found 2 running instances",
+		},
+		{
+			name:        "no instance to adopt",
+			annotations: map[string]string{v1alpha1.AnnotationAdoptInstance: "true"},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			expectError: "no running instance",
+		},
+		{
+			name:        "provider id of another provider is not adopted",
+			annotations: map[string]string{v1alpha1.AnnotationAdoptInstance: "true"},
+			providerID:  aws.String("gce://project/zone/i-1"),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeByTags(m)
+			},
+			expectError: "is not an AWS provider id",
+		},
+		{
+			name:       "instance of the provider id is not adopted without the annotation",
+			providerID: aws.String("aws:///us-east-1a/i-provider"),
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeByTags(m, runningInstance("i-machine", map[string]string{
+					"Name":                          "machine-1",
+					v1alpha1.ClusterTagKey("test1"): string(v1alpha1.ResourceLifecycleOwned),
+				}))
+			},
+			expectID: "i-machine",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "machine-1",
+						Labels:      map[string]string{"set": "node"},
+						Annotations: tc.annotations,
+					},
+					Spec: clusterv1.MachineSpec{ProviderID: tc.providerID},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.Scope.ClusterConfig.NetworkSpec.VPC.ID = "test-vpc"
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope.Scope)
+			instance, err := s.CreateOrGetMachine(scope, "token")
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if instance.ID != tc.expectID {
+				t.Fatalf("expected instance %q, got %q", tc.expectID, instance.ID)
+			}
+		})
+	}
+}
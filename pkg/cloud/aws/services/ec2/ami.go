@@ -24,7 +24,10 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
 )
 
 const (
@@ -91,6 +94,72 @@ func (s *Service) defaultAMILookup(ownerID, baseOS, baseOSVersion, kubernetesVer
 	return aws.StringValue(latestImage.ImageId), nil
 }
 
+// resolveAMI returns the ID of the AMI to launch the machine from.
+// Precedence is as follows:
+// 1. The AMI ID set in the machine configuration
+// 2. The AMI ID previously resolved and recorded in the machine status
+// 3. The value of the SSM parameter set in the machine configuration
+// 4. The most recent image matching the AMI filters set in the machine configuration
+// 5. The default image lookup
+func (s *Service) resolveAMI(machine *actuators.MachineScope) (string, error) {
+	switch {
+	case machine.MachineConfig.AMI.ID != nil:
+		return *machine.MachineConfig.AMI.ID, nil
+	case machine.MachineStatus.AMIID != nil:
+		return *machine.MachineStatus.AMIID, nil
+	case machine.MachineConfig.AMISSMParameter != "":
+		return s.ssmAMILookup(machine.MachineConfig.AMISSMParameter)
+	case len(machine.MachineConfig.AMI.Filters) > 0:
+		return s.filteredAMILookup(machine.MachineConfig.AMI.Filters)
+	default:
+		return s.defaultAMILookup(machine.MachineConfig.ImageLookupOrg, "ubuntu", "18.04", machine.Machine.Spec.Versions.Kubelet)
+	}
+}
+
+// ssmAMILookup returns the AMI ID stored in the given SSM parameter.
+func (s *Service) ssmAMILookup(name string) (string, error) {
+	out, err := s.scope.SSM.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get ami from SSM parameter %q", name)
+	}
+	if out.Parameter == nil || aws.StringValue(out.Parameter.Value) == "" {
+		return "", errors.Errorf("SSM parameter %q has no value", name)
+	}
+	s.scope.V(2).Info("Found AMI in SSM parameter", "parameter", name, "ami-id", aws.StringValue(out.Parameter.Value))
+	return aws.StringValue(out.Parameter.Value), nil
+}
+
+// filteredAMILookup returns the most recent available AMI matching the given filters.
+func (s *Service) filteredAMILookup(filters []v1alpha1.Filter) (string, error) {
+	describeImageInput := &ec2.DescribeImagesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String("available")},
+			},
+		},
+	}
+	for _, f := range filters {
+		describeImageInput.Filters = append(describeImageInput.Filters, &ec2.Filter{
+			Name:   aws.String(f.Name),
+			Values: aws.StringSlice(f.Values),
+		})
+	}
+
+	out, err := s.scope.EC2.DescribeImages(describeImageInput)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find ami matching filters %v", filters)
+	}
+	if len(out.Images) == 0 {
+		return "", errors.Errorf("found no AMIs matching filters %v", filters)
+	}
+	latestImage := getLatestImage(out.Images)
+	s.scope.V(2).Info("Found and using an existing AMI", "ami-id", aws.StringValue(latestImage.ImageId))
+	return aws.StringValue(latestImage.ImageId), nil
+}
+
 type images []*ec2.Image
 
 // Len is the number of elements in the collection.
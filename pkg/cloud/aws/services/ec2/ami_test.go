@@ -21,9 +21,12 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ssmiface"
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
@@ -86,3 +89,119 @@ func TestAMIs(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveAMI(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name          string
+		machineConfig *v1alpha1.AWSMachineProviderSpec
+		machineStatus *v1alpha1.AWSMachineProviderStatus
+		expect        func(ec2 *mock_ec2iface.MockEC2APIMockRecorder, ssm *mock_ssmiface.MockSSMAPIMockRecorder)
+		expected      string
+	}{
+		{
+			name: "uses the configured id",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI:             v1alpha1.AWSResourceReference{ID: aws.String("ami-configured")},
+				AMISSMParameter: "/some/parameter",
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{
+				AMIID: aws.String("ami-resolved"),
+			},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder, s *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expected: "ami-configured",
+		},
+		{
+			name: "uses the previously resolved id",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMISSMParameter: "/some/parameter",
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{
+				AMIID: aws.String("ami-resolved"),
+			},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder, s *mock_ssmiface.MockSSMAPIMockRecorder) {},
+			expected: "ami-resolved",
+		},
+		{
+			name: "looks up the ssm parameter",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMISSMParameter: "/some/parameter",
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder, s *mock_ssmiface.MockSSMAPIMockRecorder) {
+				s.GetParameter(&ssm.GetParameterInput{Name: aws.String("/some/parameter")}).
+					Return(&ssm.GetParameterOutput{
+						Parameter: &ssm.Parameter{Value: aws.String("ami-from-ssm")},
+					}, nil)
+			},
+			expected: "ami-from-ssm",
+		},
+		{
+			name: "looks up the latest image matching filters",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					Filters: []v1alpha1.Filter{
+						{Name: "name", Values: []string{"my-image-*"}},
+						{Name: "owner-id", Values: []string{"123456789012"}},
+					},
+				},
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder, s *mock_ssmiface.MockSSMAPIMockRecorder) {
+				m.DescribeImages(&ec2.DescribeImagesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("state"), Values: aws.StringSlice([]string{"available"})},
+						{Name: aws.String("name"), Values: aws.StringSlice([]string{"my-image-*"})},
+						{Name: aws.String("owner-id"), Values: aws.StringSlice([]string{"123456789012"})},
+					},
+				}).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{
+						{
+							ImageId:      aws.String("ami-older"),
+							CreationDate: aws.String("2018-02-08T17:02:31.000Z"),
+						},
+						{
+							ImageId:      aws.String("ami-newer"),
+							CreationDate: aws.String("2019-02-08T17:02:31.000Z"),
+						},
+					},
+				}, nil)
+			},
+			expected: "ami-newer",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			ssmMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				Machine: &clusterv1.Machine{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					SSM: ssmMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			scope.MachineConfig = tc.machineConfig
+			scope.MachineStatus = tc.machineStatus
+			tc.expect(ec2Mock.EXPECT(), ssmMock.EXPECT())
+
+			s := NewService(scope.Scope)
+			id, err := s.resolveAMI(scope)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if id != tc.expected {
+				t.Fatalf("returned %q expected %q", id, tc.expected)
+			}
+		})
+	}
+}
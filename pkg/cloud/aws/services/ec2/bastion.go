@@ -56,7 +56,7 @@ func (s *Service) ReconcileBastion() error {
 	// Describe bastion instance, if any.
 	instance, err := s.describeBastionInstance()
 	if awserrors.IsNotFound(err) {
-		instance, err = s.runInstance("bastion", spec)
+		instance, err = s.runInstance("bastion", spec, "", false, defaultInstanceRunningTimeout)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// capacityReservationSpecification returns the capacity reservation
+// specification of the RunInstances request launching the given instance:
+// the targeted capacity reservation or capacity reservation resource group,
+// else the capacity reservation preference, if any.
+func capacityReservationSpecification(i *v1alpha1.Instance) *ec2.CapacityReservationSpecification {
+	switch {
+	case i.CapacityReservationID != nil:
+		return &ec2.CapacityReservationSpecification{
+			CapacityReservationTarget: &ec2.CapacityReservationTarget{
+				CapacityReservationId: i.CapacityReservationID,
+			},
+		}
+	case i.CapacityReservationResourceGroupARN != nil:
+		return &ec2.CapacityReservationSpecification{
+			CapacityReservationTarget: &ec2.CapacityReservationTarget{
+				CapacityReservationResourceGroupArn: i.CapacityReservationResourceGroupARN,
+			},
+		}
+	case i.CapacityReservationPreference != nil:
+		return &ec2.CapacityReservationSpecification{
+			CapacityReservationPreference: i.CapacityReservationPreference,
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestCapacityReservationSpecification(t *testing.T) {
+	arn := "arn:aws:resource-groups:us-east-1:123456789012:group/reservations"
+
+	testCases := []struct {
+		name     string
+		instance *v1alpha1.Instance
+		expected *ec2.CapacityReservationSpecification
+	}{
+		{
+			name:     "no capacity reservation",
+			instance: &v1alpha1.Instance{},
+		},
+		{
+			name: "capacity reservation",
+			instance: &v1alpha1.Instance{
+				CapacityReservationID:         aws.String("cr-1"),
+				CapacityReservationPreference: aws.String("none"),
+			},
+			expected: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationId: aws.String("cr-1"),
+				},
+			},
+		},
+		{
+			name:     "capacity reservation resource group",
+			instance: &v1alpha1.Instance{CapacityReservationResourceGroupARN: aws.String(arn)},
+			expected: &ec2.CapacityReservationSpecification{
+				CapacityReservationTarget: &ec2.CapacityReservationTarget{
+					CapacityReservationResourceGroupArn: aws.String(arn),
+				},
+			},
+		},
+		{
+			name:     "capacity reservation preference",
+			instance: &v1alpha1.Instance{CapacityReservationPreference: aws.String("none")},
+			expected: &ec2.CapacityReservationSpecification{
+				CapacityReservationPreference: aws.String("none"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := capacityReservationSpecification(tc.instance); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected capacity reservation specification %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRunInstanceInCapacityReservationResourceGroup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	arn := "arn:aws:resource-groups:us-east-1:123456789012:group/reservations"
+	ec2Mock.EXPECT().
+		RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+		DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+			spec := input.CapacityReservationSpecification
+			if spec == nil || spec.CapacityReservationTarget == nil || aws.StringValue(spec.CapacityReservationTarget.CapacityReservationResourceGroupArn) != arn {
+				t.Fatalf("expected the capacity reservation resource group to be targeted, got %v", spec)
+			}
+			return &ec2.Reservation{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId:   aws.String("i-1"),
+						InstanceType: aws.String("m5.large"),
+						ImageId:      aws.String("ami-1"),
+						State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+						CapacityReservationSpecification: &ec2.CapacityReservationSpecificationResponse{
+							CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{
+								CapacityReservationResourceGroupArn: aws.String(arn),
+							},
+						},
+					},
+				},
+			}, nil
+		})
+	ec2Mock.EXPECT().
+		WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s := NewService(scope)
+	instance, err := s.runInstance("node", &v1alpha1.Instance{
+		Type:                                "m5.large",
+		ImageID:                             "ami-1",
+		CapacityReservationResourceGroupARN: aws.String(arn),
+	}, "", false, time.Minute)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if instance.ID != "i-1" {
+		t.Fatalf("expected instance i-1, got %q", instance.ID)
+	}
+	if aws.StringValue(instance.CapacityReservationResourceGroupARN) != arn {
+		t.Fatalf("expected the instance to be launched into %q, got %v", arn, instance.CapacityReservationResourceGroupARN)
+	}
+}
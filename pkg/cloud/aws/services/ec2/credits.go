@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CPUCreditsStandard is the credit option for CPU usage of burstable
+	// performance instances that throttles them once their credits run out.
+	CPUCreditsStandard = "standard"
+
+	// CPUCreditsUnlimited is the credit option for CPU usage of burstable
+	// performance instances that lets them burst for as long as required.
+	CPUCreditsUnlimited = "unlimited"
+)
+
+// IsBurstableInstanceType returns true if the instance type belongs to a
+// burstable performance (T-series) family, e.g. t2.micro or t3a.large.
+func IsBurstableInstanceType(instanceType string) bool {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	return len(family) > 1 && family[0] == 't' && unicode.IsDigit(rune(family[1]))
+}
+
+// ValidateCreditSpecification returns an error if the credit option for CPU
+// usage is invalid, or set for an instance type that isn't burstable.
+func ValidateCreditSpecification(instanceType string, cpuCredits *string) error {
+	if cpuCredits == nil {
+		return nil
+	}
+
+	switch *cpuCredits {
+	case CPUCreditsStandard, CPUCreditsUnlimited:
+	default:
+		return errors.Errorf("invalid credit specification %q, must be one of %q or %q", *cpuCredits, CPUCreditsStandard, CPUCreditsUnlimited)
+	}
+
+	if !IsBurstableInstanceType(instanceType) {
+		return errors.Errorf("credit specification can only be set for burstable performance instances, %q is not one", instanceType)
+	}
+
+	return nil
+}
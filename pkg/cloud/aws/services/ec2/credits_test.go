@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestValidateCreditSpecification(t *testing.T) {
+	testCases := []struct {
+		name         string
+		instanceType string
+		cpuCredits   *string
+		expectError  bool
+	}{
+		{
+			name:         "not set on a non burstable instance",
+			instanceType: "m5.large",
+		},
+		{
+			name:         "unlimited on a t3 instance",
+			instanceType: "t3.large",
+			cpuCredits:   aws.String(CPUCreditsUnlimited),
+		},
+		{
+			name:         "standard on a t3a instance",
+			instanceType: "t3a.medium",
+			cpuCredits:   aws.String(CPUCreditsStandard),
+		},
+		{
+			name:         "unlimited on a non burstable instance",
+			instanceType: "m5.large",
+			cpuCredits:   aws.String(CPUCreditsUnlimited),
+			expectError:  true,
+		},
+		{
+			name:         "invalid value",
+			instanceType: "t2.micro",
+			cpuCredits:   aws.String("boundless"),
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCreditSpecification(tc.instanceType, tc.cpuCredits)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// NoSubnetsError is returned when no subnet can be found to launch a machine's
+// instance in. It describes what was searched so the problem can be fixed
+// without digging through the controller logs.
+type NoSubnetsError struct {
+	Machine string
+	Cluster string
+	VPC     string
+
+	// AvailabilityZone the subnets were filtered by, if any.
+	AvailabilityZone string
+
+	// Subnets of the cluster that were considered.
+	Subnets v1alpha1.Subnets
+}
+
+// Error implements the Error interface.
+func (e *NoSubnetsError) Error() string {
+	searched := fmt.Sprintf("private subnets of cluster %q in vpc %q", e.Cluster, e.VPC)
+	if e.AvailabilityZone != "" {
+		searched += fmt.Sprintf(" in availability zone %q", e.AvailabilityZone)
+	}
+
+	candidates := "the cluster has no subnets"
+	if len(e.Subnets) > 0 {
+		descriptions := make([]string, 0, len(e.Subnets))
+		for _, sn := range e.Subnets {
+			visibility := "private"
+			if sn.IsPublic {
+				visibility = "public"
+			}
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s, %s)", sn.ID, visibility, sn.AvailabilityZone))
+		}
+		candidates = "cluster subnets: " + strings.Join(descriptions, ", ")
+	}
+
+	return fmt.Sprintf("no subnets available for machine %q: searched %s; %s; set a subnet id on the machine or add a private subnet to the cluster",
+		e.Machine, searched, candidates)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/filter"
+)
+
+// ValidateTenancy returns an error if the tenancy is invalid, or if a dedicated
+// host is requested for a tenancy other than host. See
+// AWSMachineProviderSpec.Validate for the full set of placement rules.
+func ValidateTenancy(tenancy string, hostID, hostResourceGroup *string) error {
+	spec := &v1alpha1.AWSMachineProviderSpec{
+		Tenancy:           tenancy,
+		HostID:            hostID,
+		HostResourceGroup: hostResourceGroup,
+	}
+	return spec.Validate().ToAggregate()
+}
+
+// selectHost returns the ID of the available dedicated host of the host resource
+// group with the most capacity for the instance type, in the given availability
+// zone if not empty. Ties are broken by host ID so that the selection is stable.
+// Nil is returned if no host has capacity, so that the instance falls back to
+// auto-placement.
+func (s *Service) selectHost(group, instanceType, zone string) (*string, error) {
+	input := &ec2.DescribeHostsInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.HostResourceGroup(group),
+			filter.EC2.Available(),
+		},
+	}
+
+	if zone != "" {
+		input.Filter = append(input.Filter, filter.EC2.AvailabilityZone(zone))
+	}
+
+	var (
+		hostID   *string
+		capacity int64
+	)
+
+	err := s.scope.EC2.DescribeHostsPages(input, func(out *ec2.DescribeHostsOutput, last bool) bool {
+		for _, host := range out.Hosts {
+			available := hostCapacity(host, instanceType)
+			if available == 0 {
+				continue
+			}
+
+			if available > capacity || (available == capacity && aws.StringValue(host.HostId) < aws.StringValue(hostID)) {
+				hostID = host.HostId
+				capacity = available
+			}
+		}
+		return true
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe hosts of host resource group %q", group)
+	}
+
+	if hostID == nil {
+		s.scope.V(2).Info("No dedicated host with capacity in host resource group, falling back to auto-placement",
+			"host-resource-group", group, "instance-type", instanceType)
+		return nil, nil
+	}
+
+	s.scope.V(2).Info("Selected dedicated host from host resource group",
+		"host-resource-group", group, "host-id", *hostID, "available-capacity", capacity)
+	return hostID, nil
+}
+
+// hostCapacity returns how many more instances of the instance type can be
+// launched on the dedicated host.
+func hostCapacity(host *ec2.Host, instanceType string) int64 {
+	if host.AvailableCapacity == nil {
+		return 0
+	}
+
+	for _, c := range host.AvailableCapacity.AvailableInstanceCapacity {
+		if aws.StringValue(c.InstanceType) == instanceType {
+			return aws.Int64Value(c.AvailableCapacity)
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func testHost(id, instanceType string, available int64) *ec2.Host {
+	return &ec2.Host{
+		HostId: aws.String(id),
+		AvailableCapacity: &ec2.AvailableCapacity{
+			AvailableInstanceCapacity: []*ec2.InstanceCapacity{
+				{
+					InstanceType:      aws.String(instanceType),
+					AvailableCapacity: aws.Int64(available),
+				},
+			},
+		},
+	}
+}
+
+func TestSelectHost(t *testing.T) {
+	testCases := []struct {
+		name     string
+		zone     string
+		hosts    [][]*ec2.Host
+		expected *string
+	}{
+		{
+			name:     "no hosts in the group falls back to auto-placement",
+			hosts:    [][]*ec2.Host{{}},
+			expected: nil,
+		},
+		{
+			name: "no host with capacity falls back to auto-placement",
+			hosts: [][]*ec2.Host{{
+				testHost("h-1", "m5.large", 0),
+				testHost("h-2", "c5.large", 4),
+				{HostId: aws.String("h-3")},
+			}},
+			expected: nil,
+		},
+		{
+			name: "host with the most capacity is selected",
+			zone: "us-east-1a",
+			hosts: [][]*ec2.Host{{
+				testHost("h-1", "m5.large", 1),
+				testHost("h-2", "m5.large", 3),
+				testHost("h-3", "m5.large", 2),
+			}},
+			expected: aws.String("h-2"),
+		},
+		{
+			name: "ties are broken by host id",
+			hosts: [][]*ec2.Host{{
+				testHost("h-2", "m5.large", 2),
+				testHost("h-1", "m5.large", 2),
+			}},
+			expected: aws.String("h-1"),
+		},
+		{
+			name: "hosts are selected across pages",
+			hosts: [][]*ec2.Host{
+				{testHost("h-1", "m5.large", 1)},
+				{testHost("h-2", "m5.large", 5)},
+			},
+			expected: aws.String("h-2"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().
+				DescribeHostsPages(gomock.AssignableToTypeOf(&ec2.DescribeHostsInput{}), gomock.Any()).
+				Do(func(input *ec2.DescribeHostsInput, fn func(*ec2.DescribeHostsOutput, bool) bool) {
+					expectedFilters := 2
+					if tc.zone != "" {
+						expectedFilters++
+					}
+					if len(input.Filter) != expectedFilters {
+						t.Fatalf("expected %d filters, got %v", expectedFilters, input.Filter)
+					}
+
+					for i, page := range tc.hosts {
+						if !fn(&ec2.DescribeHostsOutput{Hosts: page}, i == len(tc.hosts)-1) {
+							return
+						}
+					}
+				}).
+				Return(nil)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			s := NewService(scope)
+			hostID, err := s.selectHost("group", "m5.large", tc.zone)
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			if aws.StringValue(hostID) != aws.StringValue(tc.expected) {
+				t.Fatalf("expected host %q, got %q", aws.StringValue(tc.expected), aws.StringValue(hostID))
+			}
+		})
+	}
+}
+
+func TestValidateTenancy(t *testing.T) {
+	testCases := []struct {
+		name              string
+		tenancy           string
+		hostID            *string
+		hostResourceGroup *string
+		expectError       bool
+	}{
+		{
+			name: "no tenancy",
+		},
+		{
+			name:    "dedicated",
+			tenancy: ec2.TenancyDedicated,
+		},
+		{
+			name:    "host with a host id",
+			tenancy: ec2.TenancyHost,
+			hostID:  aws.String("h-1"),
+		},
+		{
+			name:              "host resource group without a tenancy",
+			hostResourceGroup: aws.String("group"),
+		},
+		{
+			name:        "invalid tenancy",
+			tenancy:     "shared",
+			expectError: true,
+		},
+		{
+			name:        "host id without a tenancy",
+			hostID:      aws.String("h-1"),
+			expectError: true,
+		},
+		{
+			name:        "host id with dedicated tenancy",
+			tenancy:     ec2.TenancyDedicated,
+			hostID:      aws.String("h-1"),
+			expectError: true,
+		},
+		{
+			name:              "host resource group with dedicated tenancy",
+			tenancy:           ec2.TenancyDedicated,
+			hostResourceGroup: aws.String("group"),
+			expectError:       true,
+		},
+		{
+			name:              "host id with a host resource group",
+			tenancy:           ec2.TenancyHost,
+			hostID:            aws.String("h-1"),
+			hostResourceGroup: aws.String("group"),
+			expectError:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTenancy(tc.tenancy, tc.hostID, tc.hostResourceGroup)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
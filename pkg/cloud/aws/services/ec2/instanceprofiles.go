@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+)
+
+// instanceProfileRetries is how many times an instance launch is retried
+// while its IAM instance profile isn't usable yet.
+const instanceProfileRetries = 4
+
+// instanceProfileRetryDelay is the delay before the first retry of an
+// instance launch rejected for its IAM instance profile, doubled on every
+// retry.
+var instanceProfileRetryDelay = 2 * time.Second
+
+// runInstancesWithInstanceProfileRetry calls RunInstances, retrying a bounded
+// number of times while the IAM instance profile of the instance is rejected.
+// IAM is eventually consistent, so EC2 rejects newly created instance
+// profiles as invalid for a few seconds. Any other error, including IAM
+// permission errors, is returned right away.
+func (s *Service) runInstancesWithInstanceProfileRetry(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	delay := instanceProfileRetryDelay
+	for retry := 1; ; retry++ {
+		out, err := s.scope.EC2.RunInstances(input)
+		if err == nil || input.IamInstanceProfile == nil || !isInvalidInstanceProfile(err) || retry > instanceProfileRetries {
+			return out, err
+		}
+
+		s.scope.Info("IAM instance profile is not usable yet, it may have just been created - retrying instance launch",
+			"retry", retry, "max-retries", instanceProfileRetries, "delay", delay.String(), "message", awserrors.Message(err))
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isInvalidInstanceProfile returns true if EC2 rejected the IAM instance
+// profile of an instance launch, as it does for profiles that don't exist yet
+// from its point of view.
+func isInvalidInstanceProfile(err error) bool {
+	code, _ := awserrors.Code(err)
+	return code == awserrors.InvalidParameterValue &&
+		strings.Contains(strings.ToLower(awserrors.Message(err)), "invalid iam instance profile")
+}
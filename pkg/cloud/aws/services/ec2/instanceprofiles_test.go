@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestRunInstancesWithInstanceProfileRetry(t *testing.T) {
+	defer func(delay time.Duration) { instanceProfileRetryDelay = delay }(instanceProfileRetryDelay)
+	instanceProfileRetryDelay = time.Millisecond
+
+	invalidProfile := awserr.New("InvalidParameterValue", "Value (nodes.cluster-api-provider-aws.sigs.k8s.io) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", nil)
+	reservation := &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: aws.String("i-1")}}}
+
+	testCases := []struct {
+		name        string
+		profile     *ec2.IamInstanceProfileSpecification
+		errs        []error
+		expectCalls int
+		expectError bool
+	}{
+		{
+			name:        "profile usable right away",
+			profile:     &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+			expectCalls: 1,
+		},
+		{
+			name:        "profile usable after retries",
+			profile:     &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+			errs:        []error{invalidProfile, invalidProfile},
+			expectCalls: 3,
+		},
+		{
+			name:        "profile never usable",
+			profile:     &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+			errs:        []error{invalidProfile, invalidProfile, invalidProfile, invalidProfile, invalidProfile},
+			expectCalls: instanceProfileRetries + 1,
+			expectError: true,
+		},
+		{
+			name:        "permission error fails fast",
+			profile:     &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+			errs:        []error{awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil)},
+			expectCalls: 1,
+			expectError: true,
+		},
+		{
+			name:        "other invalid parameter fails fast",
+			profile:     &ec2.IamInstanceProfileSpecification{Name: aws.String("nodes")},
+			errs:        []error{awserr.New("InvalidParameterValue", "Invalid value 'm5.huge' for InstanceType.", nil)},
+			expectCalls: 1,
+			expectError: true,
+		},
+		{
+			name:        "no profile fails fast",
+			errs:        []error{invalidProfile},
+			expectCalls: 1,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			calls := 0
+			ec2Mock.EXPECT().
+				RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+				DoAndReturn(func(*ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					calls++
+					if calls <= len(tc.errs) {
+						return nil, tc.errs[calls-1]
+					}
+					return reservation, nil
+				}).
+				Times(tc.expectCalls)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster:    &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{EC2: ec2Mock},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(scope)
+			out, err := s.runInstancesWithInstanceProfileRetry(&ec2.RunInstancesInput{IamInstanceProfile: tc.profile})
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if err != tc.errs[len(tc.errs)-1] {
+					t.Fatalf("expected the last error to be returned, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if out != reservation {
+				t.Fatalf("expected reservation %v, got %v", reservation, out)
+			}
+		})
+	}
+}
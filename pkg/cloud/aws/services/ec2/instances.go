@@ -20,8 +20,13 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,6 +35,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeadmv1beta1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
@@ -51,68 +57,190 @@ const (
 	// hostnameLookup resolves via cloud init and uses cloud provider's metadata service to lookup its own hostname.
 	hostnameLookup = "{{ ds.meta_data.hostname }}"
 
+	// instanceIDLookup resolves via cloud init and uses cloud provider's metadata service to lookup its own instance id.
+	instanceIDLookup = "{{ ds.meta_data.instance_id }}"
+
 	// containerdSocket is the path to containerd socket.
 	containerdSocket = "/var/run/containerd/containerd.sock"
 
-	// apiServerBindPort is the default port for the kube-apiserver to bind to.
-	apiServerBindPort = 6443
-
 	// cloudProvider is the name of the cloud provider passed to various kubernetes components.
 	cloudProvider = "aws"
 
 	// nodeRole is the label assigned to every node in the cluster.
 	nodeRole = "node-role.kubernetes.io/node="
+
+	// maxClientTokenLength is the maximum length of a RunInstances client token.
+	maxClientTokenLength = 64
+
+	// defaultInstanceRunningTimeout is how long to wait for a launched instance
+	// to be running when the machine doesn't set a resource creation timeout.
+	defaultInstanceRunningTimeout = 1 * time.Minute
 )
 
-// InstanceByTags returns the existing instance or nothing if it doesn't exist.
-func (s *Service) InstanceByTags(machine *actuators.MachineScope) (*v1alpha1.Instance, error) {
-	s.scope.V(2).Info("Looking for existing machine instance by tags")
+// InstanceByTags returns all the non-terminated instances tagged for the machine,
+// or nothing if none exist.
+func (s *Service) InstanceByTags(machine *actuators.MachineScope) ([]*v1alpha1.Instance, error) {
+	return s.instanceByTagsInStates(machine,
+		ec2.InstanceStateNamePending,
+		ec2.InstanceStateNameRunning,
+		ec2.InstanceStateNameStopping,
+		ec2.InstanceStateNameStopped,
+	)
+}
+
+// UnterminatedInstanceByTags returns all the instances tagged for the machine
+// that aren't terminated yet, including the ones shutting down, or nothing if
+// none exist.
+func (s *Service) UnterminatedInstanceByTags(machine *actuators.MachineScope) ([]*v1alpha1.Instance, error) {
+	return s.instanceByTagsInStates(machine,
+		ec2.InstanceStateNamePending,
+		ec2.InstanceStateNameRunning,
+		ec2.InstanceStateNameShuttingDown,
+		ec2.InstanceStateNameStopping,
+		ec2.InstanceStateNameStopped,
+	)
+}
+
+func (s *Service) instanceByTagsInStates(machine *actuators.MachineScope, states ...string) ([]*v1alpha1.Instance, error) {
+	s.scope.V(2).Info("Looking for existing machine instances by tags", "states", states)
 
 	input := &ec2.DescribeInstancesInput{
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
 			filter.EC2.ClusterOwned(s.scope.Name()),
 			filter.EC2.Name(machine.Name()),
-			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+			filter.EC2.InstanceStates(states...),
 		},
 	}
 
-	out, err := s.scope.EC2.DescribeInstances(input)
+	var instances []*v1alpha1.Instance
+	var convertErr error
+	err := s.scope.EC2.DescribeInstancesPages(input, func(out *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, res := range out.Reservations {
+			for _, inst := range res.Instances {
+				instance, err := s.SDKToInstance(inst)
+				if err != nil {
+					convertErr = err
+					return false
+				}
+				instances = append(instances, instance)
+			}
+		}
+		return true
+	})
 	switch {
 	case awserrors.IsNotFound(err):
 		return nil, nil
 	case err != nil:
 		return nil, errors.Wrap(err, "failed to describe instances by tags")
+	case convertErr != nil:
+		return nil, convertErr
 	}
 
-	// TODO: currently just returns the first matched instance, need to
-	// better rationalize how to find the right instance to return if multiple
-	// match
-	for _, res := range out.Reservations {
-		for _, inst := range res.Instances {
-			return s.SDKToInstance(inst)
+	sortInstancesByLaunchTime(instances)
+	return instances, nil
+}
+
+// sortInstancesByLaunchTime sorts instances from the most recently launched to
+// the least recently launched, so picking the first of several matches is
+// deterministic. Instances launched at the same time are sorted by ID, and
+// instances without a launch time come last.
+func sortInstancesByLaunchTime(instances []*v1alpha1.Instance) {
+	sort.SliceStable(instances, func(i, j int) bool {
+		a, b := instances[i].LaunchTime, instances[j].LaunchTime
+		switch {
+		case a == nil && b == nil:
+			return instances[i].ID < instances[j].ID
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		case !a.Equal(b):
+			return b.Before(a)
+		default:
+			return instances[i].ID < instances[j].ID
 		}
+	})
+}
+
+// ListInstancesByCluster returns all the non-terminated instances tagged as
+// owned by the given cluster.
+func (s *Service) ListInstancesByCluster(clusterName string) ([]*v1alpha1.Instance, error) {
+	s.scope.V(2).Info("Looking for instances owned by cluster", "cluster-name", clusterName)
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(clusterName),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNamePending,
+				ec2.InstanceStateNameRunning,
+				ec2.InstanceStateNameShuttingDown,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
 	}
 
-	return nil, nil
+	var instances []*v1alpha1.Instance
+	var convertErr error
+	err := s.scope.EC2.DescribeInstancesPages(input, func(out *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, res := range out.Reservations {
+			for _, inst := range res.Instances {
+				instance, err := s.SDKToInstance(inst)
+				if err != nil {
+					convertErr = err
+					return false
+				}
+				instances = append(instances, instance)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instances owned by cluster %q", clusterName)
+	}
+	if convertErr != nil {
+		return nil, convertErr
+	}
+
+	return instances, nil
 }
 
 // InstanceIfExists returns the existing instance or nothing if it doesn't exist.
 func (s *Service) InstanceIfExists(id *string) (*v1alpha1.Instance, error) {
+	return s.instanceIfExistsInStates(id, ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning)
+}
+
+// StoppedInstanceIfExists returns the instance if it is stopping or stopped,
+// or nothing otherwise.
+func (s *Service) StoppedInstanceIfExists(id *string) (*v1alpha1.Instance, error) {
+	return s.instanceIfExistsInStates(id, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped)
+}
+
+// AnyInstanceIfExists returns the instance in whatever state it is in,
+// including shutting down and terminated, or nothing if EC2 no longer knows
+// about it.
+func (s *Service) AnyInstanceIfExists(id *string) (*v1alpha1.Instance, error) {
+	return s.instanceIfExistsInStates(id)
+}
+
+func (s *Service) instanceIfExistsInStates(id *string, states ...string) (*v1alpha1.Instance, error) {
 	if id == nil {
 		s.scope.Info("Instance does not have an instance id")
 		return nil, nil
 	}
 
-	s.scope.V(2).Info("Looking for instance by id", "instance-id", *id)
+	s.scope.V(2).Info("Looking for instance by id", "instance-id", *id, "states", states)
 
 	input := &ec2.DescribeInstancesInput{
 		InstanceIds: []*string{id},
 		Filters: []*ec2.Filter{
 			filter.EC2.VPC(s.scope.VPC().ID),
-			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
 		},
 	}
+	if len(states) > 0 {
+		input.Filters = append(input.Filters, filter.EC2.InstanceStates(states...))
+	}
 
 	out, err := s.scope.EC2.DescribeInstances(input)
 	switch {
@@ -136,7 +264,45 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 	input := &v1alpha1.Instance{
 		Type:           machine.MachineConfig.InstanceType,
 		IAMProfile:     machine.MachineConfig.IAMInstanceProfile,
+		Monitoring:     machine.MachineConfig.Monitoring,
 		RootDeviceSize: machine.MachineConfig.RootDeviceSize,
+
+		RootDeviceEncrypted: machine.MachineConfig.RootVolumeEncrypted,
+		RootDeviceKMSKeyID:  machine.MachineConfig.RootVolumeKMSKeyID,
+
+		SecondaryPrivateIPCount:     machine.MachineConfig.SecondaryPrivateIPCount,
+		SecondaryPrivateIPs:         machine.MachineConfig.SecondaryPrivateIPs,
+		AdditionalNetworkInterfaces: machine.MachineConfig.AdditionalNetworkInterfaces,
+		EphemeralVolumes:            machine.MachineConfig.EphemeralVolumes,
+
+		PrimaryNetworkInterfaceDeleteOnTermination: machine.MachineConfig.PrimaryNetworkInterfaceDeleteOnTermination,
+
+		ElasticInferenceAccelerators: machine.MachineConfig.ElasticInferenceAccelerators,
+
+		CapacityReservationID:               machine.MachineConfig.CapacityReservationID,
+		CapacityReservationResourceGroupARN: machine.MachineConfig.CapacityReservationResourceGroupARN,
+		CapacityReservationPreference:       machine.MachineConfig.CapacityReservationPreference,
+	}
+
+	if err := validateAdditionalNetworkInterfaces(input.AdditionalNetworkInterfaces); err != nil {
+		return nil, err
+	}
+
+	if err := validateEphemeralVolumes(input.EphemeralVolumes); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateElasticInferenceAccelerators(input.Type, input.ElasticInferenceAccelerators); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateCreditSpecification(input.Type, machine.MachineConfig.CreditSpecification); err != nil {
+		return nil, err
+	}
+	input.CPUCredits = machine.MachineConfig.CreditSpecification
+
+	if options := machine.MachineConfig.InstanceMetadataOptions; options != nil {
+		input.HTTPTokens = options.HTTPTokens
 	}
 
 	input.Tags = v1alpha1.Build(v1alpha1.BuildParams{
@@ -149,41 +315,74 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 		},
 	})
 
-	var err error
+	if machine.MachineConfig.ComplianceScope != nil {
+		input.Tags[v1alpha1.NameAWSComplianceScope] = *machine.MachineConfig.ComplianceScope
+	}
+
+	specHash, err := ProviderSpecHash(machine.MachineConfig)
+	if err != nil {
+		return nil, err
+	}
+	input.Tags[v1alpha1.NameAWSProviderSpecHash] = specHash
+	input.Tags[v1alpha1.NameAWSClusterAPIContract] = clusterv1.SchemeGroupVersion.String()
+
 	// Pick image from the machine configuration, or use a default one.
-	if machine.MachineConfig.AMI.ID != nil {
-		input.ImageID = *machine.MachineConfig.AMI.ID
-	} else {
-		input.ImageID, err = s.defaultAMILookup(machine.MachineConfig.ImageLookupOrg, "ubuntu", "18.04", machine.Machine.Spec.Versions.Kubelet)
-		if err != nil {
-			return nil, err
-		}
+	input.ImageID, err = s.resolveAMI(machine)
+	if err != nil {
+		return nil, err
 	}
 
-	// Pick subnet from the machine configuration, or based on the availability zone specified,
-	// or default to the first private subnet available.
-	// TODO(vincepri): Move subnet picking logic to its own function/method.
-	if machine.MachineConfig.Subnet != nil && machine.MachineConfig.Subnet.ID != nil {
-		input.SubnetID = *machine.MachineConfig.Subnet.ID
+	input.SubnetID, err = s.resolveSubnet(machine)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validatePublicIP(machine, input.SubnetID); err != nil {
+		return nil, err
+	}
+
+	var zone string
+	if sn := s.scope.Subnets().FindByID(input.SubnetID); sn != nil {
+		zone = sn.AvailabilityZone
 	} else if machine.MachineConfig.AvailabilityZone != nil {
-		sns := s.scope.Subnets().FilterPrivate().FilterByZone(*machine.MachineConfig.AvailabilityZone)
-		if len(sns) == 0 {
-			return nil, awserrors.NewFailedDependency(
-				errors.Errorf("failed to run machine %q, no subnets available in availaibility zone %q",
-					machine.Name(),
-					*machine.MachineConfig.AvailabilityZone,
-				),
-			)
+		zone = *machine.MachineConfig.AvailabilityZone
+	}
+
+	if err := s.validateSecondaryPrivateIPs(input); err != nil {
+		return nil, err
+	}
+
+	if isLocalZone(s.scope.Region(), zone) {
+		s.scope.V(2).Info("Launching instance in local zone", "availability-zone", zone)
+		input.RootDeviceType = localZoneRootVolumeType
+	}
+
+	if err := machine.MachineConfig.Validate().ToAggregate(); err != nil {
+		return nil, err
+	}
+	input.Tenancy = machine.MachineConfig.Tenancy
+	input.HostID = machine.MachineConfig.HostID
+	input.Affinity = machine.MachineConfig.Affinity
+
+	if err := validateShutdownBehavior(machine.MachineConfig.InstanceInitiatedShutdownBehavior); err != nil {
+		return nil, err
+	}
+	input.InstanceInitiatedShutdownBehavior = machine.MachineConfig.InstanceInitiatedShutdownBehavior
+
+	if machine.MachineConfig.HibernationOptions != nil && machine.MachineConfig.HibernationOptions.Configured {
+		input.HibernationConfigured = true
+		if err := s.validateHibernation(input); err != nil {
+			return nil, err
 		}
-		input.SubnetID = sns[0].ID
-	} else if input.SubnetID == "" {
-		sns := s.scope.Subnets().FilterPrivate()
-		if len(sns) == 0 {
-			return nil, awserrors.NewFailedDependency(
-				errors.Errorf("failed to run machine %q, no subnets available", machine.Name()),
-			)
+	}
+
+	if machine.MachineConfig.HostResourceGroup != nil {
+		// Without a host with capacity in the group, leave it to auto-placement.
+		input.Tenancy = ec2.TenancyHost
+		input.HostID, err = s.selectHost(*machine.MachineConfig.HostResourceGroup, input.Type, zone)
+		if err != nil {
+			return nil, err
 		}
-		input.SubnetID = sns[0].ID
 	}
 
 	if !s.scope.ClusterConfig.CAKeyPair.HasCertAndKey() {
@@ -204,7 +403,12 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 		return input, err
 	}
 
-	apiServerEndpoint := fmt.Sprintf("%s:%d", machine.Network().APIServerELB.DNSName, apiServerBindPort)
+	apiServerEndpoint := fmt.Sprintf("%s:%d", machine.Network().APIServerELB.DNSName, machine.APIServerPort())
+
+	nodeName, err := nodeNameLookup(machine.MachineConfig.NodeNameSource)
+	if err != nil {
+		return nil, err
+	}
 
 	// apply values based on the role of the machine
 	switch machine.Role() {
@@ -217,9 +421,11 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 			setControlPlaneJoinConfigurationOptions(
 				&machine.MachineConfig.KubeadmConfiguration.Join,
 				machine.GetMachine(),
+				nodeName,
 				apiServerEndpoint,
 				bootstrapToken,
 				caCertHash,
+				int(s.scope.APIServerPort()),
 			)
 
 			joinConfigurationYAML, err := kubeadm.ConfigurationToYAML(&machine.MachineConfig.KubeadmConfiguration.Join)
@@ -257,7 +463,7 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 
 			kubeadm.SetClusterConfigurationOptions(
 				&s.scope.ClusterConfig.ClusterConfiguration,
-				kubeadm.WithControlPlaneEndpoint(fmt.Sprintf("%s:%d", s.scope.Network().APIServerELB.DNSName, apiServerBindPort)),
+				kubeadm.WithControlPlaneEndpoint(fmt.Sprintf("%s:%d", s.scope.Network().APIServerELB.DNSName, s.scope.APIServerPort())),
 				kubeadm.WithAPIServerCertificateSANs(localIPV4Lookup, s.scope.Network().APIServerELB.DNSName),
 				kubeadm.WithAPIServerExtraArgs(map[string]string{"cloud-provider": cloudProvider}),
 				kubeadm.WithControllerManagerExtraArgs(map[string]string{"cloud-provider": cloudProvider}),
@@ -270,7 +476,7 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 				return nil, err
 			}
 
-			setInitConfigurationOptions(&machine.MachineConfig.KubeadmConfiguration.Init, machine.GetMachine())
+			setInitConfigurationOptions(&machine.MachineConfig.KubeadmConfiguration.Init, machine.GetMachine(), nodeName, int(s.scope.APIServerPort()))
 
 			initConfigYAML, err := kubeadm.ConfigurationToYAML(&machine.MachineConfig.KubeadmConfiguration.Init)
 			if err != nil {
@@ -308,6 +514,7 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 		setNodeJoinConfigurationOptions(
 			&machine.MachineConfig.KubeadmConfiguration.Join,
 			machine.GetMachine(),
+			nodeName,
 			apiServerEndpoint,
 			bootstrapToken,
 			caCertHash,
@@ -335,6 +542,20 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 		return nil, errors.Errorf("Unknown node role %q", machine.Role())
 	}
 
+	// Wrap the bootstrap user data along with any additional parts into a
+	// multipart document, the bootstrap user data running first.
+	if len(s.scope.ClusterConfig.AdditionalUserDataParts) > 0 || len(machine.MachineConfig.AdditionalUserDataParts) > 0 {
+		parts := []userdata.Part{{Filename: "bootstrap", Content: aws.StringValue(input.UserData)}}
+		parts = append(parts, s.scope.ClusterConfig.AdditionalUserDataParts...)
+		parts = append(parts, machine.MachineConfig.AdditionalUserDataParts...)
+
+		userData, err := userdata.NewMultipart(parts)
+		if err != nil {
+			return nil, err
+		}
+		input.UserData = aws.String(userData)
+	}
+
 	ids, err := s.GetCoreSecurityGroups(machine)
 	if err != nil {
 		return nil, err
@@ -343,15 +564,48 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 		ids...,
 	)
 
+	// Attach the additional security groups at launch, resolving references by
+	// ARN or filters to the IDs they currently match.
+	additionalIDs, err := s.ResolveSecurityGroupReferences(machine.MachineConfig.AdditionalSecurityGroups)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[string]bool, len(input.SecurityGroupIDs))
+	for _, id := range input.SecurityGroupIDs {
+		attached[id] = true
+	}
+	for _, id := range additionalIDs {
+		if !attached[id] {
+			input.SecurityGroupIDs = append(input.SecurityGroupIDs, id)
+		}
+	}
+
 	// Pick SSH key, if any.
-	if machine.MachineConfig.KeyName != "" {
-		input.KeyName = aws.String(machine.MachineConfig.KeyName)
-	} else {
-		input.KeyName = aws.String(defaultSSHKeyName)
+	input.KeyName = aws.String(KeyPairName(machine))
+	if err := s.reconcileKeyPair(*input.KeyName); err != nil {
+		return nil, err
+	}
+
+	clientToken, err := instanceClientToken(machine)
+	if err != nil {
+		return nil, err
 	}
 
 	s.scope.V(2).Info("Running instance", "machine-role", machine.Role())
-	out, err := s.runInstance(machine.Role(), input)
+	waitTimeout := defaultInstanceRunningTimeout
+	if machine.MachineConfig.ResourceCreationTimeout != nil {
+		waitTimeout = machine.MachineConfig.ResourceCreationTimeout.Duration
+	}
+
+	out, err := s.runInstance(machine.Role(), input, clientToken, aws.BoolValue(machine.MachineConfig.ValidateLaunch), waitTimeout)
+	if code, _ := awserrors.Code(errors.Cause(err)); code == awserrors.IdempotentParameterMismatch {
+		// The token was used by an earlier launch with other parameters, e.g. the
+		// user data of another bootstrap token. That instance would have been found
+		// by tags if it was still around, so move on to a new token for the retry.
+		machine.MachineStatus.LaunchGeneration++
+		s.scope.Info("Client token was used by an earlier launch, bumping the launch generation",
+			"machine-name", machine.Name(), "launch-generation", machine.MachineStatus.LaunchGeneration)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -360,22 +614,59 @@ func (s *Service) createInstance(machine *actuators.MachineScope, bootstrapToken
 	return out, nil
 }
 
-func setInitConfigurationOptions(initConfig *kubeadmv1beta1.InitConfiguration, machine *clusterv1.Machine) {
+// instanceClientToken returns the client token to launch the machine's instance with.
+// Unless overridden by annotation, the token is derived from the machine's UID,
+// namespaced name and launch generation, so that a launch retried after the instance
+// ID failed to be persisted returns the original instance instead of launching a
+// second one, while a relaunch gets a token of its own.
+func instanceClientToken(machine *actuators.MachineScope) (string, error) {
+	token, ok := machine.Machine.Annotations[v1alpha1.AnnotationClientToken]
+	if !ok {
+		seed := fmt.Sprintf("%s/%s/%s", machine.Machine.UID, machine.Namespace(), machine.Name())
+		// The first generation keeps the token of launches predating generations.
+		if generation := machine.MachineStatus.LaunchGeneration; generation > 0 {
+			seed = fmt.Sprintf("%s/%d", seed, generation)
+		}
+		sum := sha256.Sum256([]byte(seed))
+		return hex.EncodeToString(sum[:]), nil
+	}
+
+	if len(token) > maxClientTokenLength {
+		return "", errors.Errorf("client token %q from annotation %q must not be longer than %d characters",
+			token, v1alpha1.AnnotationClientToken, maxClientTokenLength)
+	}
+	return token, nil
+}
+
+// nodeNameLookup returns the cloud init lookup the kubelet node name is resolved with for the given source.
+func nodeNameLookup(source v1alpha1.NodeNameSource) (string, error) {
+	switch source {
+	case "", v1alpha1.NodeNameSourcePrivateDNS:
+		return hostnameLookup, nil
+	case v1alpha1.NodeNameSourceInstanceID:
+		return instanceIDLookup, nil
+	default:
+		return "", errors.Errorf("unknown node name source %q", source)
+	}
+}
+
+func setInitConfigurationOptions(initConfig *kubeadmv1beta1.InitConfiguration, machine *clusterv1.Machine, nodeName string, bindPort int) {
 	kubeadm.SetInitConfigurationOptions(
 		initConfig,
 		kubeadm.WithNodeRegistrationOptions(
 			kubeadm.SetNodeRegistrationOptions(
 				&initConfig.NodeRegistration,
 				kubeadm.WithTaints(machine.Spec.Taints),
-				kubeadm.WithNodeRegistrationName(hostnameLookup),
+				kubeadm.WithNodeRegistrationName(nodeName),
 				kubeadm.WithCRISocket(getCRISocketPath(initConfig.NodeRegistration.CRISocket)),
 				kubeadm.WithKubeletExtraArgs(map[string]string{"cloud-provider": cloudProvider}),
 			),
 		),
+		kubeadm.WithLocalAPIEndpointPort(bindPort),
 	)
 }
 
-func setNodeJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguration, machine *clusterv1.Machine, apiServerEndpoint, bootstrapToken, caCertHash string) {
+func setNodeJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguration, machine *clusterv1.Machine, nodeName, apiServerEndpoint, bootstrapToken, caCertHash string) {
 	kubeadm.SetJoinConfigurationOptions(
 		joinConfig,
 		kubeadm.WithBootstrapTokenDiscovery(
@@ -388,7 +679,7 @@ func setNodeJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguratio
 		kubeadm.WithJoinNodeRegistrationOptions(
 			kubeadm.SetNodeRegistrationOptions(
 				&joinConfig.NodeRegistration,
-				kubeadm.WithNodeRegistrationName(hostnameLookup),
+				kubeadm.WithNodeRegistrationName(nodeName),
 				kubeadm.WithCRISocket(getCRISocketPath(joinConfig.NodeRegistration.CRISocket)),
 				kubeadm.WithKubeletExtraArgs(map[string]string{"cloud-provider": cloudProvider}),
 				kubeadm.WithTaints(machine.Spec.Taints),
@@ -398,7 +689,7 @@ func setNodeJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguratio
 	)
 }
 
-func setControlPlaneJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguration, machine *clusterv1.Machine, apiServerEndpoint, bootstrapToken, caCertHash string) {
+func setControlPlaneJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConfiguration, machine *clusterv1.Machine, nodeName, apiServerEndpoint, bootstrapToken, caCertHash string, bindPort int) {
 	kubeadm.SetJoinConfigurationOptions(
 		joinConfig,
 		kubeadm.WithBootstrapTokenDiscovery(
@@ -412,12 +703,12 @@ func setControlPlaneJoinConfigurationOptions(joinConfig *kubeadmv1beta1.JoinConf
 			kubeadm.SetNodeRegistrationOptions(
 				&joinConfig.NodeRegistration,
 				kubeadm.WithTaints(machine.Spec.Taints),
-				kubeadm.WithNodeRegistrationName(hostnameLookup),
+				kubeadm.WithNodeRegistrationName(nodeName),
 				kubeadm.WithCRISocket(getCRISocketPath(joinConfig.NodeRegistration.CRISocket)),
 				kubeadm.WithKubeletExtraArgs(map[string]string{"cloud-provider": cloudProvider}),
 			),
 		),
-		kubeadm.WithLocalAPIEndpointAndPort(localIPV4Lookup, apiServerBindPort),
+		kubeadm.WithLocalAPIEndpointAndPort(localIPV4Lookup, bindPort),
 	)
 }
 
@@ -496,21 +787,19 @@ func (s *Service) TerminateInstanceAndWait(instanceID string) error {
 
 // MachineExists will return whether or not a machine exists.
 func (s *Service) MachineExists(machine *actuators.MachineScope) (bool, error) {
-	var err error
-	var instance *v1alpha1.Instance
 	if machine.MachineStatus.InstanceID != nil {
-		instance, err = s.InstanceIfExists(machine.MachineStatus.InstanceID)
-	} else {
-		instance, err = s.InstanceByTags(machine)
+		instance, err := s.InstanceIfExists(machine.MachineStatus.InstanceID)
+		if err != nil && !awserrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to lookup machine %q", machine.Name())
+		}
+		return instance != nil, nil
 	}
 
-	if err != nil {
-		if awserrors.IsNotFound(err) {
-			return false, nil
-		}
+	instances, err := s.InstanceByTags(machine)
+	if err != nil && !awserrors.IsNotFound(err) {
 		return false, errors.Wrapf(err, "failed to lookup machine %q", machine.Name())
 	}
-	return instance != nil, nil
+	return len(instances) > 0, nil
 }
 
 // CreateOrGetMachine will either return an existing instance or create and return an instance.
@@ -529,18 +818,175 @@ func (s *Service) CreateOrGetMachine(machine *actuators.MachineScope, bootstrapT
 		}
 	}
 
+	// instance id supplied by the user, import it
+	if id := machine.Machine.Annotations[v1alpha1.AnnotationInstanceID]; id != "" {
+		return s.importInstance(machine, id)
+	}
+
 	s.scope.V(2).Info("Looking up machine by tags")
-	instance, err := s.InstanceByTags(machine)
+	instances, err := s.InstanceByTags(machine)
 	if err != nil && !awserrors.IsNotFound(err) {
 		return nil, errors.Wrapf(err, "failed to query machine %q instance by tags", machine.Name())
-	} else if err == nil && instance != nil {
-		return instance, nil
+	}
+
+	// Instances are sorted from the most recently launched, so this returns the
+	// newest pending or running instance if multiple match.
+	for _, instance := range instances {
+		switch instance.State {
+		case v1alpha1.InstanceStatePending, v1alpha1.InstanceStateRunning:
+			return instance, nil
+		}
+	}
+
+	if machine.Machine.Annotations[v1alpha1.AnnotationAdoptInstance] == "true" {
+		return s.adoptInstance(machine)
 	}
 
 	return s.createInstance(machine, bootstrapToken)
 }
 
-func (s *Service) runInstance(role string, i *v1alpha1.Instance) (*v1alpha1.Instance, error) {
+// resolveSubnet returns the ID of the subnet to launch the machine in.
+// Precedence is as follows:
+// 1. The subnet ID set in the machine configuration
+// 2. One of the subnets set in the machine configuration, see spreadSubnet
+// 3. The first private subnet of the cluster in the availability zone set in the machine configuration
+// 4. The first private subnet of the cluster
+func (s *Service) resolveSubnet(machine *actuators.MachineScope) (string, error) {
+	if machine.MachineConfig.Subnet != nil && machine.MachineConfig.Subnet.ID != nil {
+		return *machine.MachineConfig.Subnet.ID, nil
+	}
+
+	if len(machine.MachineConfig.Subnets) > 0 {
+		return spreadSubnet(machine)
+	}
+
+	sns := s.scope.Subnets().FilterPrivate()
+	var zone string
+	if machine.MachineConfig.AvailabilityZone != nil {
+		zone = *machine.MachineConfig.AvailabilityZone
+		sns = sns.FilterByZone(zone)
+	} else {
+		// Local zones are only used when asked for explicitly.
+		var regional v1alpha1.Subnets
+		for _, sn := range sns {
+			if !isLocalZone(s.scope.Region(), sn.AvailabilityZone) {
+				regional = append(regional, sn)
+			}
+		}
+		if len(regional) > 0 {
+			sns = regional
+		}
+	}
+
+	if len(sns) == 0 {
+		return "", &NoSubnetsError{
+			Machine:          machine.Name(),
+			Cluster:          s.scope.Name(),
+			VPC:              s.scope.VPC().ID,
+			AvailabilityZone: zone,
+			Subnets:          s.scope.Subnets(),
+		}
+	}
+
+	return sns[0].ID, nil
+}
+
+// validatePublicIP returns an error if the machine requests a public IP but
+// the subnet it's launched in has no route to an internet gateway, as the
+// instance couldn't reach the internet through its public IP.
+func (s *Service) validatePublicIP(machine *actuators.MachineScope, subnetID string) error {
+	if !aws.BoolValue(machine.MachineConfig.PublicIP) {
+		return nil
+	}
+
+	ok, err := s.subnetRoutesToInternetGateway(subnetID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("machine %q requests a public IP, but subnet %q has no route to an internet gateway", machine.Name(), subnetID)
+	}
+	return nil
+}
+
+// spreadSubnet returns the ID of one of the subnets set in the machine
+// configuration. The subnet recorded in the machine status is kept while it is
+// listed, otherwise the subnet is picked by a hash of the machine name so that
+// the replicas of a machine set are spread across the subnets.
+func spreadSubnet(machine *actuators.MachineScope) (string, error) {
+	var ids []string
+	for _, ref := range machine.MachineConfig.Subnets {
+		id := aws.StringValue(ref.ID)
+		if id == "" {
+			continue
+		}
+		if id == aws.StringValue(machine.MachineStatus.SubnetID) {
+			return id, nil
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return "", errors.Errorf("none of the subnets set for machine %q has an ID", machine.Name())
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(machine.Name()))
+	return ids[h.Sum32()%uint32(len(ids))], nil
+}
+
+// importInstance adopts the pre-existing instance with the given id for the
+// machine, tagging it as owned by the cluster. The instance must not be owned
+// by a cluster already, unless it was previously imported for this machine.
+func (s *Service) importInstance(machine *actuators.MachineScope, id string) (*v1alpha1.Instance, error) {
+	s.scope.V(2).Info("Importing instance for machine", "instance-id", id)
+
+	instance, err := s.InstanceIfExists(aws.String(id))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to look up instance %q to import for machine %q", id, machine.Name())
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance %q to import for machine %q was not found running in vpc %q", id, machine.Name(), s.scope.VPC().ID)
+	}
+
+	tags := v1alpha1.Tags(instance.Tags)
+	if tags.HasOwned(s.scope.Name()) && tags["Name"] == machine.Name() {
+		return instance, nil
+	}
+
+	for key := range tags {
+		if strings.HasPrefix(key, v1alpha1.NameAWSProviderOwned) || strings.HasPrefix(key, v1alpha1.NameKubernetesAWSCloudProviderPrefix) {
+			return nil, errors.Errorf("instance %q to import for machine %q is already owned by a cluster (tag %q)", id, machine.Name(), key)
+		}
+	}
+
+	owned := v1alpha1.Build(v1alpha1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   v1alpha1.ResourceLifecycleOwned,
+		Name:        aws.String(machine.Name()),
+		Role:        aws.String(machine.Role()),
+		Additional: v1alpha1.Tags{
+			v1alpha1.ClusterAWSCloudProviderTagKey(s.scope.Name()): string(v1alpha1.ResourceLifecycleOwned),
+		},
+	})
+
+	if err := s.UpdateResourceTags(aws.String(id), owned, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to tag instance %q imported for machine %q", id, machine.Name())
+	}
+
+	if instance.Tags == nil {
+		instance.Tags = map[string]string{}
+	}
+	for k, v := range owned {
+		instance.Tags[k] = v
+	}
+
+	s.scope.V(2).Info("Imported instance for machine", "instance-id", id)
+	return instance, nil
+}
+
+// runInstance launches the instance and waits up to waitTimeout for it to be
+// running. If it isn't running by then, the pending instance is returned.
+func (s *Service) runInstance(role string, i *v1alpha1.Instance, clientToken string, validate bool, waitTimeout time.Duration) (*v1alpha1.Instance, error) {
 	input := &ec2.RunInstancesInput{
 		InstanceType: aws.String(i.Type),
 		SubnetId:     aws.String(i.SubnetID),
@@ -551,6 +997,10 @@ func (s *Service) runInstance(role string, i *v1alpha1.Instance) (*v1alpha1.Inst
 		MinCount:     aws.Int64(1),
 	}
 
+	if clientToken != "" {
+		input.ClientToken = aws.String(clientToken)
+	}
+
 	if i.UserData != nil {
 		var buf bytes.Buffer
 
@@ -572,42 +1022,146 @@ func (s *Service) runInstance(role string, i *v1alpha1.Instance) (*v1alpha1.Inst
 		input.SecurityGroupIds = aws.StringSlice(i.SecurityGroupIDs)
 	}
 
+	// Secondary private IPs, additional network interfaces and the deletion of the
+	// primary network interface on termination can only be requested through network
+	// interface specifications, which must then hold the subnet and security groups
+	// of the primary network interface.
+	if i.SecondaryPrivateIPCount != nil || len(i.SecondaryPrivateIPs) > 0 || len(i.AdditionalNetworkInterfaces) > 0 || i.PrimaryNetworkInterfaceDeleteOnTermination != nil {
+		primary := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:                    aws.Int64(0),
+			SubnetId:                       input.SubnetId,
+			Groups:                         input.SecurityGroupIds,
+			SecondaryPrivateIpAddressCount: i.SecondaryPrivateIPCount,
+			DeleteOnTermination:            i.PrimaryNetworkInterfaceDeleteOnTermination,
+		}
+		for _, ip := range i.SecondaryPrivateIPs {
+			primary.PrivateIpAddresses = append(primary.PrivateIpAddresses, &ec2.PrivateIpAddressSpecification{
+				PrivateIpAddress: aws.String(ip),
+				Primary:          aws.Bool(false),
+			})
+		}
+		input.NetworkInterfaces = []*ec2.InstanceNetworkInterfaceSpecification{primary}
+		input.SubnetId = nil
+		input.SecurityGroupIds = nil
+
+		for _, eni := range i.AdditionalNetworkInterfaces {
+			spec := &ec2.InstanceNetworkInterfaceSpecification{
+				DeviceIndex:         aws.Int64(eni.DeviceIndex),
+				SubnetId:            aws.String(eni.SubnetID),
+				DeleteOnTermination: aws.Bool(true),
+			}
+			if len(eni.SecurityGroupIDs) > 0 {
+				spec.Groups = aws.StringSlice(eni.SecurityGroupIDs)
+			}
+			if eni.DeleteOnTermination != nil {
+				spec.DeleteOnTermination = eni.DeleteOnTermination
+			}
+			input.NetworkInterfaces = append(input.NetworkInterfaces, spec)
+		}
+	}
+
 	if i.IAMProfile != "" {
-		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
-			Name: aws.String(i.IAMProfile),
+		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{}
+		if strings.HasPrefix(i.IAMProfile, "arn:") {
+			input.IamInstanceProfile.Arn = aws.String(i.IAMProfile)
+		} else {
+			input.IamInstanceProfile.Name = aws.String(i.IAMProfile)
 		}
 	}
 
-	if i.RootDeviceSize != 0 {
-		rootDeviceName, err := s.getImageRootDevice(i.ImageID)
+	if i.Tenancy != "" || i.HostID != nil || i.Affinity != "" {
+		input.Placement = &ec2.Placement{
+			HostId: i.HostID,
+		}
+		if i.Tenancy != "" {
+			input.Placement.Tenancy = aws.String(i.Tenancy)
+		}
+		if i.Affinity != "" {
+			input.Placement.Affinity = aws.String(i.Affinity)
+		}
+	}
+
+	if i.InstanceInitiatedShutdownBehavior != nil {
+		input.InstanceInitiatedShutdownBehavior = i.InstanceInitiatedShutdownBehavior
+	}
+
+	if i.HibernationConfigured {
+		input.HibernationOptions = &ec2.HibernationOptionsRequest{
+			Configured: aws.Bool(true),
+		}
+	}
+
+	input.CapacityReservationSpecification = capacityReservationSpecification(i)
+
+	if i.HTTPTokens != "" {
+		input.MetadataOptions = &ec2.InstanceMetadataOptionsRequest{
+			HttpTokens: aws.String(string(i.HTTPTokens)),
+		}
+	}
+
+	if i.CPUCredits != nil {
+		input.CreditSpecification = &ec2.CreditSpecificationRequest{
+			CpuCredits: i.CPUCredits,
+		}
+	}
+
+	if len(i.ElasticInferenceAccelerators) > 0 {
+		input.ElasticInferenceAccelerators = elasticInferenceAccelerators(i.ElasticInferenceAccelerators)
+	}
+
+	if i.Monitoring != nil {
+		input.Monitoring = &ec2.RunInstancesMonitoringEnabled{
+			Enabled: i.Monitoring,
+		}
+	}
+
+	overrideRoot := i.RootDeviceSize != 0 || i.RootDeviceType != "" || i.RootDeviceEncrypted != nil || i.RootDeviceKMSKeyID != nil
+	if overrideRoot || len(i.EphemeralVolumes) > 0 {
+		image, err := s.getImage(i.ImageID)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get root volume from image %q", i.ImageID)
+			return nil, errors.Wrapf(err, "failed to get block device mappings from image %q", i.ImageID)
 		}
 
-		input.BlockDeviceMappings = []*ec2.BlockDeviceMapping{
-			{
-				DeviceName: rootDeviceName,
-				Ebs: &ec2.EbsBlockDevice{
-					DeleteOnTermination: aws.Bool(true),
-					VolumeSize:          aws.Int64(i.RootDeviceSize),
-				},
-			},
+		if overrideRoot {
+			root, err := rootBlockDeviceMapping(i, imageRootDevice(image))
+			if err != nil {
+				return nil, err
+			}
+			input.BlockDeviceMappings = append(input.BlockDeviceMappings, root)
+		}
+
+		ephemeral, err := ephemeralBlockDeviceMappings(i.EphemeralVolumes, image)
+		if err != nil {
+			return nil, err
 		}
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, ephemeral...)
 	}
 
 	if len(i.Tags) > 0 {
-		spec := &ec2.TagSpecification{ResourceType: aws.String(ec2.ResourceTypeInstance)}
+		input.TagSpecifications = append(input.TagSpecifications, tagSpecification(ec2.ResourceTypeInstance, i.Tags))
+
+		// The volumes and network interfaces created with the instance carry
+		// its tags too, except for the spec hash which only describes the
+		// instance, so they can be attributed to the cluster.
+		attached := make(map[string]string, len(i.Tags))
 		for key, value := range i.Tags {
-			spec.Tags = append(spec.Tags, &ec2.Tag{
-				Key:   aws.String(key),
-				Value: aws.String(value),
-			})
+			if key != v1alpha1.NameAWSProviderSpecHash {
+				attached[key] = value
+			}
 		}
+		input.TagSpecifications = append(input.TagSpecifications,
+			tagSpecification(ec2.ResourceTypeVolume, attached),
+			tagSpecification(ec2.ResourceTypeNetworkInterface, attached),
+		)
+	}
 
-		input.TagSpecifications = append(input.TagSpecifications, spec)
+	if validate {
+		if err := s.validateRunInstance(input); err != nil {
+			return nil, err
+		}
 	}
 
-	out, err := s.scope.EC2.RunInstances(input)
+	out, err := s.runInstancesWithInstanceProfileRetry(input)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to run instance: %v", i)
 	}
@@ -616,20 +1170,66 @@ func (s *Service) runInstance(role string, i *v1alpha1.Instance) (*v1alpha1.Inst
 		return nil, errors.Errorf("no instance returned for reservation %v", out.GoString())
 	}
 
-	waitTimeout := 1 * time.Minute
-	s.scope.V(2).Info("Waiting for instance to be in running state", "instance-id", *out.Instances[0].InstanceId, "timeout", waitTimeout.String())
-	ctx, cancel := context.WithTimeout(aws.BackgroundContext(), waitTimeout)
+	instance := converters.SDKToInstance(out.Instances[0])
+	if err := s.WaitForInstanceRunning(instance.ID, waitTimeout); err != nil {
+		s.scope.V(2).Info("Could not determine if Machine is running. Machine state might be unavailable until next renconciliation.")
+		return instance, nil
+	}
+
+	instance.State = v1alpha1.InstanceStateRunning
+	return instance, nil
+}
+
+// tagSpecification returns the specification of the tags to apply to the
+// resources of the given type created by RunInstances.
+func tagSpecification(resourceType string, tags map[string]string) *ec2.TagSpecification {
+	spec := &ec2.TagSpecification{ResourceType: aws.String(resourceType)}
+	for key, value := range tags {
+		spec.Tags = append(spec.Tags, &ec2.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return spec
+}
+
+// WaitForInstanceRunning waits for the instance to be running, up to the given
+// timeout. It returns an error for which awserrors.IsTimeout is true if the
+// instance isn't running in time.
+func (s *Service) WaitForInstanceRunning(instanceID string, timeout time.Duration) error {
+	s.scope.V(2).Info("Waiting for instance to be in running state", "instance-id", instanceID, "timeout", timeout.String())
+	ctx, cancel := context.WithTimeout(aws.BackgroundContext(), timeout)
 	defer cancel()
 
-	if err := s.scope.EC2.WaitUntilInstanceRunningWithContext(
+	err := s.scope.EC2.WaitUntilInstanceRunningWithContext(
 		ctx,
-		&ec2.DescribeInstancesInput{InstanceIds: []*string{out.Instances[0].InstanceId}},
+		&ec2.DescribeInstancesInput{InstanceIds: []*string{aws.String(instanceID)}},
 		request.WithWaiterLogger(&awslog{s.scope.Logger}),
-	); err != nil {
-		s.scope.V(2).Info("Could not determine if Machine is running. Machine state might be unavailable until next renconciliation.")
+	)
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil:
+		return awserrors.NewTimeout(errors.Errorf("instance %q is not running after %s", instanceID, timeout))
+	default:
+		return errors.Wrapf(err, "failed to wait for instance %q to be running", instanceID)
+	}
+}
+
+// validateRunInstance checks the launch parameters and permissions with a dry
+// run of RunInstances. EC2 reports a dry run that would have succeeded with
+// the DryRunOperation error code.
+func (s *Service) validateRunInstance(input *ec2.RunInstancesInput) error {
+	dryRun := *input
+	dryRun.DryRun = aws.Bool(true)
+	dryRun.ClientToken = nil
+
+	_, err := s.runInstancesWithInstanceProfileRetry(&dryRun)
+	if code, _ := awserrors.Code(err); err != nil && code != awserrors.DryRunOperation {
+		return errors.Wrapf(err, "failed to validate instance launch parameters")
 	}
 
-	return converters.SDKToInstance(out.Instances[0]), nil
+	return nil
 }
 
 // An internal type to satisfy aws' log interface.
@@ -641,45 +1241,175 @@ func (a *awslog) Log(args ...interface{}) {
 	a.WithName("aws-logger").Info("AWS context", args...)
 }
 
-// GetInstanceSecurityGroups returns a map from ENI id to the security groups applied to that ENI
-// While some security group operations take place at the "instance" level, these are in fact an API convenience for manipulating the first ("primary") ENI's properties.
-func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]string, error) {
-	enis, err := s.getInstanceENIs(instanceID)
+// GetInstanceSecurityGroups returns the ID of the primary network interface
+// of the instance and the security groups applied to it. While some security
+// group operations take place at the "instance" level, these are in fact an
+// API convenience for manipulating the primary ENI's properties; the groups of
+// any additional network interface are not managed by the machine. An empty
+// ID is returned if the instance has no primary network interface.
+func (s *Service) GetInstanceSecurityGroups(instanceID string) (string, []string, error) {
+	eni, err := s.getInstancePrimaryENI(instanceID)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get ENIs for instance %q", instanceID)
+		return "", nil, errors.Wrapf(err, "failed to get primary ENI for instance %q", instanceID)
+	}
+	if eni == nil {
+		return "", nil, nil
+	}
+
+	var groups []string
+	for _, group := range eni.Groups {
+		groups = append(groups, aws.StringValue(group.GroupId))
+	}
+	return aws.StringValue(eni.NetworkInterfaceId), groups, nil
+}
+
+// UpdateNetworkInterfaceSecurityGroups replaces the security groups of the
+// given network interface with ids, in a single call.
+func (s *Service) UpdateNetworkInterfaceSecurityGroups(eniID string, ids []string) error {
+	s.scope.V(2).Info("Attempting to update security groups on network interface", "eni-id", eniID, "security-groups", ids)
+
+	input := &ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String(eniID),
+		Groups:             aws.StringSlice(ids),
+	}
+
+	if _, err := s.scope.EC2.ModifyNetworkInterfaceAttribute(input); err != nil {
+		return errors.Wrapf(err, "failed to modify security groups of network interface %q", eniID)
 	}
 
-	out := make(map[string][]string)
-	for _, eni := range enis {
-		var groups []string
-		for _, group := range eni.Groups {
-			groups = append(groups, aws.StringValue(group.GroupId))
+	return nil
+}
+
+// UpdateInstanceMonitoring enables or disables detailed monitoring for the
+// given EC2 instance and returns the resulting monitoring state.
+func (s *Service) UpdateInstanceMonitoring(instanceID string, enabled bool) (string, error) {
+	s.scope.V(2).Info("Attempting to update monitoring on instance", "instance-id", instanceID, "enabled", enabled)
+
+	var monitorings []*ec2.InstanceMonitoring
+	if enabled {
+		input := &ec2.MonitorInstancesInput{
+			InstanceIds: aws.StringSlice([]string{instanceID}),
+		}
+
+		out, err := s.scope.EC2.MonitorInstances(input)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to enable monitoring on instance %q", instanceID)
 		}
-		out[aws.StringValue(eni.NetworkInterfaceId)] = groups
+		monitorings = out.InstanceMonitorings
+	} else {
+		input := &ec2.UnmonitorInstancesInput{
+			InstanceIds: aws.StringSlice([]string{instanceID}),
+		}
+
+		out, err := s.scope.EC2.UnmonitorInstances(input)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to disable monitoring on instance %q", instanceID)
+		}
+		monitorings = out.InstanceMonitorings
 	}
-	return out, nil
+
+	for _, m := range monitorings {
+		if aws.StringValue(m.InstanceId) == instanceID && m.Monitoring != nil {
+			return aws.StringValue(m.Monitoring.State), nil
+		}
+	}
+
+	return "", nil
 }
 
-// UpdateInstanceSecurityGroups modifies the security groups of the given
-// EC2 instance.
-func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string) error {
-	s.scope.V(2).Info("Attempting to update security groups on instance", "instance-id", instanceID)
+// UpdateInstanceSourceDestCheck enables or disables source/destination
+// checking for the given EC2 instance.
+func (s *Service) UpdateInstanceSourceDestCheck(instanceID string, enabled bool) error {
+	s.scope.V(2).Info("Attempting to update source/destination check on instance", "instance-id", instanceID, "enabled", enabled)
 
-	enis, err := s.getInstanceENIs(instanceID)
-	if err != nil {
-		return errors.Wrapf(err, "failed to get ENIs for instance %q", instanceID)
+	input := &ec2.ModifyInstanceAttributeInput{
+		InstanceId:      aws.String(instanceID),
+		SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(enabled)},
+	}
+
+	if _, err := s.scope.EC2.ModifyInstanceAttribute(input); err != nil {
+		return errors.Wrapf(err, "failed to update source/destination check on instance %q", instanceID)
+	}
+
+	return nil
+}
+
+// HibernateInstance hibernates the given instance, which must have been
+// launched with hibernation configured.
+func (s *Service) HibernateInstance(instanceID string) error {
+	s.scope.V(2).Info("Attempting to hibernate instance", "instance-id", instanceID)
+
+	input := &ec2.StopInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+		Hibernate:   aws.Bool(true),
+	}
+
+	if _, err := s.scope.EC2.StopInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to hibernate instance %q", instanceID)
+	}
+
+	return nil
+}
+
+// ResumeInstance starts the given hibernated or stopped instance.
+func (s *Service) ResumeInstance(instanceID string) error {
+	s.scope.V(2).Info("Attempting to resume instance", "instance-id", instanceID)
+
+	input := &ec2.StartInstancesInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
+	}
+
+	if _, err := s.scope.EC2.StartInstances(input); err != nil {
+		return errors.Wrapf(err, "failed to resume instance %q", instanceID)
+	}
+
+	return nil
+}
+
+// GetInstanceCreditSpecification returns the credit option for CPU usage of
+// the given burstable performance EC2 instance.
+func (s *Service) GetInstanceCreditSpecification(instanceID string) (string, error) {
+	input := &ec2.DescribeInstanceCreditSpecificationsInput{
+		InstanceIds: aws.StringSlice([]string{instanceID}),
 	}
 
-	s.scope.V(3).Info("Found ENIs on instance", "number-of-enis", len(enis), "instance-id", instanceID)
+	out, err := s.scope.EC2.DescribeInstanceCreditSpecifications(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe credit specification of instance %q", instanceID)
+	}
 
-	for _, eni := range enis {
-		input := &ec2.ModifyNetworkInterfaceAttributeInput{
-			NetworkInterfaceId: eni.NetworkInterfaceId,
-			Groups:             aws.StringSlice(ids),
+	for _, spec := range out.InstanceCreditSpecifications {
+		if aws.StringValue(spec.InstanceId) == instanceID {
+			return aws.StringValue(spec.CpuCredits), nil
 		}
+	}
+
+	return "", errors.Errorf("no credit specification found for instance %q", instanceID)
+}
+
+// UpdateInstanceCreditSpecification modifies the credit option for CPU usage
+// of the given burstable performance EC2 instance.
+func (s *Service) UpdateInstanceCreditSpecification(instanceID string, cpuCredits string) error {
+	s.scope.V(2).Info("Attempting to update credit specification on instance", "instance-id", instanceID, "cpu-credits", cpuCredits)
+
+	input := &ec2.ModifyInstanceCreditSpecificationInput{
+		InstanceCreditSpecifications: []*ec2.InstanceCreditSpecificationRequest{
+			{
+				InstanceId: aws.String(instanceID),
+				CpuCredits: aws.String(cpuCredits),
+			},
+		},
+	}
 
-		if _, err := s.scope.EC2.ModifyNetworkInterfaceAttribute(input); err != nil {
-			return errors.Wrapf(err, "failed to modify interface %q on instance %q", aws.StringValue(eni.NetworkInterfaceId), instanceID)
+	out, err := s.scope.EC2.ModifyInstanceCreditSpecification(input)
+	if err != nil {
+		return errors.Wrapf(err, "failed to modify credit specification of instance %q", instanceID)
+	}
+
+	for _, unsuccessful := range out.UnsuccessfulInstanceCreditSpecifications {
+		if unsuccessful.Error != nil {
+			return errors.Errorf("failed to modify credit specification of instance %q: %s: %s",
+				instanceID, aws.StringValue(unsuccessful.Error.Code), aws.StringValue(unsuccessful.Error.Message))
 		}
 	}
 
@@ -697,18 +1427,24 @@ func (s *Service) UpdateResourceTags(resourceID *string, create map[string]strin
 	if len(create) > 0 {
 		s.scope.V(2).Info("Attempting to create tags on resource", "resource-id", *resourceID)
 
-		// Convert our create map into an array of *ec2.Tag
-		createTagsInput := converters.MapToTags(create)
+		if batcher := currentTagBatcher(); batcher != nil {
+			if err := batcher.CreateTags(s.scope.EC2, resourceID, create); err != nil {
+				return errors.Wrapf(err, "failed to create tags for resource %q: %+v", *resourceID, create)
+			}
+		} else {
+			// Convert our create map into an array of *ec2.Tag
+			createTagsInput := converters.MapToTags(create)
 
-		// Create the CreateTags input.
-		input := &ec2.CreateTagsInput{
-			Resources: []*string{resourceID},
-			Tags:      createTagsInput,
-		}
+			// Create the CreateTags input.
+			input := &ec2.CreateTagsInput{
+				Resources: []*string{resourceID},
+				Tags:      createTagsInput,
+			}
 
-		// Create/Update tags in AWS.
-		if _, err := s.scope.EC2.CreateTags(input); err != nil {
-			return errors.Wrapf(err, "failed to create tags for resource %q: %+v", *resourceID, create)
+			// Create/Update tags in AWS.
+			if _, err := s.scope.EC2.CreateTags(input); err != nil {
+				return errors.Wrapf(err, "failed to create tags for resource %q: %+v", *resourceID, create)
+			}
 		}
 	}
 
@@ -716,31 +1452,43 @@ func (s *Service) UpdateResourceTags(resourceID *string, create map[string]strin
 	if len(remove) > 0 {
 		s.scope.V(2).Info("Attempting to delete tags on resource", "resource-id", *resourceID)
 
-		// Convert our remove map into an array of *ec2.Tag
-		removeTagsInput := converters.MapToTags(remove)
+		if batcher := currentTagBatcher(); batcher != nil {
+			if err := batcher.DeleteTags(s.scope.EC2, resourceID, remove); err != nil {
+				return errors.Wrapf(err, "failed to delete tags for resource %q: %v", *resourceID, remove)
+			}
+		} else {
+			// Convert our remove map into an array of *ec2.Tag
+			removeTagsInput := converters.MapToTags(remove)
 
-		// Create the DeleteTags input
-		input := &ec2.DeleteTagsInput{
-			Resources: []*string{resourceID},
-			Tags:      removeTagsInput,
-		}
+			// Create the DeleteTags input
+			input := &ec2.DeleteTagsInput{
+				Resources: []*string{resourceID},
+				Tags:      removeTagsInput,
+			}
 
-		// Delete tags in AWS.
-		if _, err := s.scope.EC2.DeleteTags(input); err != nil {
-			return errors.Wrapf(err, "failed to delete tags for resource %q: %v", *resourceID, remove)
+			// Delete tags in AWS.
+			if _, err := s.scope.EC2.DeleteTags(input); err != nil {
+				return errors.Wrapf(err, "failed to delete tags for resource %q: %v", *resourceID, remove)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, error) {
+// getInstancePrimaryENI returns the primary network interface of the
+// instance, the one attached at device index 0, or nil if there is none.
+func (s *Service) getInstancePrimaryENI(instanceID string) (*ec2.NetworkInterface, error) {
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: []*ec2.Filter{
 			{
 				Name:   aws.String("attachment.instance-id"),
 				Values: []*string{aws.String(instanceID)},
 			},
+			{
+				Name:   aws.String("attachment.device-index"),
+				Values: []*string{aws.String("0")},
+			},
 		},
 	}
 
@@ -749,10 +1497,155 @@ func (s *Service) getInstanceENIs(instanceID string) ([]*ec2.NetworkInterface, e
 		return nil, err
 	}
 
-	return output.NetworkInterfaces, nil
+	for _, eni := range output.NetworkInterfaces {
+		if eni.Attachment != nil && aws.Int64Value(eni.Attachment.DeviceIndex) == 0 {
+			return eni, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// validateHibernation returns an error unless the root volume of the instance
+// will be encrypted, which hibernation requires.
+func (s *Service) validateHibernation(i *v1alpha1.Instance) error {
+	if aws.BoolValue(i.RootDeviceEncrypted) || i.RootDeviceKMSKeyID != nil {
+		return nil
+	}
+
+	imageRoot, err := s.getImageRootDevice(i.ImageID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get root volume from image %q", i.ImageID)
+	}
+	if imageRoot.Ebs == nil || !aws.BoolValue(imageRoot.Ebs.Encrypted) {
+		return errors.Errorf("hibernation requires an encrypted root volume, but the root volume of image %q isn't encrypted and no root volume encryption is set", i.ImageID)
+	}
+	return nil
+}
+
+// ephemeralVirtualName matches the virtual names of instance store volumes.
+var ephemeralVirtualName = regexp.MustCompile(`^ephemeral([0-9]|1[0-9]|2[0-3])$`)
+
+// validateEphemeralVolumes returns an error if the instance store volumes can't
+// be mapped at launch. Device names may be left empty to be allocated.
+func validateEphemeralVolumes(volumes []v1alpha1.EphemeralVolume) error {
+	virtualNames := map[string]bool{}
+	deviceNames := map[string]string{}
+	for _, v := range volumes {
+		if !ephemeralVirtualName.MatchString(v.VirtualName) {
+			return errors.Errorf("invalid virtual name %q for ephemeral volume, must be ephemeral0 to ephemeral23", v.VirtualName)
+		}
+		if virtualNames[v.VirtualName] {
+			return errors.Errorf("ephemeral volume %q is mapped more than once", v.VirtualName)
+		}
+		virtualNames[v.VirtualName] = true
+
+		if v.DeviceName == "" {
+			continue
+		}
+		device := normalizeDeviceName(v.DeviceName)
+		if other, ok := deviceNames[device]; ok {
+			return errors.Errorf("device %q of ephemeral volume %q collides with the device of ephemeral volume %q", v.DeviceName, v.VirtualName, other)
+		}
+		deviceNames[device] = v.VirtualName
+	}
+	return nil
 }
 
-func (s *Service) getImageRootDevice(imageID string) (*string, error) {
+// ephemeralDeviceLetters are the letters of the devices that instance store
+// volumes without a device name are mapped to, in order, e.g. "/dev/sdb".
+const ephemeralDeviceLetters = "bcdefghijklmnopqrstuvwxyz"
+
+// ephemeralBlockDeviceMappings returns the block device mappings of the
+// instance store volumes. Device names that are set must not collide with the
+// devices mapped by the image, including its root device. The volumes without
+// a device name are assigned the first free device from "/dev/sdb" onwards, in
+// the order they are listed, so the same configuration always yields the same
+// mappings.
+func ephemeralBlockDeviceMappings(volumes []v1alpha1.EphemeralVolume, image *ec2.Image) ([]*ec2.BlockDeviceMapping, error) {
+	used := map[string]bool{}
+	if root := aws.StringValue(image.RootDeviceName); root != "" {
+		used[normalizeDeviceName(root)] = true
+	}
+	for _, bdm := range image.BlockDeviceMappings {
+		used[normalizeDeviceName(aws.StringValue(bdm.DeviceName))] = true
+	}
+
+	for _, v := range volumes {
+		if v.DeviceName == "" {
+			continue
+		}
+		device := normalizeDeviceName(v.DeviceName)
+		if used[device] {
+			return nil, errors.Errorf("device %q of ephemeral volume %q collides with a device mapped by image %q", v.DeviceName, v.VirtualName, aws.StringValue(image.ImageId))
+		}
+		used[device] = true
+	}
+
+	mappings := make([]*ec2.BlockDeviceMapping, 0, len(volumes))
+	next := 0
+	for _, v := range volumes {
+		deviceName := v.DeviceName
+		for deviceName == "" {
+			if next == len(ephemeralDeviceLetters) {
+				return nil, errors.Errorf("no free device left to map ephemeral volume %q to", v.VirtualName)
+			}
+			letter := ephemeralDeviceLetters[next : next+1]
+			next++
+			if !used[letter] {
+				used[letter] = true
+				deviceName = "/dev/sd" + letter
+			}
+		}
+
+		mappings = append(mappings, &ec2.BlockDeviceMapping{
+			DeviceName:  aws.String(deviceName),
+			VirtualName: aws.String(v.VirtualName),
+		})
+	}
+	return mappings, nil
+}
+
+// normalizeDeviceName returns the letter identifying a block device, so that
+// the names EC2 treats as the same device compare equal: "/dev/sdb",
+// "/dev/xvdb" and "/dev/sdb1" all yield "b".
+func normalizeDeviceName(name string) string {
+	name = strings.TrimPrefix(name, "/dev/")
+	if strings.HasPrefix(name, "xvd") {
+		name = strings.TrimPrefix(name, "xvd")
+	} else {
+		name = strings.TrimPrefix(name, "sd")
+	}
+	return strings.TrimRight(name, "0123456789")
+}
+
+// validateShutdownBehavior returns an error unless the instance initiated
+// shutdown behavior is unset, "stop" or "terminate".
+func validateShutdownBehavior(behavior *string) error {
+	if behavior == nil {
+		return nil
+	}
+
+	switch *behavior {
+	case ec2.ShutdownBehaviorStop, ec2.ShutdownBehaviorTerminate:
+		return nil
+	default:
+		return errors.Errorf("invalid instance initiated shutdown behavior %q, must be %q or %q", *behavior, ec2.ShutdownBehaviorStop, ec2.ShutdownBehaviorTerminate)
+	}
+}
+
+// getImageRootDevice returns the block device mapping of the root device of
+// the image. Only its device name is set if the image doesn't map it.
+func (s *Service) getImageRootDevice(imageID string) (*ec2.BlockDeviceMapping, error) {
+	image, err := s.getImage(imageID)
+	if err != nil {
+		return nil, err
+	}
+	return imageRootDevice(image), nil
+}
+
+// getImage returns the image with the given ID.
+func (s *Service) getImage(imageID string) (*ec2.Image, error) {
 	input := &ec2.DescribeImagesInput{
 		ImageIds: []*string{aws.String(imageID)},
 	}
@@ -765,8 +1658,64 @@ func (s *Service) getImageRootDevice(imageID string) (*string, error) {
 	if len(output.Images) == 0 {
 		return nil, errors.Errorf("no images returned when looking up ID %q", imageID)
 	}
+	return output.Images[0], nil
+}
 
-	return output.Images[0].RootDeviceName, nil
+// imageRootDevice returns the block device mapping of the root device of the
+// image. Only its device name is set if the image doesn't map it.
+func imageRootDevice(image *ec2.Image) *ec2.BlockDeviceMapping {
+	for _, bdm := range image.BlockDeviceMappings {
+		if aws.StringValue(bdm.DeviceName) == aws.StringValue(image.RootDeviceName) {
+			return bdm
+		}
+	}
+	return &ec2.BlockDeviceMapping{DeviceName: image.RootDeviceName}
+}
+
+// rootBlockDeviceMapping returns the block device mapping overriding the root
+// device of the image for the instance. The device is named after the root
+// device of the image, which differs between AMIs, e.g. /dev/xvda or /dev/sda1.
+// Overriding the mapping must not drop the encryption of the image snapshot,
+// so unless a different KMS key is requested the root volume inherits the
+// encryption and key of the image.
+func rootBlockDeviceMapping(i *v1alpha1.Instance, imageRoot *ec2.BlockDeviceMapping) (*ec2.BlockDeviceMapping, error) {
+	if aws.StringValue(imageRoot.DeviceName) == "" {
+		return nil, errors.Errorf("image %q has no root device name, its root volume can't be configured", i.ImageID)
+	}
+
+	ebs := &ec2.EbsBlockDevice{
+		DeleteOnTermination: aws.Bool(true),
+	}
+	if i.RootDeviceSize != 0 {
+		ebs.VolumeSize = aws.Int64(i.RootDeviceSize)
+	}
+	if i.RootDeviceType != "" {
+		ebs.VolumeType = aws.String(i.RootDeviceType)
+	}
+
+	imageEncrypted := imageRoot.Ebs != nil && aws.BoolValue(imageRoot.Ebs.Encrypted)
+	if imageEncrypted {
+		if i.RootDeviceEncrypted != nil && !*i.RootDeviceEncrypted {
+			return nil, errors.Errorf("root volume of image %q is encrypted and can't be launched unencrypted", i.ImageID)
+		}
+		ebs.Encrypted = aws.Bool(true)
+		ebs.KmsKeyId = imageRoot.Ebs.KmsKeyId
+	} else if i.RootDeviceEncrypted != nil {
+		ebs.Encrypted = i.RootDeviceEncrypted
+	}
+
+	if i.RootDeviceKMSKeyID != nil {
+		if i.RootDeviceEncrypted != nil && !*i.RootDeviceEncrypted {
+			return nil, errors.New("a KMS key can't be set for an unencrypted root volume")
+		}
+		ebs.Encrypted = aws.Bool(true)
+		ebs.KmsKeyId = i.RootDeviceKMSKeyID
+	}
+
+	return &ec2.BlockDeviceMapping{
+		DeviceName: imageRoot.DeviceName,
+		Ebs:        ebs,
+	}, nil
 }
 
 func (s *Service) getInstanceRootDeviceSize(instance *ec2.Instance) (*int64, error) {
@@ -810,6 +1759,41 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*v1alpha1.Instance, error) {
 		EBSOptimized: v.EbsOptimized,
 	}
 
+	if v.LaunchTime != nil {
+		i.LaunchTime = &metav1.Time{Time: *v.LaunchTime}
+	}
+
+	i.SecondaryPrivateIPs = converters.SecondaryPrivateIPs(v)
+	i.NetworkInterfaceIDs = converters.NetworkInterfaceIDs(v)
+
+	if v.Placement != nil {
+		i.AvailabilityZone = aws.StringValue(v.Placement.AvailabilityZone)
+		i.Tenancy = aws.StringValue(v.Placement.Tenancy)
+		i.HostID = v.Placement.HostId
+		i.Affinity = aws.StringValue(v.Placement.Affinity)
+	}
+
+	i.SourceDestCheck = v.SourceDestCheck
+
+	if v.HibernationOptions != nil {
+		i.HibernationConfigured = aws.BoolValue(v.HibernationOptions.Configured)
+	}
+
+	i.CapacityReservationID = v.CapacityReservationId
+	if v.CapacityReservationSpecification != nil {
+		i.CapacityReservationPreference = v.CapacityReservationSpecification.CapacityReservationPreference
+	}
+
+	if v.Monitoring != nil && v.Monitoring.State != nil {
+		i.MonitoringState = *v.Monitoring.State
+		switch i.MonitoringState {
+		case ec2.MonitoringStateEnabled, ec2.MonitoringStatePending:
+			i.Monitoring = aws.Bool(true)
+		default:
+			i.Monitoring = aws.Bool(false)
+		}
+	}
+
 	// Extract IAM Instance Profile name from ARN
 	// TODO: Handle this comparison more safely, perhaps by querying IAM for the
 	// instance profile ARN and comparing to the ARN returned by EC2
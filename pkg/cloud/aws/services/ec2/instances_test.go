@@ -17,10 +17,15 @@ limitations under the License.
 package ec2
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -29,6 +34,7 @@ import (
 	kubeadmv1beta1 "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/elb/mock_elbiface"
@@ -188,6 +194,412 @@ func TestInstanceIfExists(t *testing.T) {
 	}
 }
 
+func TestAnyInstanceIfExists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+		NetworkSpec: v1alpha1.NetworkSpec{
+			VPC: v1alpha1.VPCSpec{
+				ID: "test-vpc",
+			},
+		},
+	}
+
+	// The instance is looked up without filtering on its state.
+	ec2Mock.EXPECT().DescribeInstances(gomock.Eq(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String("id-1")},
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String("test-vpc")},
+			},
+		},
+	})).Return(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{
+			{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId:   aws.String("id-1"),
+						InstanceType: aws.String("m5.large"),
+						SubnetId:     aws.String("subnet-1"),
+						ImageId:      aws.String("ami-1"),
+						State: &ec2.InstanceState{
+							Code: aws.Int64(48),
+							Name: aws.String(ec2.InstanceStateNameTerminated),
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	instance, err := NewService(scope).AnyInstanceIfExists(aws.String("id-1"))
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if instance == nil || instance.State != v1alpha1.InstanceStateTerminated {
+		t.Fatalf("expected the terminated instance, got %+v", instance)
+	}
+}
+
+func TestInstanceByTags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	instance := func(id, state string) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:   aws.String(id),
+			InstanceType: aws.String("m5.large"),
+			SubnetId:     aws.String("subnet-1"),
+			ImageId:      aws.String("ami-1"),
+			State: &ec2.InstanceState{
+				Name: aws.String(state),
+			},
+		}
+	}
+
+	launchedAt := func(inst *ec2.Instance, launchTime time.Time) *ec2.Instance {
+		inst.LaunchTime = aws.Time(launchTime)
+		return inst
+	}
+
+	pages := func(outs ...*ec2.DescribeInstancesOutput) func(*ec2.DescribeInstancesInput, func(*ec2.DescribeInstancesOutput, bool) bool) {
+		return func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+			for i, out := range outs {
+				if !fn(out, i == len(outs)-1) {
+					return
+				}
+			}
+		}
+	}
+
+	now := time.Now().Truncate(time.Second)
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		check  func(instances []*v1alpha1.Instance, err error)
+	}{
+		{
+			name: "does not exist",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					Do(pages(&ec2.DescribeInstancesOutput{})).
+					Return(nil)
+			},
+			check: func(instances []*v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if len(instances) != 0 {
+					t.Fatalf("Did not expect anything but got something: %+v", instances)
+				}
+			},
+		},
+		{
+			name: "multiple instances exist",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.Eq(&ec2.DescribeInstancesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String("test-vpc")},
+						},
+						{
+							Name:   aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+							Values: []*string{aws.String("owned")},
+						},
+						{
+							Name:   aws.String("tag:Name"),
+							Values: []*string{aws.String("test-machine")},
+						},
+						{
+							Name:   aws.String("instance-state-name"),
+							Values: []*string{aws.String("pending"), aws.String("running"), aws.String("stopping"), aws.String("stopped")},
+						},
+					},
+				}), gomock.Any()).
+					Do(pages(&ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{
+							{
+								Instances: []*ec2.Instance{
+									instance("id-1", ec2.InstanceStateNameRunning),
+									instance("id-2", ec2.InstanceStateNamePending),
+								},
+							},
+							{
+								Instances: []*ec2.Instance{
+									instance("id-3", ec2.InstanceStateNameStopped),
+								},
+							},
+						},
+					})).
+					Return(nil)
+			},
+			check: func(instances []*v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if len(instances) != 3 {
+					t.Fatalf("expected 3 instances but got: %+v", instances)
+				}
+
+				for i, id := range []string{"id-1", "id-2", "id-3"} {
+					if instances[i].ID != id {
+						t.Fatalf("expected %s but got: %v", id, instances[i].ID)
+					}
+				}
+			},
+		},
+		{
+			name: "instances on multiple pages",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					Do(pages(
+						&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{Instances: []*ec2.Instance{launchedAt(instance("id-1", ec2.InstanceStateNameRunning), now.Add(-2*time.Hour))}},
+							},
+						},
+						&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{Instances: []*ec2.Instance{launchedAt(instance("id-2", ec2.InstanceStateNamePending), now)}},
+							},
+						},
+						&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{Instances: []*ec2.Instance{launchedAt(instance("id-3", ec2.InstanceStateNameStopped), now.Add(-time.Hour))}},
+							},
+						},
+					)).
+					Return(nil)
+			},
+			check: func(instances []*v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				// Most recently launched first.
+				expected := []string{"id-2", "id-3", "id-1"}
+				if len(instances) != len(expected) {
+					t.Fatalf("expected %d instances but got: %+v", len(expected), instances)
+				}
+				for i, id := range expected {
+					if instances[i].ID != id {
+						t.Fatalf("expected %s at index %d but got: %v", id, i, instances[i].ID)
+					}
+				}
+			},
+		},
+		{
+			name: "instances launched at the same time",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					Do(pages(
+						&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{
+									Instances: []*ec2.Instance{
+										instance("id-4", ec2.InstanceStateNameRunning),
+										launchedAt(instance("id-3", ec2.InstanceStateNameRunning), now),
+									},
+								},
+							},
+						},
+						&ec2.DescribeInstancesOutput{
+							Reservations: []*ec2.Reservation{
+								{Instances: []*ec2.Instance{launchedAt(instance("id-2", ec2.InstanceStateNameRunning), now)}},
+							},
+						},
+					)).
+					Return(nil)
+			},
+			check: func(instances []*v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				// Ties are broken by ID, instances without a launch time come last.
+				expected := []string{"id-2", "id-3", "id-4"}
+				if len(instances) != len(expected) {
+					t.Fatalf("expected %d instances but got: %+v", len(expected), instances)
+				}
+				for i, id := range expected {
+					if instances[i].ID != id {
+						t.Fatalf("expected %s at index %d but got: %v", id, i, instances[i].ID)
+					}
+				}
+			},
+		},
+		{
+			name: "error describing instances",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+					Return(errors.New("some unknown error"))
+			},
+			check: func(instances []*v1alpha1.Instance, err error) {
+				if err == nil {
+					t.Fatalf("expected an error but got none.")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-machine"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					VPC: v1alpha1.VPCSpec{
+						ID: "test-vpc",
+					},
+				},
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope.Scope)
+			instances, err := s.InstanceByTags(scope)
+			tc.check(instances, err)
+		})
+	}
+}
+
+func TestListInstancesByCluster(t *testing.T) {
+	instance := func(id string) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId:   aws.String(id),
+			InstanceType: aws.String("m5.large"),
+			SubnetId:     aws.String("subnet-1"),
+			ImageId:      aws.String("ami-1"),
+			State: &ec2.InstanceState{
+				Name: aws.String(ec2.InstanceStateNameRunning),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		pages    []*ec2.DescribeInstancesOutput
+		expected []string
+	}{
+		{
+			name:     "no instances",
+			pages:    []*ec2.DescribeInstancesOutput{{}},
+			expected: []string{},
+		},
+		{
+			name: "instances across reservations and pages",
+			pages: []*ec2.DescribeInstancesOutput{
+				{
+					Reservations: []*ec2.Reservation{
+						{Instances: []*ec2.Instance{instance("i-1"), instance("i-2")}},
+						{Instances: []*ec2.Instance{instance("i-3")}},
+					},
+				},
+				{
+					Reservations: []*ec2.Reservation{
+						{Instances: []*ec2.Instance{instance("i-4")}},
+					},
+				},
+			},
+			expected: []string{"i-1", "i-2", "i-3", "i-4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().
+				DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+				Do(func(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) {
+					expectedFilters := []*ec2.Filter{
+						{
+							Name:   aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+							Values: aws.StringSlice([]string{"owned"}),
+						},
+						{
+							Name: aws.String("instance-state-name"),
+							Values: aws.StringSlice([]string{
+								ec2.InstanceStateNamePending,
+								ec2.InstanceStateNameRunning,
+								ec2.InstanceStateNameShuttingDown,
+								ec2.InstanceStateNameStopping,
+								ec2.InstanceStateNameStopped,
+							}),
+						},
+					}
+					if !reflect.DeepEqual(input.Filters, expectedFilters) {
+						t.Fatalf("expected filters %v, got %v", expectedFilters, input.Filters)
+					}
+
+					for i, page := range tc.pages {
+						if !fn(page, i == len(tc.pages)-1) {
+							return
+						}
+					}
+				}).
+				Return(nil)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			s := NewService(scope)
+			instances, err := s.ListInstancesByCluster("test-cluster")
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			ids := []string{}
+			for _, i := range instances {
+				ids = append(ids, i.ID)
+			}
+			if !reflect.DeepEqual(ids, tc.expected) {
+				t.Fatalf("expected instances %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
+
 func TestTerminateInstance(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -258,52 +670,174 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
-func TestCreateInstance(t *testing.T) {
-	testCaCert := []byte(`
------BEGIN CERTIFICATE-----
-MIID6jCCAtICCQCa6H6nD76FxzANBgkqhkiG9w0BAQsFADCBtjELMAkGA1UEBhMC
-VVMxCzAJBgNVBAgMAldBMRMwEQYDVQQHDAprdWJlcm5ldGVzMRQwEgYDVQQKDAtj
-bHVzdGVyLWFwaTEhMB8GA1UECwwYY2x1c3Rlci1hcGktcHJvdmlkZXItYXdzMTAw
-LgYDVQQDDCdzaWdzLms4cy5pby5jbHVzdGVyLWFwaS1wcm92aWRlci1hd3MuYWYx
-GjAYBgkqhkiG9w0BCQEWC2Zvb0BiYXIuY29tMB4XDTE5MDExMTA5MTgxNVoXDTIx
-MTAwNzA5MTgxNVowgbYxCzAJBgNVBAYTAlVTMQswCQYDVQQIDAJXQTETMBEGA1UE
-BwwKa3ViZXJuZXRlczEUMBIGA1UECgwLY2x1c3Rlci1hcGkxITAfBgNVBAsMGGNs
-dXN0ZXItYXBpLXByb3ZpZGVyLWF3czEwMC4GA1UEAwwnc2lncy5rOHMuaW8uY2x1
-c3Rlci1hcGktcHJvdmlkZXItYXdzLmFmMRowGAYJKoZIhvcNAQkBFgtmb29AYmFy
-LmNvbTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBAKHVoYcq6NiS2lch
-ai62dDU+wStXJzFkF3URQ7auYDmL3Xz+01yxdARdafO3fweXSsfuxcGZ/DDBzRBB
-ROXeJI1zxV6xk+OlI0puOabo6m5ji4RdTTFqt94afnK43qcDMDOnh0u6F5UZXZlr
-T7XNnO++6e7elZ+9jJJ/NKPXDGKo9+M7kmypTLcI5b5pH4qn1coe8a5Li+FQONEM
-j+Ttomqr0s84DyFBSNZYKvRVL1AdH/6r213pco5Qm9RDkw9HZr83Y1PjyQ77C7FQ
-IPquny5XkjZjq65Bz8I8s+MoPQgBOr8JvVfc3Jt8u10qD4JOeRFnhZOygaApgswg
-9XZhdMsCAwEAATANBgkqhkiG9w0BAQsFAAOCAQEAVfucXOzEy88NQ+fz5FV1D1PO
-No6uqi2Q9fqGU9Lfnj3PhXr0sb0tAXGnZEg8i1317xMXqzA9J9umqg3ADsOsR3sL
-NR41dkjP2ROfTW1wkEGBaRzp/TOagMy1IeeS9MPd4gRH3cZqgUvrQJCrX8878gxk
-jor3R8gPhjvV74KrZD4lIF7IHHv4cCBaejm+3GwOIbTNoHXa4PadVwbcjWp6P8UB
-dTga1FiyISsMchVaVKD5aX7hkxMP1/C98KdVzWQ4k12TBOhZDYUS67M4ibBtw/og
-vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
------END CERTIFICATE-----
-	`)
-	testcases := []struct {
-		name          string
-		machine       clusterv1.Machine
-		machineConfig *v1alpha1.AWSMachineProviderSpec
-		clusterStatus *v1alpha1.AWSClusterProviderStatus
-		clusterConfig *v1alpha1.AWSClusterProviderSpec
-		cluster       clusterv1.Cluster
-		expect        func(m *mock_ec2iface.MockEC2APIMockRecorder)
-		check         func(instance *v1alpha1.Instance, err error)
+func TestGetInstanceSecurityGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name         string
+		enis         []*ec2.NetworkInterface
+		expectENI    string
+		expectGroups []string
 	}{
 		{
-			name: "simple",
-			machine: clusterv1.Machine{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"set": "node"},
+			name: "single network interface",
+			enis: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-primary"),
+					Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+					Groups:             []*ec2.GroupIdentifier{{GroupId: aws.String("sg-core")}},
 				},
 			},
-			machineConfig: &v1alpha1.AWSMachineProviderSpec{
-				AMI: v1alpha1.AWSResourceReference{
-					ID: aws.String("abc"),
+			expectENI:    "eni-primary",
+			expectGroups: []string{"sg-core"},
+		},
+		{
+			name: "multiple network interfaces",
+			enis: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-secondary"),
+					Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(1)},
+					Groups:             []*ec2.GroupIdentifier{{GroupId: aws.String("sg-secondary")}},
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-primary"),
+					Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(0)},
+					Groups: []*ec2.GroupIdentifier{
+						{GroupId: aws.String("sg-core")},
+						{GroupId: aws.String("sg-extra")},
+					},
+				},
+			},
+			expectENI:    "eni-primary",
+			expectGroups: []string{"sg-core", "sg-extra"},
+		},
+		{
+			name: "no primary network interface",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			ec2Mock.EXPECT().
+				DescribeNetworkInterfaces(gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("attachment.instance-id"),
+							Values: aws.StringSlice([]string{"i-1"}),
+						},
+						{
+							Name:   aws.String("attachment.device-index"),
+							Values: aws.StringSlice([]string{"0"}),
+						},
+					},
+				})).
+				Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: tc.enis}, nil)
+
+			s := NewService(scope)
+			eni, groups, err := s.GetInstanceSecurityGroups("i-1")
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if eni != tc.expectENI {
+				t.Fatalf("expected network interface %q, got %q", tc.expectENI, eni)
+			}
+			if !reflect.DeepEqual(groups, tc.expectGroups) {
+				t.Fatalf("expected security groups %v, got %v", tc.expectGroups, groups)
+			}
+		})
+	}
+}
+
+func TestUpdateNetworkInterfaceSecurityGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	ec2Mock.EXPECT().
+		ModifyNetworkInterfaceAttribute(gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: aws.String("eni-primary"),
+			Groups:             aws.StringSlice([]string{"sg-core", "sg-extra"}),
+		})).
+		Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil).
+		Times(1)
+
+	s := NewService(scope)
+	if err := s.UpdateNetworkInterfaceSecurityGroups("eni-primary", []string{"sg-core", "sg-extra"}); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+var testCaCert = []byte(`
+-----BEGIN CERTIFICATE-----
+MIID6jCCAtICCQCa6H6nD76FxzANBgkqhkiG9w0BAQsFADCBtjELMAkGA1UEBhMC
+VVMxCzAJBgNVBAgMAldBMRMwEQYDVQQHDAprdWJlcm5ldGVzMRQwEgYDVQQKDAtj
+bHVzdGVyLWFwaTEhMB8GA1UECwwYY2x1c3Rlci1hcGktcHJvdmlkZXItYXdzMTAw
+LgYDVQQDDCdzaWdzLms4cy5pby5jbHVzdGVyLWFwaS1wcm92aWRlci1hd3MuYWYx
+GjAYBgkqhkiG9w0BCQEWC2Zvb0BiYXIuY29tMB4XDTE5MDExMTA5MTgxNVoXDTIx
+MTAwNzA5MTgxNVowgbYxCzAJBgNVBAYTAlVTMQswCQYDVQQIDAJXQTETMBEGA1UE
+BwwKa3ViZXJuZXRlczEUMBIGA1UECgwLY2x1c3Rlci1hcGkxITAfBgNVBAsMGGNs
+dXN0ZXItYXBpLXByb3ZpZGVyLWF3czEwMC4GA1UEAwwnc2lncy5rOHMuaW8uY2x1
+c3Rlci1hcGktcHJvdmlkZXItYXdzLmFmMRowGAYJKoZIhvcNAQkBFgtmb29AYmFy
+LmNvbTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBAKHVoYcq6NiS2lch
+ai62dDU+wStXJzFkF3URQ7auYDmL3Xz+01yxdARdafO3fweXSsfuxcGZ/DDBzRBB
+ROXeJI1zxV6xk+OlI0puOabo6m5ji4RdTTFqt94afnK43qcDMDOnh0u6F5UZXZlr
+T7XNnO++6e7elZ+9jJJ/NKPXDGKo9+M7kmypTLcI5b5pH4qn1coe8a5Li+FQONEM
+j+Ttomqr0s84DyFBSNZYKvRVL1AdH/6r213pco5Qm9RDkw9HZr83Y1PjyQ77C7FQ
+IPquny5XkjZjq65Bz8I8s+MoPQgBOr8JvVfc3Jt8u10qD4JOeRFnhZOygaApgswg
+9XZhdMsCAwEAATANBgkqhkiG9w0BAQsFAAOCAQEAVfucXOzEy88NQ+fz5FV1D1PO
+No6uqi2Q9fqGU9Lfnj3PhXr0sb0tAXGnZEg8i1317xMXqzA9J9umqg3ADsOsR3sL
+NR41dkjP2ROfTW1wkEGBaRzp/TOagMy1IeeS9MPd4gRH3cZqgUvrQJCrX8878gxk
+jor3R8gPhjvV74KrZD4lIF7IHHv4cCBaejm+3GwOIbTNoHXa4PadVwbcjWp6P8UB
+dTga1FiyISsMchVaVKD5aX7hkxMP1/C98KdVzWQ4k12TBOhZDYUS67M4ibBtw/og
+vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
+-----END CERTIFICATE-----
+	`)
+
+func TestCreateInstance(t *testing.T) {
+	testcases := []struct {
+		name          string
+		machine       clusterv1.Machine
+		machineConfig *v1alpha1.AWSMachineProviderSpec
+		clusterStatus *v1alpha1.AWSClusterProviderStatus
+		clusterConfig *v1alpha1.AWSClusterProviderSpec
+		cluster       clusterv1.Cluster
+		expect        func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		check         func(instance *v1alpha1.Instance, err error)
+	}{
+		{
+			name: "simple",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
 			},
@@ -396,7 +930,7 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 			},
 		},
 		{
-			name: "with availability zone",
+			name: "with dedicated host tenancy",
 			machine: clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -406,8 +940,9 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 				AMI: v1alpha1.AWSResourceReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType:     "m5.2xlarge",
-				AvailabilityZone: aws.String("us-east-1c"),
+				InstanceType: "m5.large",
+				Tenancy:      "host",
+				HostID:       aws.String("h-1"),
 			},
 			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
 				Network: v1alpha1.Network{
@@ -431,24 +966,11 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 				NetworkSpec: v1alpha1.NetworkSpec{
 					Subnets: v1alpha1.Subnets{
 						&v1alpha1.SubnetSpec{
-							ID:               "subnet-1",
-							AvailabilityZone: "us-east-1a",
-							IsPublic:         false,
-						},
-						&v1alpha1.SubnetSpec{
-							ID:               "subnet-2",
-							AvailabilityZone: "us-east-1b",
-							IsPublic:         false,
-						},
-						&v1alpha1.SubnetSpec{
-							ID:               "subnet-3",
-							AvailabilityZone: "us-east-1c",
-							IsPublic:         false,
+							ID:       "subnet-1",
+							IsPublic: false,
 						},
 						&v1alpha1.SubnetSpec{
-							ID:               "subnet-3-public",
-							AvailabilityZone: "us-east-1c",
-							IsPublic:         true,
+							IsPublic: false,
 						},
 					},
 				},
@@ -483,9 +1005,13 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 							},
 						},
 					}, nil)
-
 				m.
-					RunInstances(gomock.Any()).
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if input.Placement == nil || aws.StringValue(input.Placement.Tenancy) != "host" || aws.StringValue(input.Placement.HostId) != "h-1" {
+							t.Fatalf("expected the instance to be placed on host h-1, got %v", input.Placement)
+						}
+					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -497,12 +1023,11 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 								},
 								InstanceId:   aws.String("two"),
 								InstanceType: aws.String("m5.large"),
-								SubnetId:     aws.String("subnet-3"),
+								SubnetId:     aws.String("subnet-1"),
 								ImageId:      aws.String("ami-1"),
 							},
 						},
 					}, nil)
-
 				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
@@ -510,516 +1035,4202 @@ vuO9LYxDXLVY9F7W4ccyCqe27Cj1xyAvdZxwhITrib8Wg5CMqoRpqTw5V3+TpA==
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
-
-				if instance.SubnetID != "subnet-3" {
-					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
-				}
 			},
 		},
-	}
-
-	for _, tc := range testcases {
-		t.Run(tc.name, func(t *testing.T) {
-			mockCtrl := gomock.NewController(t)
-			// defer mockCtrl.Finish()
-			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
-			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
-
-			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
-				Cluster: &tc.cluster,
-				Machine: &clusterv1.Machine{
-					ObjectMeta: metav1.ObjectMeta{
-						Labels: map[string]string{
-							"set": "node",
-						},
-					},
-				},
-				AWSClients: actuators.AWSClients{
-					EC2: ec2Mock,
-					ELB: elbMock,
-				},
-			})
-
-			if err != nil {
-				t.Fatalf("Failed to create test context: %v", err)
-			}
-
-			scope.Scope.ClusterConfig = tc.clusterConfig
-			scope.Scope.ClusterStatus = tc.clusterStatus
-			scope.MachineConfig = tc.machineConfig
-			tc.expect(ec2Mock.EXPECT())
-
-			s := NewService(scope.Scope)
-			instance, err := s.createInstance(scope, "token")
-			tc.check(instance, err)
-		})
-	}
-}
-
-func Test_setInitConfigurationOptions(t *testing.T) {
-	type args struct {
-		initConfig kubeadmv1beta1.InitConfiguration
-		machine    *clusterv1.Machine
-	}
-	tests := []struct {
-		name     string
-		args     args
-		expected kubeadmv1beta1.InitConfiguration
-	}{
 		{
-			name: "simple",
-			args: args{
-				initConfig: kubeadmv1beta1.InitConfiguration{},
-				machine:    &clusterv1.Machine{},
-			},
-			expected: kubeadmv1beta1.InitConfiguration{
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-					},
+			name: "with resource creation timeout, instance running",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
 				},
 			},
-		},
-		{
-			name: "with node taints",
-			args: args{
-				initConfig: kubeadmv1beta1.InitConfiguration{},
-				machine: &clusterv1.Machine{
-					Spec: clusterv1.MachineSpec{
-						Taints: []corev1.Taint{{
-							Key:    "my-property",
-							Value:  "special",
-							Effect: corev1.TaintEffectNoSchedule,
-						}},
-					},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
 				},
+				InstanceType:            "m5.large",
+				ResourceCreationTimeout: &metav1.Duration{Duration: 5 * time.Minute},
 			},
-			expected: kubeadmv1beta1.InitConfiguration{
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
-					Taints: []corev1.Taint{{
-						Key:    "my-property",
-						Value:  "special",
-						Effect: corev1.TaintEffectNoSchedule,
-					}},
 				},
 			},
-		},
-		{
-			name: "with cni options",
-			args: args{
-				initConfig: kubeadmv1beta1.InitConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						KubeletExtraArgs: map[string]string{
-							"cni-bin-dir":  "/opt/cni/bin",
-							"cni-conf-dir": "/etc/cni/net.d",
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
 						},
 					},
 				},
-				machine: &clusterv1.Machine{},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
 			},
-			expected: kubeadmv1beta1.InitConfiguration{
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"cni-bin-dir":    "/opt/cni/bin",
-						"cni-conf-dir":   "/etc/cni/net.d",
-					},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
 				},
-			},
-		},
-		{
-			name: "with cri socket",
-			args: args{
-				initConfig: kubeadmv1beta1.InitConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						CRISocket: "/var/run/my-cri.sock",
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
 					},
 				},
-				machine: &clusterv1.Machine{},
 			},
-			expected: kubeadmv1beta1.InitConfiguration{
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/my-cri.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-					},
-				},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.WaiterOption) {
+						deadline, ok := ctx.Deadline()
+						if !ok || time.Until(deadline) <= time.Minute {
+							t.Fatalf("expected the wait to be bounded by the resource creation timeout, got deadline %v", deadline)
+						}
+					}).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.State != v1alpha1.InstanceStateRunning {
+					t.Fatalf("expected instance to be running, got %q", instance.State)
+				}
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			setInitConfigurationOptions(&tt.args.initConfig, tt.args.machine)
-
-			actual := tt.args.initConfig
-			if !reflect.DeepEqual(tt.expected, actual) {
-				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
-			}
-		})
-	}
-}
-
-func Test_setNodeJoinConfigurationOptions(t *testing.T) {
-	type args struct {
-		joinConfig        kubeadmv1beta1.JoinConfiguration
-		machine           *clusterv1.Machine
-		apiServerEndpoint string
-		bootstrapToken    string
-		caCertHash        string
-	}
-	tests := []struct {
-		name     string
-		args     args
-		expected kubeadmv1beta1.JoinConfiguration
-	}{
 		{
-			name: "simple",
-			args: args{
-				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
-			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
-						},
-						APIServerEndpoint: "https://api-server:6443",
-					},
-				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"node-labels":    "node-role.kubernetes.io/node=",
-					},
+			name: "with resource creation timeout, instance still pending",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
 				},
 			},
-		},
-		{
-			name: "with taints",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{},
-				machine: &clusterv1.Machine{
-					Spec: clusterv1.MachineSpec{
-						Taints: []corev1.Taint{{
-							Key:    "my-property",
-							Value:  "special",
-							Effect: corev1.TaintEffectNoSchedule,
-						}},
-					},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
 				},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
+				InstanceType:            "m5.large",
+				ResourceCreationTimeout: &metav1.Duration{Duration: 5 * time.Minute},
 			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
 						},
-						APIServerEndpoint: "https://api-server:6443",
 					},
-				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"node-labels":    "node-role.kubernetes.io/node=",
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
-					Taints: []corev1.Taint{{
-						Key:    "my-property",
-						Value:  "special",
-						Effect: corev1.TaintEffectNoSchedule,
-					}},
 				},
 			},
-		},
-		{
-			name: "with cni options",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						KubeletExtraArgs: map[string]string{
-							"cni-bin-dir":  "/opt/cni/bin",
-							"cni-conf-dir": "/etc/cni/net.d",
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
 						},
-					},
-				},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
-			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
 						},
-						APIServerEndpoint: "https://api-server:6443",
 					},
 				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"cni-bin-dir":    "/opt/cni/bin",
-						"cni-conf-dir":   "/etc/cni/net.d",
-						"node-labels":    "node-role.kubernetes.io/node=",
-					},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
 				},
 			},
-		},
-		{
-			name: "with cri socket",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						CRISocket: "/var/run/my-cri.sock",
-					},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
 				},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
-			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
 						},
-						APIServerEndpoint: "https://api-server:6443",
-					},
-				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/my-cri.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"node-labels":    "node-role.kubernetes.io/node=",
 					},
 				},
 			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(awserr.New(request.WaiterResourceNotReadyErrorCode, "exceeded wait attempts", nil))
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.State != v1alpha1.InstanceStatePending {
+					t.Fatalf("expected instance to be pending, got %q", instance.State)
+				}
+			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			setNodeJoinConfigurationOptions(&tt.args.joinConfig, tt.args.machine, tt.args.apiServerEndpoint, tt.args.bootstrapToken, tt.args.caCertHash)
-
-			actual := tt.args.joinConfig
-			if !reflect.DeepEqual(tt.expected, actual) {
-				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
-			}
-		})
-	}
-}
-
-func Test_setControlPlaneJoinConfigurationOptions(t *testing.T) {
-	type args struct {
-		joinConfig        kubeadmv1beta1.JoinConfiguration
-		machine           *clusterv1.Machine
-		apiServerEndpoint string
-		bootstrapToken    string
-		caCertHash        string
-	}
-	tests := []struct {
-		name     string
-		args     args
-		expected kubeadmv1beta1.JoinConfiguration
-	}{
 		{
-			name: "simple",
-			args: args{
-				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
+			name: "with launch validation",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
 			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:   "m5.large",
+				ValidateLaunch: aws.Bool(true),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
 						},
-						APIServerEndpoint: "https://api-server:6443",
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
 				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
 					},
 				},
-				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
-					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
-						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
-						BindPort:         6443,
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
 					},
 				},
 			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				dryRun := m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if !aws.BoolValue(input.DryRun) {
+							t.Fatalf("expected a dry run first, got %v", input)
+						}
+					}).
+					Return(nil, awserr.New(awserrors.DryRunOperation, "Request would have succeeded, but DryRun flag is set.", nil))
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if input.DryRun != nil {
+							t.Fatalf("expected the real launch not to be a dry run, got %v", input)
+						}
+					}).
+					After(dryRun).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
 		},
 		{
-			name: "with taints",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{},
-				machine: &clusterv1.Machine{
-					Spec: clusterv1.MachineSpec{
-						Taints: []corev1.Taint{{
-							Key:    "my-property",
-							Value:  "special",
-							Effect: corev1.TaintEffectNoSchedule,
-						}},
-					},
+			name: "with failing launch validation",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
 				},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
 			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
-						},
-						APIServerEndpoint: "https://api-server:6443",
-					},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
 				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
+				InstanceType:   "m5.large",
+				ValidateLaunch: aws.Bool(true),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
 					},
-					Taints: []corev1.Taint{{
-						Key:    "my-property",
-						Value:  "special",
-						Effect: corev1.TaintEffectNoSchedule,
-					}},
-				},
-				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
-					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
-						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
-						BindPort:         6443,
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
 				},
 			},
-		},
-		{
-			name: "with cni options",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						KubeletExtraArgs: map[string]string{
-							"cni-bin-dir":  "/opt/cni/bin",
-							"cni-conf-dir": "/etc/cni/net.d",
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
 						},
 					},
 				},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
-			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
 						},
-						APIServerEndpoint: "https://api-server:6443",
 					},
 				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/containerd/containerd.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
-						"cni-bin-dir":    "/opt/cni/bin",
-						"cni-conf-dir":   "/etc/cni/net.d",
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if !aws.BoolValue(input.DryRun) {
+							t.Fatalf("expected only a dry run, got %v", input)
+						}
+					}).
+					Return(nil, awserr.New(awserrors.UnauthorizedOperation, "You are not authorized to perform this operation.", nil))
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if code, _ := awserrors.Code(errors.Cause(err)); code != awserrors.UnauthorizedOperation {
+					t.Fatalf("expected the validation error, got %v", err)
+				}
+			},
+		},
+		{
+			name: "with availability zone",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:     "m5.2xlarge",
+				AvailabilityZone: aws.String("us-east-1c"),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
 				},
-				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
-					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
-						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
-						BindPort:         6443,
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-2",
+							AvailabilityZone: "us-east-1b",
+							IsPublic:         false,
+						},
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-3",
+							AvailabilityZone: "us-east-1c",
+							IsPublic:         false,
+						},
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-3-public",
+							AvailabilityZone: "us-east-1c",
+							IsPublic:         true,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
 					},
 				},
 			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+
+				m.
+					RunInstances(gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-3"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-3" {
+					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
 		},
 		{
-			name: "with cri socket",
-			args: args{
-				joinConfig: kubeadmv1beta1.JoinConfiguration{
-					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-						CRISocket: "/var/run/my-cri.sock",
-					},
+			name: "with local zone availability zone",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
 				},
-				machine:           &clusterv1.Machine{},
-				apiServerEndpoint: "https://api-server:6443",
-				bootstrapToken:    "abcdef.1234567890abcdef",
-				caCertHash:        "sha256:1234cdef",
 			},
-			expected: kubeadmv1beta1.JoinConfiguration{
-				Discovery: kubeadmv1beta1.Discovery{
-					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
-						Token: "abcdef.1234567890abcdef",
-						CACertHashes: []string{
-							"sha256:1234cdef",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:     "m5.2xlarge",
+				AvailabilityZone: aws.String("us-east-1-bos-1a"),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
 						},
-						APIServerEndpoint: "https://api-server:6443",
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
 					},
 				},
-				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
-					Name:      "{{ ds.meta_data.hostname }}",
-					CRISocket: "/var/run/my-cri.sock",
-					KubeletExtraArgs: map[string]string{
-						"cloud-provider": "aws",
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-lz",
+							AvailabilityZone: "us-east-1-bos-1a",
+							IsPublic:         false,
+						},
 					},
 				},
-				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
-					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
-						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
-						BindPort:         6443,
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
 					},
 				},
 			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			setControlPlaneJoinConfigurationOptions(&tt.args.joinConfig, tt.args.machine, tt.args.apiServerEndpoint, tt.args.bootstrapToken, tt.args.caCertHash)
-
-			actual := tt.args.joinConfig
-			if !reflect.DeepEqual(tt.expected, actual) {
-				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String("abc")}}).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:           aws.String("ami-1"),
+								RootDeviceName: aws.String("/dev/xvda"),
+							},
+						},
+					}, nil)
+
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if len(input.BlockDeviceMappings) != 1 {
+							t.Fatalf("expected a root block device mapping, got %v", input.BlockDeviceMappings)
+						}
+						ebs := input.BlockDeviceMappings[0].Ebs
+						if aws.StringValue(ebs.VolumeType) != ec2.VolumeTypeGp2 || ebs.VolumeSize != nil {
+							t.Fatalf("expected a gp2 root volume of the image size, got %v", ebs)
+						}
+
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-lz"),
+									ImageId:      aws.String("ami-1"),
+								},
+							},
+						}, nil
+					})
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-lz" {
+					t.Fatalf("expected subnet-lz from local zone us-east-1-bos-1a, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with root device size and an encrypted image",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:   "m5.2xlarge",
+				RootDeviceSize: 100,
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String("abc")}}).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:           aws.String("ami-1"),
+								RootDeviceName: aws.String("/dev/xvda"),
+								BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+									{
+										DeviceName: aws.String("/dev/xvda"),
+										Ebs: &ec2.EbsBlockDevice{
+											SnapshotId: aws.String("snap-1"),
+											Encrypted:  aws.Bool(true),
+											KmsKeyId:   aws.String("arn:aws:kms:us-east-1:123456789012:key/image"),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if len(input.BlockDeviceMappings) != 1 {
+							t.Fatalf("expected a root block device mapping, got %v", input.BlockDeviceMappings)
+						}
+						ebs := input.BlockDeviceMappings[0].Ebs
+						if aws.Int64Value(ebs.VolumeSize) != 100 {
+							t.Fatalf("expected a root volume of 100GiB, got %v", ebs)
+						}
+						if !aws.BoolValue(ebs.Encrypted) || aws.StringValue(ebs.KmsKeyId) != "arn:aws:kms:us-east-1:123456789012:key/image" {
+							t.Fatalf("expected the root volume to inherit the encryption of the image, got %v", ebs)
+						}
+
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+								},
+							},
+						}, nil
+					})
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-1" {
+					t.Fatalf("expected subnet-1, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with ephemeral volumes",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:   "m5d.2xlarge",
+				RootDeviceSize: 100,
+				EphemeralVolumes: []v1alpha1.EphemeralVolume{
+					{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"},
+					{VirtualName: "ephemeral1", DeviceName: "/dev/sdc"},
+				},
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String("abc")}}).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:           aws.String("ami-1"),
+								RootDeviceName: aws.String("/dev/xvda"),
+								BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+									{
+										DeviceName: aws.String("/dev/xvda"),
+										Ebs: &ec2.EbsBlockDevice{
+											SnapshotId: aws.String("snap-1"),
+											Encrypted:  aws.Bool(true),
+											KmsKeyId:   aws.String("arn:aws:kms:us-east-1:123456789012:key/image"),
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if len(input.BlockDeviceMappings) != 3 {
+							t.Fatalf("expected a root and 2 ephemeral block device mappings, got %v", input.BlockDeviceMappings)
+						}
+						if aws.StringValue(input.BlockDeviceMappings[0].DeviceName) != "/dev/xvda" || aws.Int64Value(input.BlockDeviceMappings[0].Ebs.VolumeSize) != 100 {
+							t.Fatalf("expected a root volume of 100GiB, got %v", input.BlockDeviceMappings[0])
+						}
+						for i, expected := range []struct{ virtualName, deviceName string }{{"ephemeral0", "/dev/sdb"}, {"ephemeral1", "/dev/sdc"}} {
+							bdm := input.BlockDeviceMappings[i+1]
+							if aws.StringValue(bdm.VirtualName) != expected.virtualName || aws.StringValue(bdm.DeviceName) != expected.deviceName || bdm.Ebs != nil {
+								t.Fatalf("expected %s mapped to %s, got %v", expected.virtualName, expected.deviceName, bdm)
+							}
+						}
+
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+								},
+							},
+						}, nil
+					})
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-1" {
+					t.Fatalf("expected subnet-1, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with elastic inference accelerators",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "c5.large",
+				ElasticInferenceAccelerators: []v1alpha1.ElasticInferenceAccelerator{
+					{Type: "eia1.medium", Count: 2},
+				},
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						if len(input.ElasticInferenceAccelerators) != 2 {
+							t.Fatalf("expected 2 elastic inference accelerators, got %v", input.ElasticInferenceAccelerators)
+						}
+						for _, a := range input.ElasticInferenceAccelerators {
+							if aws.StringValue(a.Type) != "eia1.medium" {
+								t.Fatalf("expected an eia1.medium accelerator, got %v", a)
+							}
+						}
+
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("c5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+								},
+							},
+						}, nil
+					})
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-1" {
+					t.Fatalf("expected subnet-1, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with additional security groups",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				AdditionalSecurityGroups: []v1alpha1.AWSResourceReference{
+					{ID: aws.String("sg-extra")},
+					{ID: aws.String("2")},
+					{Filters: []v1alpha1.Filter{{Name: "tag:role", Values: []string{"monitoring"}}}},
+				},
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					VPC: v1alpha1.VPCSpec{
+						ID: "vpc-1",
+					},
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:               "subnet-1",
+							AvailabilityZone: "us-east-1a",
+							IsPublic:         false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+						Filters: []*ec2.Filter{
+							{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-1"})},
+							{Name: aws.String("tag:role"), Values: aws.StringSlice([]string{"monitoring"})},
+						},
+					}).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{{GroupId: aws.String("sg-monitoring")}},
+					}, nil)
+
+				m.
+					RunInstances(gomock.Any()).
+					DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						expected := []string{"2", "3", "sg-extra", "sg-monitoring"}
+						if !reflect.DeepEqual(aws.StringValueSlice(input.SecurityGroupIds), expected) {
+							t.Fatalf("expected security groups %v, got %v", expected, aws.StringValueSlice(input.SecurityGroupIds))
+						}
+
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									InstanceId:   aws.String("two"),
+									InstanceType: aws.String("m5.large"),
+									SubnetId:     aws.String("subnet-1"),
+									ImageId:      aws.String("ami-1"),
+								},
+							},
+						}, nil
+					})
+
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-1" {
+					t.Fatalf("expected subnet-1, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with client token annotation",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"set": "node"},
+					Annotations: map[string]string{v1alpha1.AnnotationClientToken: "my-token"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if aws.StringValue(input.ClientToken) != "my-token" {
+							t.Fatalf("expected client token %q, got %q", "my-token", aws.StringValue(input.ClientToken))
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with iam instance profile arn",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:       "m5.large",
+				IAMInstanceProfile: "arn:aws:iam::123456789012:instance-profile/path/foo",
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						expected := "arn:aws:iam::123456789012:instance-profile/path/foo"
+						if aws.StringValue(input.IamInstanceProfile.Arn) != expected {
+							t.Fatalf("expected instance profile arn %q, got %q", expected, aws.StringValue(input.IamInstanceProfile.Arn))
+						}
+						if input.IamInstanceProfile.Name != nil {
+							t.Fatalf("expected no instance profile name, got %q", aws.StringValue(input.IamInstanceProfile.Name))
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/path/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with secondary private ips",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:            "m5.large",
+				SecondaryPrivateIPCount: aws.Int64(2),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if input.SubnetId != nil || input.SecurityGroupIds != nil {
+							t.Fatalf("expected subnet and security groups to be set on the network interface, got %v and %v", input.SubnetId, input.SecurityGroupIds)
+						}
+						if len(input.NetworkInterfaces) != 1 {
+							t.Fatalf("expected 1 network interface, got %d", len(input.NetworkInterfaces))
+						}
+						eni := input.NetworkInterfaces[0]
+						if aws.Int64Value(eni.DeviceIndex) != 0 || aws.StringValue(eni.SubnetId) != "subnet-1" || aws.Int64Value(eni.SecondaryPrivateIpAddressCount) != 2 {
+							t.Fatalf("unexpected network interface %v", eni)
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+								NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+									{
+										Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+											DeviceIndex: aws.Int64(0),
+										},
+										PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+											{PrivateIpAddress: aws.String("10.0.0.10"), Primary: aws.Bool(true)},
+											{PrivateIpAddress: aws.String("10.0.0.11"), Primary: aws.Bool(false)},
+											{PrivateIpAddress: aws.String("10.0.0.12"), Primary: aws.Bool(false)},
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(instance.SecondaryPrivateIPs) != 2 {
+					t.Fatalf("expected 2 secondary private ips, got %v", instance.SecondaryPrivateIPs)
+				}
+			},
+		},
+		{
+			name: "with instance initiated shutdown behavior",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:                      "m5.large",
+				InstanceInitiatedShutdownBehavior: aws.String(ec2.ShutdownBehaviorStop),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if aws.StringValue(input.InstanceInitiatedShutdownBehavior) != ec2.ShutdownBehaviorStop {
+							t.Fatalf("expected shutdown behavior %q, got %v", ec2.ShutdownBehaviorStop, input.InstanceInitiatedShutdownBehavior)
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if aws.StringValue(input.InstanceInitiatedShutdownBehavior) != ec2.ShutdownBehaviorStop {
+							t.Fatalf("expected shutdown behavior %q, got %v", ec2.ShutdownBehaviorStop, input.InstanceInitiatedShutdownBehavior)
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+								NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+									{
+										Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+											DeviceIndex: aws.Int64(0),
+										},
+										PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+											{PrivateIpAddress: aws.String("10.0.0.10"), Primary: aws.Bool(true)},
+											{PrivateIpAddress: aws.String("10.0.0.11"), Primary: aws.Bool(false)},
+											{PrivateIpAddress: aws.String("10.0.0.12"), Primary: aws.Bool(false)},
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with explicit secondary private ips",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:        "m5.large",
+				SecondaryPrivateIPs: []string{"10.0.0.11", "10.0.0.12"},
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:        "subnet-1",
+							CidrBlock: "10.0.0.0/24",
+							IsPublic:  false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						if input.SubnetId != nil || input.SecurityGroupIds != nil {
+							t.Fatalf("expected subnet and security groups to be set on the network interface, got %v and %v", input.SubnetId, input.SecurityGroupIds)
+						}
+						if len(input.NetworkInterfaces) != 1 {
+							t.Fatalf("expected 1 network interface, got %d", len(input.NetworkInterfaces))
+						}
+						eni := input.NetworkInterfaces[0]
+						if aws.Int64Value(eni.DeviceIndex) != 0 || aws.StringValue(eni.SubnetId) != "subnet-1" || eni.SecondaryPrivateIpAddressCount != nil {
+							t.Fatalf("unexpected network interface %v", eni)
+						}
+						if len(eni.PrivateIpAddresses) != 2 {
+							t.Fatalf("expected 2 private ip addresses, got %v", eni.PrivateIpAddresses)
+						}
+						for i, ip := range []string{"10.0.0.11", "10.0.0.12"} {
+							if aws.StringValue(eni.PrivateIpAddresses[i].PrivateIpAddress) != ip || aws.BoolValue(eni.PrivateIpAddresses[i].Primary) {
+								t.Fatalf("expected secondary private ip %q, got %v", ip, eni.PrivateIpAddresses[i])
+							}
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+								NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+									{
+										Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+											DeviceIndex: aws.Int64(0),
+										},
+										PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+											{PrivateIpAddress: aws.String("10.0.0.10"), Primary: aws.Bool(true)},
+											{PrivateIpAddress: aws.String("10.0.0.11"), Primary: aws.Bool(false)},
+											{PrivateIpAddress: aws.String("10.0.0.12"), Primary: aws.Bool(false)},
+										},
+									},
+								},
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(instance.SecondaryPrivateIPs) != 2 || instance.SecondaryPrivateIPs[0] != "10.0.0.11" {
+					t.Fatalf("expected secondary private ips 10.0.0.11 and 10.0.0.12, got %v", instance.SecondaryPrivateIPs)
+				}
+			},
+		},
+		{
+			name: "with explicit secondary private ips outside the subnet",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:        "m5.large",
+				SecondaryPrivateIPs: []string{"10.0.1.11"},
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:        "subnet-1",
+							CidrBlock: "10.0.0.0/24",
+							IsPublic:  false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err == nil {
+					t.Fatal("expected an error for a secondary private ip outside the subnet")
+				}
+			},
+		},
+		{
+			name: "with compliance scope",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:    "m5.large",
+				ComplianceScope: aws.String("pci"),
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						for _, spec := range input.TagSpecifications {
+							for _, tag := range spec.Tags {
+								if aws.StringValue(tag.Key) == v1alpha1.NameAWSComplianceScope && aws.StringValue(tag.Value) == "pci" {
+									return
+								}
+							}
+						}
+						t.Fatalf("expected tag %q=%q, got %+v", v1alpha1.NameAWSComplianceScope, "pci", input.TagSpecifications)
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with contract version tag",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.
+					RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					Do(func(input *ec2.RunInstancesInput) {
+						for _, spec := range input.TagSpecifications {
+							for _, tag := range spec.Tags {
+								if aws.StringValue(tag.Key) == v1alpha1.NameAWSClusterAPIContract && aws.StringValue(tag.Value) == "cluster.k8s.io/v1alpha1" {
+									return
+								}
+							}
+						}
+						t.Fatalf("expected tag %q=%q, got %+v", v1alpha1.NameAWSClusterAPIContract, "cluster.k8s.io/v1alpha1", input.TagSpecifications)
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:   aws.String("two"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+							},
+						},
+					}, nil)
+				m.WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "with invalid client token annotation",
+			machine: clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"set": "node"},
+					Annotations: map[string]string{v1alpha1.AnnotationClientToken: strings.Repeat("a", 65)},
+				},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				AMI: v1alpha1.AWSResourceReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+			},
+			clusterStatus: &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {
+							ID: "1",
+						},
+						v1alpha1.SecurityGroupNode: {
+							ID: "2",
+						},
+						v1alpha1.SecurityGroupLB: {
+							ID: "3",
+						},
+					},
+					APIServerELB: v1alpha1.ClassicELB{
+						DNSName: "test-apiserver.us-east-1.aws",
+					},
+				},
+			},
+			clusterConfig: &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{
+							ID:       "subnet-1",
+							IsPublic: false,
+						},
+						&v1alpha1.SubnetSpec{
+							IsPublic: false,
+						},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			},
+			cluster: clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test1",
+				},
+				Spec: clusterv1.ClusterSpec{
+					ClusterNetwork: clusterv1.ClusterNetworkingConfig{
+						ServiceDomain: "cluster.local",
+						Services: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+						Pods: clusterv1.NetworkRanges{
+							CIDRBlocks: []string{"192.168.0.0/16"},
+						},
+					},
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.
+					DescribeImages(gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name: aws.String("ami-1"),
+							},
+						},
+					}, nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err == nil {
+					t.Fatal("expected error for a client token longer than 64 characters")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			// defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &tc.cluster,
+				Machine: &tc.machine,
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.Scope.ClusterConfig = tc.clusterConfig
+			scope.Scope.ClusterStatus = tc.clusterStatus
+			scope.MachineConfig = tc.machineConfig
+			tc.expect(ec2Mock.EXPECT())
+			ec2Mock.EXPECT().
+				DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+				Return(&ec2.DescribeKeyPairsOutput{}, nil).
+				AnyTimes()
+
+			s := NewService(scope.Scope)
+			instance, err := s.createInstance(scope, "token")
+			tc.check(instance, err)
+		})
+	}
+}
+
+func TestCreateOrGetMachineIdempotent(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+		},
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "machine-1",
+				Namespace: "default",
+				UID:       "8a5c1f5e-0d2b-4c3e-9b8e-5a9a2a4d7c11",
+				Labels:    map[string]string{"set": "node"},
+			},
+		},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	scope.Scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+		NetworkSpec: v1alpha1.NetworkSpec{
+			Subnets: v1alpha1.Subnets{
+				&v1alpha1.SubnetSpec{ID: "subnet-1"},
+			},
+		},
+		CAKeyPair: v1alpha1.KeyPair{
+			Cert: testCaCert,
+			Key:  []byte("y"),
+		},
+	}
+	scope.Scope.ClusterStatus = &v1alpha1.AWSClusterProviderStatus{
+		Network: v1alpha1.Network{
+			SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "1"},
+				v1alpha1.SecurityGroupNode:         {ID: "2"},
+				v1alpha1.SecurityGroupLB:           {ID: "3"},
+			},
+			APIServerELB: v1alpha1.ClassicELB{DNSName: "test-apiserver.us-east-1.aws"},
+		},
+	}
+	scope.MachineConfig = &v1alpha1.AWSMachineProviderSpec{
+		AMI:          v1alpha1.AWSResourceReference{ID: aws.String("ami-1")},
+		InstanceType: "m5.large",
+	}
+
+	// Emulate RunInstances idempotency: launches with a known client token
+	// return the instance that was launched with it originally.
+	launched := map[string]*ec2.Instance{}
+	ec2Mock.EXPECT().
+		RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+		DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+			token := aws.StringValue(input.ClientToken)
+			if token == "" {
+				t.Fatal("expected a client token")
+			}
+			if _, ok := launched[token]; !ok {
+				launched[token] = &ec2.Instance{
+					InstanceId:   aws.String(fmt.Sprintf("i-%d", len(launched))),
+					InstanceType: input.InstanceType,
+					SubnetId:     input.SubnetId,
+					ImageId:      input.ImageId,
+					State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+				}
+			}
+			return &ec2.Reservation{Instances: []*ec2.Instance{launched[token]}}, nil
+		}).
+		Times(2)
+	ec2Mock.EXPECT().
+		WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+	ec2Mock.EXPECT().
+		DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+		Return(&ec2.DescribeKeyPairsOutput{}, nil).
+		Times(2)
+	// The instance isn't visible by tags yet on the second attempt.
+	ec2Mock.EXPECT().
+		DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	s := NewService(scope.Scope)
+	first, err := s.CreateOrGetMachine(scope, "token")
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	// The instance ID was never persisted to the machine status.
+	second, err := s.CreateOrGetMachine(scope, "token")
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	if len(launched) != 1 {
+		t.Fatalf("expected 1 instance to be launched, got %d", len(launched))
+	}
+	if first.ID != second.ID {
+		t.Fatalf("expected the same instance to be returned, got %q and %q", first.ID, second.ID)
+	}
+}
+
+func TestCreateOrGetMachineRelaunch(t *testing.T) {
+	testCases := []struct {
+		name string
+		// relaunch launches the machine's instance again after the first
+		// launch, returning the error of the last attempt.
+		relaunch func(s *Service, scope *actuators.MachineScope) (*v1alpha1.Instance, error)
+	}{
+		{
+			name: "terminated instance is relaunched",
+			relaunch: func(s *Service, scope *actuators.MachineScope) (*v1alpha1.Instance, error) {
+				// The machine actuator clears the terminated instance from the status.
+				scope.MachineStatus.InstanceID = nil
+				scope.MachineStatus.LaunchGeneration++
+				return s.CreateOrGetMachine(scope, "token-1")
+			},
+		},
+		{
+			name: "create is retried with new user data",
+			relaunch: func(s *Service, scope *actuators.MachineScope) (*v1alpha1.Instance, error) {
+				// The instance ID was never persisted to the machine status.
+				_, err := s.CreateOrGetMachine(scope, "token-2")
+				if code, _ := awserrors.Code(errors.Cause(err)); code != awserrors.IdempotentParameterMismatch {
+					t.Fatalf("expected an idempotent parameter mismatch, got %v", err)
+				}
+				if scope.MachineStatus.LaunchGeneration != 1 {
+					t.Fatalf("expected launch generation 1, got %d", scope.MachineStatus.LaunchGeneration)
+				}
+				return s.CreateOrGetMachine(scope, "token-2")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "machine-1",
+						Namespace: "default",
+						UID:       "8a5c1f5e-0d2b-4c3e-9b8e-5a9a2a4d7c11",
+						Labels:    map[string]string{"set": "node"},
+					},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.Scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						&v1alpha1.SubnetSpec{ID: "subnet-1"},
+					},
+				},
+				CAKeyPair: v1alpha1.KeyPair{
+					Cert: testCaCert,
+					Key:  []byte("y"),
+				},
+			}
+			scope.Scope.ClusterStatus = &v1alpha1.AWSClusterProviderStatus{
+				Network: v1alpha1.Network{
+					SecurityGroups: map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+						v1alpha1.SecurityGroupControlPlane: {ID: "1"},
+						v1alpha1.SecurityGroupNode:         {ID: "2"},
+						v1alpha1.SecurityGroupLB:           {ID: "3"},
+					},
+					APIServerELB: v1alpha1.ClassicELB{DNSName: "test-apiserver.us-east-1.aws"},
+				},
+			}
+			scope.MachineConfig = &v1alpha1.AWSMachineProviderSpec{
+				AMI:          v1alpha1.AWSResourceReference{ID: aws.String("ami-1")},
+				InstanceType: "m5.large",
+			}
+
+			// Emulate RunInstances idempotency: launches with a known client token
+			// return the instance that was launched with it originally, unless
+			// their parameters differ.
+			type launch struct {
+				instance *ec2.Instance
+				userData string
+			}
+			launched := map[string]launch{}
+			ec2Mock.EXPECT().
+				RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+				DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					token := aws.StringValue(input.ClientToken)
+					if l, ok := launched[token]; ok {
+						if l.userData != aws.StringValue(input.UserData) {
+							return nil, awserr.New(awserrors.IdempotentParameterMismatch, "client token reused with other parameters", nil)
+						}
+						return &ec2.Reservation{Instances: []*ec2.Instance{l.instance}}, nil
+					}
+					launched[token] = launch{
+						instance: &ec2.Instance{
+							InstanceId:   aws.String(fmt.Sprintf("i-%d", len(launched))),
+							InstanceType: input.InstanceType,
+							SubnetId:     input.SubnetId,
+							ImageId:      input.ImageId,
+							State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+						},
+						userData: aws.StringValue(input.UserData),
+					}
+					return &ec2.Reservation{Instances: []*ec2.Instance{launched[token].instance}}, nil
+				}).
+				AnyTimes()
+			ec2Mock.EXPECT().
+				WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil).
+				AnyTimes()
+			ec2Mock.EXPECT().
+				DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+				Return(&ec2.DescribeKeyPairsOutput{}, nil).
+				AnyTimes()
+			// The previous instance is gone, or isn't visible by tags yet.
+			ec2Mock.EXPECT().
+				DescribeInstancesPages(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{}), gomock.Any()).
+				Return(nil).
+				AnyTimes()
+
+			s := NewService(scope.Scope)
+			first, err := s.CreateOrGetMachine(scope, "token-1")
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			second, err := tc.relaunch(s, scope)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if len(launched) != 2 {
+				t.Fatalf("expected 2 instances to be launched, got %d", len(launched))
+			}
+			if first.ID == second.ID {
+				t.Fatalf("expected a new instance to be launched, got %q again", second.ID)
+			}
+		})
+	}
+}
+
+func TestCreateOrGetMachineImport(t *testing.T) {
+	describeInstance := func(m *mock_ec2iface.MockEC2APIMockRecorder, tags map[string]string) {
+		m.DescribeInstances(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{})).
+			Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{
+						Instances: []*ec2.Instance{
+							{
+								InstanceId:   aws.String("i-import"),
+								InstanceType: aws.String("m5.large"),
+								SubnetId:     aws.String("subnet-1"),
+								ImageId:      aws.String("ami-1"),
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNameRunning),
+								},
+								Tags: converters.MapToTags(tags),
+							},
+						},
+					},
+				},
+			}, nil)
+	}
+
+	testCases := []struct {
+		name   string
+		expect func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		check  func(instance *v1alpha1.Instance, err error)
+	}{
+		{
+			name: "unowned instance is imported",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, map[string]string{"Name": "legacy"})
+				m.CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Do(func(input *ec2.CreateTagsInput) {
+						if len(input.Resources) != 1 || aws.StringValue(input.Resources[0]) != "i-import" {
+							t.Fatalf("expected tags to be created on i-import, got %v", input.Resources)
+						}
+						tags := v1alpha1.Tags(converters.TagsToMap(input.Tags))
+						if !tags.HasOwned("test1") || tags["Name"] != "machine-1" {
+							t.Fatalf("expected the instance to be tagged as owned by the machine, got %v", tags)
+						}
+					}).
+					Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.ID != "i-import" {
+					t.Fatalf("expected instance i-import, got %q", instance.ID)
+				}
+				if !v1alpha1.Tags(instance.Tags).HasOwned("test1") {
+					t.Fatalf("expected the imported instance to be owned, got tags %v", instance.Tags)
+				}
+			},
+		},
+		{
+			name: "previously imported instance is returned",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, map[string]string{
+					"Name":                          "machine-1",
+					v1alpha1.ClusterTagKey("test1"): string(v1alpha1.ResourceLifecycleOwned),
+				})
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if instance.ID != "i-import" {
+					t.Fatalf("expected instance i-import, got %q", instance.ID)
+				}
+			},
+		},
+		{
+			name: "instance owned by another cluster is rejected",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				describeInstance(m, map[string]string{
+					v1alpha1.ClusterAWSCloudProviderTagKey("other"): string(v1alpha1.ResourceLifecycleOwned),
+				})
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err == nil {
+					t.Fatal("expected an error for an instance owned by another cluster")
+				}
+			},
+		},
+		{
+			name: "missing instance is rejected",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeInstances(gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{})).
+					Return(&ec2.DescribeInstancesOutput{}, nil)
+			},
+			check: func(instance *v1alpha1.Instance, err error) {
+				if err == nil {
+					t.Fatal("expected an error for a missing instance")
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "machine-1",
+						Labels:      map[string]string{"set": "node"},
+						Annotations: map[string]string{v1alpha1.AnnotationInstanceID: "i-import"},
+					},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.Scope.ClusterConfig.NetworkSpec.VPC.ID = "test-vpc"
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope.Scope)
+			instance, err := s.CreateOrGetMachine(scope, "token")
+			tc.check(instance, err)
+		})
+	}
+}
+
+func TestResolveSubnet(t *testing.T) {
+	testCases := []struct {
+		name           string
+		subnets        v1alpha1.Subnets
+		machineConfig  *v1alpha1.AWSMachineProviderSpec
+		machineStatus  *v1alpha1.AWSMachineProviderStatus
+		expectSubnet   string
+		expectMessages []string
+	}{
+		{
+			name: "subnet id from the machine configuration",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				Subnet: &v1alpha1.AWSResourceReference{ID: aws.String("subnet-machine")},
+			},
+			expectSubnet: "subnet-machine",
+		},
+		{
+			name: "subnet spread by the machine name",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				Subnets: []v1alpha1.AWSResourceReference{
+					{ID: aws.String("subnet-a")},
+					{ID: aws.String("subnet-b")},
+					{ID: aws.String("subnet-c")},
+				},
+			},
+			expectSubnet: "subnet-b",
+		},
+		{
+			name: "subnet recorded in the machine status",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				Subnets: []v1alpha1.AWSResourceReference{
+					{ID: aws.String("subnet-a")},
+					{ID: aws.String("subnet-b")},
+					{ID: aws.String("subnet-c")},
+				},
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{SubnetID: aws.String("subnet-c")},
+			expectSubnet:  "subnet-c",
+		},
+		{
+			name: "subnet recorded in the machine status is no longer listed",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{
+				Subnets: []v1alpha1.AWSResourceReference{
+					{ID: aws.String("subnet-a")},
+					{ID: aws.String("subnet-b")},
+				},
+			},
+			machineStatus: &v1alpha1.AWSMachineProviderStatus{SubnetID: aws.String("subnet-c")},
+			expectSubnet:  "subnet-a",
+		},
+		{
+			name: "first private subnet in the availability zone",
+			subnets: v1alpha1.Subnets{
+				{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-b", AvailabilityZone: "us-east-1b"},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{AvailabilityZone: aws.String("us-east-1b")},
+			expectSubnet:  "subnet-b",
+		},
+		{
+			name: "local zone subnets are skipped without an availability zone",
+			subnets: v1alpha1.Subnets{
+				{ID: "subnet-lz", AvailabilityZone: "us-east-1-bos-1a"},
+				{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{},
+			expectSubnet:  "subnet-a",
+		},
+		{
+			name: "local zone subnet in the availability zone",
+			subnets: v1alpha1.Subnets{
+				{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+				{ID: "subnet-lz", AvailabilityZone: "us-east-1-bos-1a"},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{AvailabilityZone: aws.String("us-east-1-bos-1a")},
+			expectSubnet:  "subnet-lz",
+		},
+		{
+			name:          "cluster without subnets",
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{},
+			expectMessages: []string{
+				`machine "machine-1"`,
+				`cluster "test1"`,
+				`vpc "test-vpc"`,
+				"the cluster has no subnets",
+			},
+		},
+		{
+			name: "cluster with only public subnets",
+			subnets: v1alpha1.Subnets{
+				{ID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{},
+			expectMessages: []string{
+				"subnet-public (public, us-east-1a)",
+			},
+		},
+		{
+			name: "no private subnets in the availability zone",
+			subnets: v1alpha1.Subnets{
+				{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+			},
+			machineConfig: &v1alpha1.AWSMachineProviderSpec{AvailabilityZone: aws.String("us-east-1b")},
+			expectMessages: []string{
+				`in availability zone "us-east-1b"`,
+				"subnet-a (private, us-east-1a)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := actuators.NewMachineScope(actuators.MachineScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine-1"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: mock_ec2iface.NewMockEC2API(gomock.NewController(t)),
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.Scope.ClusterConfig.Region = "us-east-1"
+			scope.Scope.ClusterConfig.NetworkSpec.VPC.ID = "test-vpc"
+			scope.Scope.ClusterConfig.NetworkSpec.Subnets = tc.subnets
+			scope.MachineConfig = tc.machineConfig
+			if tc.machineStatus != nil {
+				scope.MachineStatus = tc.machineStatus
+			}
+
+			s := NewService(scope.Scope)
+			subnet, err := s.resolveSubnet(scope)
+			if tc.expectMessages == nil {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if subnet != tc.expectSubnet {
+					t.Fatalf("expected subnet %q, got %q", tc.expectSubnet, subnet)
+				}
+				return
+			}
+
+			if _, ok := err.(*NoSubnetsError); !ok {
+				t.Fatalf("expected a NoSubnetsError, got %v", err)
+			}
+			for _, msg := range tc.expectMessages {
+				if !strings.Contains(err.Error(), msg) {
+					t.Errorf("expected error %q to contain %q", err.Error(), msg)
+				}
+			}
+		})
+	}
+}
+
+func Test_nodeNameLookup(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      v1alpha1.NodeNameSource
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "default",
+			expected: "{{ ds.meta_data.hostname }}",
+		},
+		{
+			name:     "private dns",
+			source:   v1alpha1.NodeNameSourcePrivateDNS,
+			expected: "{{ ds.meta_data.hostname }}",
+		},
+		{
+			name:     "instance id",
+			source:   v1alpha1.NodeNameSourceInstanceID,
+			expected: "{{ ds.meta_data.instance_id }}",
+		},
+		{
+			name:        "unknown",
+			source:      v1alpha1.NodeNameSource("FQDN"),
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := nodeNameLookup(tt.source)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if actual != tt.expected {
+				t.Fatalf("Expected: %q but was: %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_setInitConfigurationOptions(t *testing.T) {
+	type args struct {
+		initConfig     kubeadmv1beta1.InitConfiguration
+		machine        *clusterv1.Machine
+		nodeNameSource v1alpha1.NodeNameSource
+		bindPort       int
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected kubeadmv1beta1.InitConfiguration
+	}{
+		{
+			name: "simple",
+			args: args{
+				initConfig: kubeadmv1beta1.InitConfiguration{},
+				machine:    &clusterv1.Machine{},
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 6443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+			},
+		},
+		{
+			name: "with instance id node name",
+			args: args{
+				initConfig:     kubeadmv1beta1.InitConfiguration{},
+				machine:        &clusterv1.Machine{},
+				nodeNameSource: v1alpha1.NodeNameSourceInstanceID,
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 6443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.instance_id }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+			},
+		},
+		{
+			name: "with node taints",
+			args: args{
+				initConfig: kubeadmv1beta1.InitConfiguration{},
+				machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						Taints: []corev1.Taint{{
+							Key:    "my-property",
+							Value:  "special",
+							Effect: corev1.TaintEffectNoSchedule,
+						}},
+					},
+				},
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 6443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+					Taints: []corev1.Taint{{
+						Key:    "my-property",
+						Value:  "special",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
+			},
+		},
+		{
+			name: "with cni options",
+			args: args{
+				initConfig: kubeadmv1beta1.InitConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						KubeletExtraArgs: map[string]string{
+							"cni-bin-dir":  "/opt/cni/bin",
+							"cni-conf-dir": "/etc/cni/net.d",
+						},
+					},
+				},
+				machine: &clusterv1.Machine{},
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 6443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"cni-bin-dir":    "/opt/cni/bin",
+						"cni-conf-dir":   "/etc/cni/net.d",
+					},
+				},
+			},
+		},
+		{
+			name: "with api server port",
+			args: args{
+				initConfig: kubeadmv1beta1.InitConfiguration{},
+				machine:    &clusterv1.Machine{},
+				bindPort:   8443,
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 8443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+			},
+		},
+		{
+			name: "with cri socket",
+			args: args{
+				initConfig: kubeadmv1beta1.InitConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						CRISocket: "/var/run/my-cri.sock",
+					},
+				},
+				machine: &clusterv1.Machine{},
+			},
+			expected: kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: 6443,
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/my-cri.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeName, err := nodeNameLookup(tt.args.nodeNameSource)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			bindPort := tt.args.bindPort
+			if bindPort == 0 {
+				bindPort = 6443
+			}
+			setInitConfigurationOptions(&tt.args.initConfig, tt.args.machine, nodeName, bindPort)
+
+			actual := tt.args.initConfig
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_setNodeJoinConfigurationOptions(t *testing.T) {
+	type args struct {
+		joinConfig        kubeadmv1beta1.JoinConfiguration
+		machine           *clusterv1.Machine
+		nodeNameSource    v1alpha1.NodeNameSource
+		apiServerEndpoint string
+		bootstrapToken    string
+		caCertHash        string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected kubeadmv1beta1.JoinConfiguration
+	}{
+		{
+			name: "simple",
+			args: args{
+				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"node-labels":    "node-role.kubernetes.io/node=",
+					},
+				},
+			},
+		},
+		{
+			name: "with instance id node name",
+			args: args{
+				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
+				machine:           &clusterv1.Machine{},
+				nodeNameSource:    v1alpha1.NodeNameSourceInstanceID,
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.instance_id }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"node-labels":    "node-role.kubernetes.io/node=",
+					},
+				},
+			},
+		},
+		{
+			name: "with taints",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{},
+				machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						Taints: []corev1.Taint{{
+							Key:    "my-property",
+							Value:  "special",
+							Effect: corev1.TaintEffectNoSchedule,
+						}},
+					},
+				},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"node-labels":    "node-role.kubernetes.io/node=",
+					},
+					Taints: []corev1.Taint{{
+						Key:    "my-property",
+						Value:  "special",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
+			},
+		},
+		{
+			name: "with cni options",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						KubeletExtraArgs: map[string]string{
+							"cni-bin-dir":  "/opt/cni/bin",
+							"cni-conf-dir": "/etc/cni/net.d",
+						},
+					},
+				},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"cni-bin-dir":    "/opt/cni/bin",
+						"cni-conf-dir":   "/etc/cni/net.d",
+						"node-labels":    "node-role.kubernetes.io/node=",
+					},
+				},
+			},
+		},
+		{
+			name: "with cri socket",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						CRISocket: "/var/run/my-cri.sock",
+					},
+				},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/my-cri.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"node-labels":    "node-role.kubernetes.io/node=",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeName, err := nodeNameLookup(tt.args.nodeNameSource)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			setNodeJoinConfigurationOptions(&tt.args.joinConfig, tt.args.machine, nodeName, tt.args.apiServerEndpoint, tt.args.bootstrapToken, tt.args.caCertHash)
+
+			actual := tt.args.joinConfig
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_setControlPlaneJoinConfigurationOptions(t *testing.T) {
+	type args struct {
+		joinConfig        kubeadmv1beta1.JoinConfiguration
+		machine           *clusterv1.Machine
+		nodeNameSource    v1alpha1.NodeNameSource
+		apiServerEndpoint string
+		bootstrapToken    string
+		caCertHash        string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected kubeadmv1beta1.JoinConfiguration
+	}{
+		{
+			name: "simple",
+			args: args{
+				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
+					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
+						BindPort:         6443,
+					},
+				},
+			},
+		},
+		{
+			name: "with instance id node name",
+			args: args{
+				joinConfig:        kubeadmv1beta1.JoinConfiguration{},
+				machine:           &clusterv1.Machine{},
+				nodeNameSource:    v1alpha1.NodeNameSourceInstanceID,
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.instance_id }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
+					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
+						BindPort:         6443,
+					},
+				},
+			},
+		},
+		{
+			name: "with taints",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{},
+				machine: &clusterv1.Machine{
+					Spec: clusterv1.MachineSpec{
+						Taints: []corev1.Taint{{
+							Key:    "my-property",
+							Value:  "special",
+							Effect: corev1.TaintEffectNoSchedule,
+						}},
+					},
+				},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+					Taints: []corev1.Taint{{
+						Key:    "my-property",
+						Value:  "special",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
+				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
+					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
+						BindPort:         6443,
+					},
+				},
+			},
+		},
+		{
+			name: "with cni options",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						KubeletExtraArgs: map[string]string{
+							"cni-bin-dir":  "/opt/cni/bin",
+							"cni-conf-dir": "/etc/cni/net.d",
+						},
+					},
+				},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/containerd/containerd.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+						"cni-bin-dir":    "/opt/cni/bin",
+						"cni-conf-dir":   "/etc/cni/net.d",
+					},
+				},
+				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
+					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
+						BindPort:         6443,
+					},
+				},
+			},
+		},
+		{
+			name: "with cri socket",
+			args: args{
+				joinConfig: kubeadmv1beta1.JoinConfiguration{
+					NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+						CRISocket: "/var/run/my-cri.sock",
+					},
+				},
+				machine:           &clusterv1.Machine{},
+				apiServerEndpoint: "https://api-server:6443",
+				bootstrapToken:    "abcdef.1234567890abcdef",
+				caCertHash:        "sha256:1234cdef",
+			},
+			expected: kubeadmv1beta1.JoinConfiguration{
+				Discovery: kubeadmv1beta1.Discovery{
+					BootstrapToken: &kubeadmv1beta1.BootstrapTokenDiscovery{
+						Token: "abcdef.1234567890abcdef",
+						CACertHashes: []string{
+							"sha256:1234cdef",
+						},
+						APIServerEndpoint: "https://api-server:6443",
+					},
+				},
+				NodeRegistration: kubeadmv1beta1.NodeRegistrationOptions{
+					Name:      "{{ ds.meta_data.hostname }}",
+					CRISocket: "/var/run/my-cri.sock",
+					KubeletExtraArgs: map[string]string{
+						"cloud-provider": "aws",
+					},
+				},
+				ControlPlane: &kubeadmv1beta1.JoinControlPlane{
+					LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+						AdvertiseAddress: "{{ ds.meta_data.local_ipv4 }}",
+						BindPort:         6443,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeName, err := nodeNameLookup(tt.args.nodeNameSource)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			setControlPlaneJoinConfigurationOptions(&tt.args.joinConfig, tt.args.machine, nodeName, tt.args.apiServerEndpoint, tt.args.bootstrapToken, tt.args.caCertHash, 6443)
+
+			actual := tt.args.joinConfig
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Fatalf("Expected: \n%+v\nbut was:\n%+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWaitForInstanceRunning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		wait          func(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error
+		expectError   bool
+		expectTimeout bool
+	}{
+		{
+			name: "instance is running",
+			wait: func(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+				if aws.StringValue(input.InstanceIds[0]) != "i-1" {
+					t.Fatalf("expected to wait for instance i-1, got %v", input.InstanceIds)
+				}
+				return nil
+			},
+		},
+		{
+			name: "instance is not running in time",
+			wait: func(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+				<-ctx.Done()
+				return awserr.New(request.CanceledErrorCode, "waiter context canceled", ctx.Err())
+			},
+			expectError:   true,
+			expectTimeout: true,
+		},
+		{
+			name: "instance fails to run",
+			wait: func(ctx aws.Context, input *ec2.DescribeInstancesInput, opts ...request.WaiterOption) error {
+				return awserr.New(request.WaiterResourceNotReadyErrorCode, "failed waiting for successful resource state", nil)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().
+				WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(tc.wait)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			err = NewService(scope).WaitForInstanceRunning("i-1", 10*time.Millisecond)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if awserrors.IsTimeout(err) != tc.expectTimeout {
+				t.Fatalf("expected timeout error to be %v, got %v", tc.expectTimeout, err)
+			}
+		})
+	}
+}
+
+func TestRootBlockDeviceMapping(t *testing.T) {
+	const (
+		imageKey = "arn:aws:kms:us-east-1:123456789012:key/image"
+		otherKey = "arn:aws:kms:us-east-1:123456789012:key/other"
+	)
+
+	encryptedImage := &ec2.BlockDeviceMapping{
+		DeviceName: aws.String("/dev/xvda"),
+		Ebs: &ec2.EbsBlockDevice{
+			SnapshotId: aws.String("snap-1"),
+			Encrypted:  aws.Bool(true),
+			KmsKeyId:   aws.String(imageKey),
+		},
+	}
+	unencryptedImage := &ec2.BlockDeviceMapping{
+		DeviceName: aws.String("/dev/xvda"),
+		Ebs: &ec2.EbsBlockDevice{
+			SnapshotId: aws.String("snap-1"),
+			Encrypted:  aws.Bool(false),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		instance        v1alpha1.Instance
+		imageRoot       *ec2.BlockDeviceMapping
+		expectEncrypted *bool
+		expectKey       *string
+		expectError     bool
+	}{
+		{
+			name:      "unencrypted image",
+			instance:  v1alpha1.Instance{RootDeviceSize: 50},
+			imageRoot: unencryptedImage,
+		},
+		{
+			name:      "image without a root mapping",
+			instance:  v1alpha1.Instance{RootDeviceSize: 50},
+			imageRoot: &ec2.BlockDeviceMapping{DeviceName: aws.String("/dev/xvda")},
+		},
+		{
+			name:            "encryption and key are inherited from the image",
+			instance:        v1alpha1.Instance{RootDeviceSize: 50},
+			imageRoot:       encryptedImage,
+			expectEncrypted: aws.Bool(true),
+			expectKey:       aws.String(imageKey),
+		},
+		{
+			name:            "explicit encryption of an encrypted image keeps its key",
+			instance:        v1alpha1.Instance{RootDeviceEncrypted: aws.Bool(true)},
+			imageRoot:       encryptedImage,
+			expectEncrypted: aws.Bool(true),
+			expectKey:       aws.String(imageKey),
+		},
+		{
+			name:            "key of an encrypted image is overridden",
+			instance:        v1alpha1.Instance{RootDeviceKMSKeyID: aws.String(otherKey)},
+			imageRoot:       encryptedImage,
+			expectEncrypted: aws.Bool(true),
+			expectKey:       aws.String(otherKey),
+		},
+		{
+			name:            "unencrypted image is encrypted with the default key",
+			instance:        v1alpha1.Instance{RootDeviceEncrypted: aws.Bool(true)},
+			imageRoot:       unencryptedImage,
+			expectEncrypted: aws.Bool(true),
+		},
+		{
+			name:            "unencrypted image is encrypted with a key",
+			instance:        v1alpha1.Instance{RootDeviceKMSKeyID: aws.String(otherKey)},
+			imageRoot:       unencryptedImage,
+			expectEncrypted: aws.Bool(true),
+			expectKey:       aws.String(otherKey),
+		},
+		{
+			name:        "encrypted image can't be decrypted",
+			instance:    v1alpha1.Instance{RootDeviceEncrypted: aws.Bool(false)},
+			imageRoot:   encryptedImage,
+			expectError: true,
+		},
+		{
+			name:        "key for an unencrypted volume",
+			instance:    v1alpha1.Instance{RootDeviceEncrypted: aws.Bool(false), RootDeviceKMSKeyID: aws.String(otherKey)},
+			imageRoot:   unencryptedImage,
+			expectError: true,
+		},
+		{
+			name:        "image without a root device name",
+			instance:    v1alpha1.Instance{RootDeviceSize: 50},
+			imageRoot:   &ec2.BlockDeviceMapping{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bdm, err := rootBlockDeviceMapping(&tc.instance, tc.imageRoot)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if aws.StringValue(bdm.DeviceName) != "/dev/xvda" {
+				t.Fatalf("expected the root device of the image, got %v", bdm.DeviceName)
+			}
+			if !reflect.DeepEqual(bdm.Ebs.Encrypted, tc.expectEncrypted) {
+				t.Fatalf("expected encrypted %v, got %v", aws.BoolValue(tc.expectEncrypted), aws.BoolValue(bdm.Ebs.Encrypted))
+			}
+			if !reflect.DeepEqual(bdm.Ebs.KmsKeyId, tc.expectKey) {
+				t.Fatalf("expected KMS key %q, got %q", aws.StringValue(tc.expectKey), aws.StringValue(bdm.Ebs.KmsKeyId))
+			}
+		})
+	}
+}
+
+func TestValidateShutdownBehavior(t *testing.T) {
+	testCases := []struct {
+		name        string
+		behavior    *string
+		expectError bool
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:     "stop",
+			behavior: aws.String("stop"),
+		},
+		{
+			name:     "terminate",
+			behavior: aws.String("terminate"),
+		},
+		{
+			name:        "invalid",
+			behavior:    aws.String("hibernate"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateShutdownBehavior(tc.behavior)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHibernation(t *testing.T) {
+	describeImage := func(encrypted bool) func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+		return func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+			m.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String("ami-1")}}).
+				Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{
+						{
+							RootDeviceName: aws.String("/dev/xvda"),
+							BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+								{
+									DeviceName: aws.String("/dev/xvda"),
+									Ebs:        &ec2.EbsBlockDevice{Encrypted: aws.Bool(encrypted)},
+								},
+							},
+						},
+					},
+				}, nil)
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		instance    v1alpha1.Instance
+		expect      func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:     "root volume encryption is set",
+			instance: v1alpha1.Instance{ImageID: "ami-1", RootDeviceEncrypted: aws.Bool(true)},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:     "root volume KMS key is set",
+			instance: v1alpha1.Instance{ImageID: "ami-1", RootDeviceKMSKeyID: aws.String("key")},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:     "image is encrypted",
+			instance: v1alpha1.Instance{ImageID: "ami-1"},
+			expect:   describeImage(true),
+		},
+		{
+			name:        "image is not encrypted",
+			instance:    v1alpha1.Instance{ImageID: "ami-1"},
+			expect:      describeImage(false),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			tc.expect(ec2Mock.EXPECT())
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			err = NewService(scope).validateHibernation(&tc.instance)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateEphemeralVolumes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		volumes     []v1alpha1.EphemeralVolume
+		expectError bool
+	}{
+		{
+			name: "valid ephemeral volumes",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"},
+				{VirtualName: "ephemeral23", DeviceName: "/dev/sdc"},
+			},
+		},
+		{
+			name:        "invalid virtual name",
+			volumes:     []v1alpha1.EphemeralVolume{{VirtualName: "ephemeral24", DeviceName: "/dev/sdb"}},
+			expectError: true,
+		},
+		{
+			name:    "device name to allocate",
+			volumes: []v1alpha1.EphemeralVolume{{VirtualName: "ephemeral0"}},
+		},
+		{
+			name: "duplicate virtual name",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"},
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdc"},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate device name",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"},
+				{VirtualName: "ephemeral1", DeviceName: "/dev/sdb"},
+			},
+			expectError: true,
+		},
+		{
+			name: "device names of the same device",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"},
+				{VirtualName: "ephemeral1", DeviceName: "/dev/xvdb"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEphemeralVolumes(tc.volumes)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestEphemeralBlockDeviceMappings(t *testing.T) {
+	image := &ec2.Image{
+		ImageId:        aws.String("ami-1"),
+		RootDeviceName: aws.String("/dev/sda1"),
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{DeviceName: aws.String("/dev/sda1")},
+			{DeviceName: aws.String("/dev/xvdb"), VirtualName: aws.String("ephemeral0")},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		volumes       []v1alpha1.EphemeralVolume
+		expectDevices []string
+		expectError   bool
+	}{
+		{
+			name: "no ephemeral volumes",
+		},
+		{
+			name: "device names are kept",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0", DeviceName: "/dev/sdd"},
+				{VirtualName: "ephemeral1", DeviceName: "/dev/sdc"},
+			},
+			expectDevices: []string{"/dev/sdd", "/dev/sdc"},
+		},
+		{
+			name: "device names are allocated in order around the image devices",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0"},
+				{VirtualName: "ephemeral1"},
+				{VirtualName: "ephemeral2"},
+			},
+			expectDevices: []string{"/dev/sdc", "/dev/sdd", "/dev/sde"},
+		},
+		{
+			name: "device names are allocated around the devices set",
+			volumes: []v1alpha1.EphemeralVolume{
+				{VirtualName: "ephemeral0"},
+				{VirtualName: "ephemeral1", DeviceName: "/dev/sdc"},
+				{VirtualName: "ephemeral2"},
+			},
+			expectDevices: []string{"/dev/sdd", "/dev/sdc", "/dev/sde"},
+		},
+		{
+			name:        "device name collides with the root device",
+			volumes:     []v1alpha1.EphemeralVolume{{VirtualName: "ephemeral0", DeviceName: "/dev/xvda"}},
+			expectError: true,
+		},
+		{
+			name:        "device name collides with an image device",
+			volumes:     []v1alpha1.EphemeralVolume{{VirtualName: "ephemeral1", DeviceName: "/dev/sdb"}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mappings, err := ephemeralBlockDeviceMappings(tc.volumes, image)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+
+			if len(mappings) != len(tc.expectDevices) {
+				t.Fatalf("expected %d block device mappings, got %v", len(tc.expectDevices), mappings)
+			}
+			for i, bdm := range mappings {
+				if aws.StringValue(bdm.DeviceName) != tc.expectDevices[i] || aws.StringValue(bdm.VirtualName) != tc.volumes[i].VirtualName {
+					t.Fatalf("expected %s mapped to %s, got %v", tc.volumes[i].VirtualName, tc.expectDevices[i], bdm)
+				}
+			}
+		})
+	}
+}
+
+func TestRunInstanceTagSpecifications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	tags := v1alpha1.Tags{
+		"Name":                          "machine-1",
+		v1alpha1.ClusterTagKey("test1"): "owned",
+		v1alpha1.ClusterAWSCloudProviderTagKey("test1"): "owned",
+		v1alpha1.NameAWSClusterAPIRole:                  "node",
+		v1alpha1.NameAWSProviderSpecHash:                "hash",
+	}
+	attached := v1alpha1.Tags{
+		"Name":                          "machine-1",
+		v1alpha1.ClusterTagKey("test1"): "owned",
+		v1alpha1.ClusterAWSCloudProviderTagKey("test1"): "owned",
+		v1alpha1.NameAWSClusterAPIRole:                  "node",
+	}
+	expect := map[string]v1alpha1.Tags{
+		ec2.ResourceTypeInstance:         tags,
+		ec2.ResourceTypeVolume:           attached,
+		ec2.ResourceTypeNetworkInterface: attached,
+	}
+
+	ec2Mock.EXPECT().
+		RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+		DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+			specs := map[string]v1alpha1.Tags{}
+			for _, spec := range input.TagSpecifications {
+				specs[aws.StringValue(spec.ResourceType)] = converters.TagsToMap(spec.Tags)
+			}
+			if !reflect.DeepEqual(specs, expect) {
+				t.Fatalf("expected tag specifications %v, got %v", expect, specs)
+			}
+			return &ec2.Reservation{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId:   aws.String("i-1"),
+						InstanceType: aws.String("m5.large"),
+						ImageId:      aws.String("ami-1"),
+						State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+					},
+				},
+			}, nil
+		})
+	ec2Mock.EXPECT().
+		WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s := NewService(scope)
+	if _, err := s.runInstance("node", &v1alpha1.Instance{
+		Type:    "m5.large",
+		ImageID: "ami-1",
+		Tags:    tags,
+	}, "", false, time.Minute); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+func TestRunInstanceRootDeviceName(t *testing.T) {
+	testCases := []struct {
+		name       string
+		rootDevice string
+	}{
+		{
+			name:       "xvda root device",
+			rootDevice: "/dev/xvda",
+		},
+		{
+			name:       "sda1 root device",
+			rootDevice: "/dev/sda1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			ec2Mock.EXPECT().
+				DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String("ami-1")}}).
+				Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{
+						{
+							ImageId:        aws.String("ami-1"),
+							RootDeviceName: aws.String(tc.rootDevice),
+							BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+								{
+									DeviceName: aws.String(tc.rootDevice),
+									Ebs:        &ec2.EbsBlockDevice{SnapshotId: aws.String("snap-1"), VolumeSize: aws.Int64(8)},
+								},
+							},
+						},
+					},
+				}, nil)
+			ec2Mock.EXPECT().
+				RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+				DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					if len(input.BlockDeviceMappings) != 1 {
+						t.Fatalf("expected a single block device mapping, got %v", input.BlockDeviceMappings)
+					}
+					bdm := input.BlockDeviceMappings[0]
+					if aws.StringValue(bdm.DeviceName) != tc.rootDevice || aws.Int64Value(bdm.Ebs.VolumeSize) != 100 {
+						t.Fatalf("expected the root volume size to apply to %s, got %v", tc.rootDevice, bdm)
+					}
+					return &ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								InstanceId:   aws.String("i-1"),
+								InstanceType: aws.String("m5.large"),
+								ImageId:      aws.String("ami-1"),
+								State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+							},
+						},
+					}, nil
+				})
+			ec2Mock.EXPECT().
+				WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil)
+
+			s := NewService(scope)
+			if _, err := s.runInstance("node", &v1alpha1.Instance{
+				Type:           "m5.large",
+				ImageID:        "ami-1",
+				RootDeviceSize: 100,
+			}, "", false, time.Minute); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePublicIP(t *testing.T) {
+	routeTable := func(subnetID string, main bool, routes ...*ec2.Route) *ec2.RouteTable {
+		association := &ec2.RouteTableAssociation{Main: aws.Bool(main)}
+		if subnetID != "" {
+			association.SubnetId = aws.String(subnetID)
+		}
+		return &ec2.RouteTable{
+			RouteTableId: aws.String("rtb-" + subnetID),
+			Associations: []*ec2.RouteTableAssociation{association},
+			Routes:       routes,
+		}
+	}
+	igwRoute := &ec2.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), State: aws.String(ec2.RouteStateActive)}
+	natRoute := &ec2.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: aws.String(ec2.RouteStateActive)}
+
+	testCases := []struct {
+		name        string
+		publicIP    *bool
+		routeTables []*ec2.RouteTable
+		expectError bool
+	}{
+		{
+			name: "public IP not requested",
+		},
+		{
+			name:        "public IP not requested explicitly",
+			publicIP:    aws.Bool(false),
+			routeTables: []*ec2.RouteTable{routeTable("subnet-1", false, natRoute)},
+		},
+		{
+			name:        "subnet routes to an internet gateway",
+			publicIP:    aws.Bool(true),
+			routeTables: []*ec2.RouteTable{routeTable("subnet-1", false, igwRoute)},
+		},
+		{
+			name:     "main route table routes to an internet gateway",
+			publicIP: aws.Bool(true),
+			routeTables: []*ec2.RouteTable{
+				routeTable("", true, igwRoute),
+				routeTable("subnet-2", false, natRoute),
+			},
+		},
+		{
+			name:        "subnet routes through a NAT gateway",
+			publicIP:    aws.Bool(true),
+			routeTables: []*ec2.RouteTable{routeTable("subnet-1", false, natRoute), routeTable("", true, igwRoute)},
+			expectError: true,
+		},
+		{
+			name:     "route to an internet gateway is a blackhole",
+			publicIP: aws.Bool(true),
+			routeTables: []*ec2.RouteTable{routeTable("subnet-1", false, &ec2.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				GatewayId:            aws.String("igw-1"),
+				State:                aws.String(ec2.RouteStateBlackhole),
+			})},
+			expectError: true,
+		},
+		{
+			name:        "no route table",
+			publicIP:    aws.Bool(true),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			if aws.BoolValue(tc.publicIP) {
+				ec2Mock.EXPECT().
+					DescribeRouteTables(gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{RouteTables: tc.routeTables}, nil)
+			}
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{VPC: v1alpha1.VPCSpec{ID: "vpc-1"}},
+			}
+
+			machine := &actuators.MachineScope{
+				Scope:         scope,
+				Machine:       &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-1"}},
+				MachineConfig: &v1alpha1.AWSMachineProviderSpec{PublicIP: tc.publicIP},
+			}
+
+			s := NewService(scope)
+			err = s.validatePublicIP(machine, "subnet-1")
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if !strings.Contains(err.Error(), `subnet "subnet-1" has no route to an internet gateway`) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunInstancePrimaryNetworkInterfaceDeleteOnTermination(t *testing.T) {
+	testCases := []struct {
+		name                string
+		deleteOnTermination *bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:                "deleted on termination",
+			deleteOnTermination: aws.Bool(true),
+		},
+		{
+			name:                "kept on termination",
+			deleteOnTermination: aws.Bool(false),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			ec2Mock.EXPECT().
+				RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+				DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+					if tc.deleteOnTermination == nil {
+						if len(input.NetworkInterfaces) != 0 || aws.StringValue(input.SubnetId) != "subnet-1" {
+							t.Fatalf("expected the instance to be launched in subnet-1 without network interfaces, got %v", input)
+						}
+					} else {
+						if len(input.NetworkInterfaces) != 1 || input.SubnetId != nil || input.SecurityGroupIds != nil {
+							t.Fatalf("expected only the primary network interface, got %v", input)
+						}
+						eni := input.NetworkInterfaces[0]
+						if aws.Int64Value(eni.DeviceIndex) != 0 || aws.StringValue(eni.SubnetId) != "subnet-1" || len(eni.Groups) != 1 {
+							t.Fatalf("expected the primary network interface in subnet-1, got %v", eni)
+						}
+						if !reflect.DeepEqual(eni.DeleteOnTermination, tc.deleteOnTermination) {
+							t.Fatalf("expected delete on termination %v, got %v", aws.BoolValue(tc.deleteOnTermination), aws.BoolValue(eni.DeleteOnTermination))
+						}
+					}
+					return &ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								InstanceId:   aws.String("i-1"),
+								InstanceType: aws.String("m5.large"),
+								ImageId:      aws.String("ami-1"),
+								State:        &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+							},
+						},
+					}, nil
+				})
+			ec2Mock.EXPECT().
+				WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil)
+
+			s := NewService(scope)
+			if _, err := s.runInstance("node", &v1alpha1.Instance{
+				Type:             "m5.large",
+				ImageID:          "ami-1",
+				SubnetID:         "subnet-1",
+				SecurityGroupIDs: []string{"sg-1"},
+
+				PrimaryNetworkInterfaceDeleteOnTermination: tc.deleteOnTermination,
+			}, "", false, time.Minute); err != nil {
+				t.Fatalf("did not expect error: %v", err)
 			}
 		})
 	}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/record"
+)
+
+// KeyPairName returns the name of the SSH key pair installed on a machine's instance.
+func KeyPairName(machine *actuators.MachineScope) string {
+	if machine.MachineConfig.KeyName != "" {
+		return machine.MachineConfig.KeyName
+	}
+	return defaultSSHKeyName
+}
+
+// KeyPairExists returns whether the named SSH key pair exists in the cluster's region.
+func (s *Service) KeyPairExists(name string) (bool, error) {
+	s.scope.V(2).Info("Looking up SSH key pair", "key-name", name)
+
+	_, err := s.scope.EC2.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{
+		KeyNames: []*string{aws.String(name)},
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if code, _ := awserrors.Code(err); code != awserrors.InvalidKeyPairNotFound {
+		return false, errors.Wrapf(err, "failed to describe SSH key pair %q", name)
+	}
+
+	return false, nil
+}
+
+// CreateKeyPair creates the named SSH key pair in the cluster's region and
+// returns its unencrypted PEM encoded private key.
+func (s *Service) CreateKeyPair(name string) (string, error) {
+	out, err := s.scope.EC2.CreateKeyPair(&ec2.CreateKeyPairInput{
+		KeyName: aws.String(name),
+	})
+	if err != nil {
+		record.Warnf(s.scope.Cluster, "FailedCreateKeyPair", "Failed to create SSH key pair %q: %v", name, err)
+		return "", errors.Wrapf(err, "failed to create SSH key pair %q in region %q", name, s.scope.Region())
+	}
+
+	record.Eventf(s.scope.Cluster, "CreatedKeyPair", "Created SSH key pair %q", name)
+	s.scope.V(2).Info("Created SSH key pair", "key-name", name)
+	return aws.StringValue(out.KeyMaterial), nil
+}
+
+// DeleteKeyPair deletes the named SSH key pair from the cluster's region.
+// A key pair that doesn't exist is not an error.
+func (s *Service) DeleteKeyPair(name string) error {
+	_, err := s.scope.EC2.DeleteKeyPair(&ec2.DeleteKeyPairInput{
+		KeyName: aws.String(name),
+	})
+	if err != nil {
+		if code, _ := awserrors.Code(err); code == awserrors.InvalidKeyPairNotFound {
+			return nil
+		}
+		record.Warnf(s.scope.Cluster, "FailedDeleteKeyPair", "Failed to delete SSH key pair %q: %v", name, err)
+		return errors.Wrapf(err, "failed to delete SSH key pair %q in region %q", name, s.scope.Region())
+	}
+
+	record.Eventf(s.scope.Cluster, "DeletedKeyPair", "Deleted SSH key pair %q", name)
+	s.scope.V(2).Info("Deleted SSH key pair", "key-name", name)
+	return nil
+}
+
+// reconcileKeyPair makes sure the named SSH key pair exists in the cluster's region.
+// A missing key pair is imported from the cluster's SSH public key, if one is set.
+func (s *Service) reconcileKeyPair(name string) error {
+	exists, err := s.KeyPairExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if s.scope.ClusterConfig.SSHPublicKey == "" {
+		return errors.Errorf("SSH key pair %q does not exist in region %q", name, s.scope.Region())
+	}
+
+	if _, err := s.scope.EC2.ImportKeyPair(&ec2.ImportKeyPairInput{
+		KeyName:           aws.String(name),
+		PublicKeyMaterial: []byte(s.scope.ClusterConfig.SSHPublicKey),
+	}); err != nil {
+		record.Warnf(s.scope.Cluster, "FailedImportKeyPair", "Failed to import SSH key pair %q: %v", name, err)
+		return errors.Wrapf(err, "failed to import SSH key pair %q in region %q", name, s.scope.Region())
+	}
+
+	record.Eventf(s.scope.Cluster, "ImportedKeyPair", "Imported SSH key pair %q", name)
+	s.scope.V(2).Info("Imported SSH key pair", "key-name", name)
+	return nil
+}
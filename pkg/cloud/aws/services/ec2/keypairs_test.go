@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestReconcileKeyPair(t *testing.T) {
+	notFound := awserr.New("InvalidKeyPair.NotFound", "The key pair 'my-key' does not exist", nil)
+
+	testCases := []struct {
+		name         string
+		sshPublicKey string
+		expect       func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expectError  string
+	}{
+		{
+			name: "existing key pair",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{KeyNames: []*string{aws.String("my-key")}}).
+					Return(&ec2.DescribeKeyPairsOutput{
+						KeyPairs: []*ec2.KeyPairInfo{{KeyName: aws.String("my-key")}},
+					}, nil)
+			},
+		},
+		{
+			name: "missing key pair without a public key",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+					Return(nil, notFound)
+			},
+			expectError: `SSH key pair "my-key" does not exist in region "us-east-1"`,
+		},
+		{
+			name:         "missing key pair is imported from the public key",
+			sshPublicKey: "ssh-rsa AAAA test",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+					Return(nil, notFound)
+				m.ImportKeyPair(&ec2.ImportKeyPairInput{
+					KeyName:           aws.String("my-key"),
+					PublicKeyMaterial: []byte("ssh-rsa AAAA test"),
+				}).
+					Return(&ec2.ImportKeyPairOutput{KeyName: aws.String("my-key")}, nil)
+			},
+		},
+		{
+			name: "describe failure",
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeKeyPairs(gomock.AssignableToTypeOf(&ec2.DescribeKeyPairsInput{})).
+					Return(nil, awserr.New("UnauthorizedOperation", "not allowed", nil))
+			},
+			expectError: `failed to describe SSH key pair "my-key"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				Region:       "us-east-1",
+				SSHPublicKey: tc.sshPublicKey,
+			}
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			err = s.reconcileKeyPair("my-key")
+			if tc.expectError == "" {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+				t.Fatalf("expected error containing %q, got %v", tc.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// UpdateInstanceMetadataOptions changes whether requests to the instance
+// metadata service of the given instance must use session tokens.
+func (s *Service) UpdateInstanceMetadataOptions(instanceID string, httpTokens v1alpha1.HTTPTokensState) error {
+	s.scope.V(2).Info("Attempting to update instance metadata options", "instance-id", instanceID, "http-tokens", httpTokens)
+
+	input := &ec2.ModifyInstanceMetadataOptionsInput{
+		InstanceId: aws.String(instanceID),
+		HttpTokens: aws.String(string(httpTokens)),
+	}
+
+	if _, err := s.scope.EC2.ModifyInstanceMetadataOptions(input); err != nil {
+		return errors.Wrapf(err, "failed to update instance metadata options of instance %q", instanceID)
+	}
+
+	return nil
+}
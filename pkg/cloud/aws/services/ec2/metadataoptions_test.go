@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestUpdateInstanceMetadataOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	ec2Mock.EXPECT().
+		ModifyInstanceMetadataOptions(&ec2.ModifyInstanceMetadataOptionsInput{
+			InstanceId: aws.String("i-1"),
+			HttpTokens: aws.String("required"),
+		}).
+		Return(&ec2.ModifyInstanceMetadataOptionsOutput{}, nil)
+
+	s := NewService(scope)
+	if err := s.UpdateInstanceMetadataOptions("i-1", v1alpha1.HTTPTokensStateRequired); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+func TestRunInstanceWithInstanceMetadataOptions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	ec2Mock.EXPECT().
+		RunInstances(gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+		DoAndReturn(func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+			if input.MetadataOptions == nil || aws.StringValue(input.MetadataOptions.HttpTokens) != "required" {
+				t.Fatalf("expected the instance to require HTTP tokens, got metadata options %v", input.MetadataOptions)
+			}
+			return &ec2.Reservation{
+				Instances: []*ec2.Instance{
+					{
+						InstanceId:      aws.String("i-1"),
+						InstanceType:    aws.String("m5.large"),
+						ImageId:         aws.String("ami-1"),
+						State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+						MetadataOptions: &ec2.InstanceMetadataOptionsResponse{HttpTokens: aws.String("required")},
+					},
+				},
+			}, nil
+		})
+	ec2Mock.EXPECT().
+		WaitUntilInstanceRunningWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s := NewService(scope)
+	instance, err := s.runInstance("node", &v1alpha1.Instance{
+		Type:       "m5.large",
+		ImageID:    "ami-1",
+		HTTPTokens: v1alpha1.HTTPTokensStateRequired,
+	}, "", false, time.Minute)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if instance.HTTPTokens != v1alpha1.HTTPTokensStateRequired {
+		t.Fatalf("expected the instance to require HTTP tokens, got %q", instance.HTTPTokens)
+	}
+}
@@ -18,6 +18,7 @@ package ec2
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -56,6 +57,13 @@ func (s *Service) reconcileNatGateways() error {
 			continue
 		}
 
+		// NAT gateways aren't available in local zones, their private subnets
+		// use a NAT gateway of the region instead.
+		if isLocalZone(s.scope.Region(), sn.AvailabilityZone) {
+			s.scope.V(2).Info("Skipping NAT gateway for public subnet in local zone", "subnet-id", sn.ID, "availability-zone", sn.AvailabilityZone)
+			continue
+		}
+
 		if ngw, ok := existing[sn.ID]; ok {
 			// Make sure tags are up to date.
 			err := tags.Ensure(converters.TagsToMap(ngw.Tags), &tags.ApplyParams{
@@ -254,5 +262,21 @@ func (s *Service) getNatGatewayForSubnet(sn *v1alpha1.SubnetSpec) (string, error
 		return gws[0], nil
 	}
 
+	// Private subnets in a local zone go through a NAT gateway of the region,
+	// the same one for all of them.
+	if isLocalZone(s.scope.Region(), sn.AvailabilityZone) {
+		zones := make([]string, 0, len(azGateways))
+		for zone := range azGateways {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+
+		for _, zone := range zones {
+			if !isLocalZone(s.scope.Region(), zone) {
+				return azGateways[zone][0], nil
+			}
+		}
+	}
+
 	return "", errors.Errorf("no nat gateways available in %q for private subnet %q, current state: %+v", sn.AvailabilityZone, sn.ID, azGateways)
 }
@@ -281,6 +281,30 @@ func TestReconcileNatGateways(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name: "public & private subnet in a local zone, should create no NAT gateway",
+			input: []*v1alpha1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1-bos-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1-bos-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPages(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				m.AllocateAddress(gomock.Any()).Times(0)
+				m.CreateNatGateway(gomock.Any()).Times(0)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -299,6 +323,7 @@ func TestReconcileNatGateways(t *testing.T) {
 			})
 
 			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
 				NetworkSpec: v1alpha1.NetworkSpec{
 					VPC: v1alpha1.VPCSpec{
 						ID: subnetsVPCID,
@@ -323,3 +348,69 @@ func TestReconcileNatGateways(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNatGatewayForSubnet(t *testing.T) {
+	subnets := v1alpha1.Subnets{
+		{ID: "subnet-public-b", AvailabilityZone: "us-east-1b", IsPublic: true, NatGatewayID: aws.String("nat-b")},
+		{ID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true, NatGatewayID: aws.String("nat-a")},
+		{ID: "subnet-public-lz", AvailabilityZone: "us-east-1-bos-1a", IsPublic: true},
+	}
+
+	testCases := []struct {
+		name        string
+		subnet      *v1alpha1.SubnetSpec
+		expect      string
+		expectError bool
+	}{
+		{
+			name:   "NAT gateway in the availability zone",
+			subnet: &v1alpha1.SubnetSpec{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+			expect: "nat-b",
+		},
+		{
+			name:   "local zone uses the NAT gateway of the first availability zone",
+			subnet: &v1alpha1.SubnetSpec{ID: "subnet-private-lz", AvailabilityZone: "us-east-1-bos-1a"},
+			expect: "nat-a",
+		},
+		{
+			name:        "no NAT gateway in the availability zone",
+			subnet:      &v1alpha1.SubnetSpec{ID: "subnet-private-c", AvailabilityZone: "us-east-1c"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				Region: "us-east-1",
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: subnets,
+				},
+			}
+
+			s := NewService(scope)
+			id, err := s.getNatGatewayForSubnet(tc.subnet)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got NAT gateway %q", id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if id != tc.expect {
+				t.Fatalf("expected NAT gateway %q, got %q", tc.expect, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+)
+
+// validateAdditionalNetworkInterfaces returns an error if the additional network
+// interfaces can't be attached to an instance at launch.
+func validateAdditionalNetworkInterfaces(enis []v1alpha1.NetworkInterfaceSpec) error {
+	indexes := map[int64]bool{}
+	for _, eni := range enis {
+		if eni.DeviceIndex < 1 {
+			return errors.Errorf("invalid device index %d for additional network interface in subnet %q, must be at least 1", eni.DeviceIndex, eni.SubnetID)
+		}
+		if indexes[eni.DeviceIndex] {
+			return errors.Errorf("device index %d is used by more than one additional network interface", eni.DeviceIndex)
+		}
+		indexes[eni.DeviceIndex] = true
+
+		if eni.SubnetID == "" {
+			return errors.Errorf("missing subnet for additional network interface with device index %d", eni.DeviceIndex)
+		}
+	}
+	return nil
+}
+
+// validateSecondaryPrivateIPs returns an error if the explicit secondary private
+// IPs of the instance can't be assigned to its primary network interface.
+func (s *Service) validateSecondaryPrivateIPs(i *v1alpha1.Instance) error {
+	if len(i.SecondaryPrivateIPs) == 0 {
+		return nil
+	}
+	if i.SecondaryPrivateIPCount != nil {
+		return errors.New("secondary private IP count and explicit secondary private IPs are mutually exclusive")
+	}
+
+	cidr, err := s.subnetCIDR(i.SubnetID)
+	if err != nil {
+		return err
+	}
+	return secondaryPrivateIPsInCIDR(i.SecondaryPrivateIPs, cidr)
+}
+
+// secondaryPrivateIPsInCIDR returns an error unless every address is a distinct
+// IPv4 address within the given CIDR block.
+func secondaryPrivateIPsInCIDR(ips []string, cidr string) error {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse subnet CIDR block %q", cidr)
+	}
+
+	seen := map[string]bool{}
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil || ip.To4() == nil {
+			return errors.Errorf("invalid secondary private IP %q, must be an IPv4 address", s)
+		}
+		if !ipnet.Contains(ip) {
+			return errors.Errorf("secondary private IP %q is not within subnet CIDR block %q", s, cidr)
+		}
+		if seen[ip.String()] {
+			return errors.Errorf("secondary private IP %q is listed more than once", s)
+		}
+		seen[ip.String()] = true
+	}
+	return nil
+}
+
+// subnetCIDR returns the CIDR block of the given subnet, looking it up when it
+// isn't one of the cluster subnets.
+func (s *Service) subnetCIDR(id string) (string, error) {
+	if sn := s.scope.Subnets().FindByID(id); sn != nil && sn.CidrBlock != "" {
+		return sn.CidrBlock, nil
+	}
+
+	out, err := s.scope.EC2.DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{aws.String(id)},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", id)
+	}
+	if len(out.Subnets) == 0 {
+		return "", errors.Errorf("subnet %q not found", id)
+	}
+	return aws.StringValue(out.Subnets[0].CidrBlock), nil
+}
+
+// DeleteDetachedNetworkInterfaces deletes the given network interfaces once they are
+// detached from their instance, so that network interfaces that aren't deleted on
+// termination don't leak. Network interfaces that will be deleted along with their
+// instance are left alone. It returns the number of network interfaces that are
+// still attached and have to be deleted later.
+func (s *Service) DeleteDetachedNetworkInterfaces(ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("network-interface-id"),
+				Values: aws.StringSlice(ids),
+			},
+		},
+	}
+
+	out, err := s.scope.EC2.DescribeNetworkInterfaces(input)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to describe network interfaces %q", ids)
+	}
+
+	remaining := 0
+	for _, eni := range out.NetworkInterfaces {
+		id := aws.StringValue(eni.NetworkInterfaceId)
+
+		if aws.StringValue(eni.Status) != ec2.NetworkInterfaceStatusAvailable {
+			if eni.Attachment != nil && aws.BoolValue(eni.Attachment.DeleteOnTermination) {
+				continue
+			}
+			s.scope.V(2).Info("Waiting for network interface to be detached before deleting it", "network-interface-id", id)
+			remaining++
+			continue
+		}
+
+		s.scope.V(2).Info("Deleting detached network interface", "network-interface-id", id)
+		if _, err := s.scope.EC2.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: eni.NetworkInterfaceId}); err != nil {
+			if code, _ := awserrors.Code(err); code == awserrors.InvalidNetworkInterfaceIDNotFound {
+				continue
+			}
+			return 0, errors.Wrapf(err, "failed to delete network interface %q", id)
+		}
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+func TestValidateAdditionalNetworkInterfaces(t *testing.T) {
+	testCases := []struct {
+		name        string
+		enis        []v1alpha1.NetworkInterfaceSpec
+		expectError bool
+	}{
+		{
+			name: "valid network interfaces",
+			enis: []v1alpha1.NetworkInterfaceSpec{
+				{DeviceIndex: 1, SubnetID: "subnet-1"},
+				{DeviceIndex: 2, SubnetID: "subnet-2", SecurityGroupIDs: []string{"sg-1"}},
+			},
+		},
+		{
+			name:        "primary device index",
+			enis:        []v1alpha1.NetworkInterfaceSpec{{DeviceIndex: 0, SubnetID: "subnet-1"}},
+			expectError: true,
+		},
+		{
+			name: "duplicate device index",
+			enis: []v1alpha1.NetworkInterfaceSpec{
+				{DeviceIndex: 1, SubnetID: "subnet-1"},
+				{DeviceIndex: 1, SubnetID: "subnet-2"},
+			},
+			expectError: true,
+		},
+		{
+			name:        "missing subnet",
+			enis:        []v1alpha1.NetworkInterfaceSpec{{DeviceIndex: 1}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAdditionalNetworkInterfaces(tc.enis)
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSecondaryPrivateIPsInCIDR(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ips         []string
+		expectError bool
+	}{
+		{
+			name: "within subnet",
+			ips:  []string{"10.0.0.10", "10.0.0.254"},
+		},
+		{
+			name:        "outside subnet",
+			ips:         []string{"10.0.0.10", "10.0.1.10"},
+			expectError: true,
+		},
+		{
+			name:        "not an ip",
+			ips:         []string{"10.0.0"},
+			expectError: true,
+		},
+		{
+			name:        "ipv6",
+			ips:         []string{"2001:db8::1"},
+			expectError: true,
+		},
+		{
+			name:        "duplicate",
+			ips:         []string{"10.0.0.10", "10.0.0.10"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := secondaryPrivateIPsInCIDR(tc.ips, "10.0.0.0/24")
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSubnetCIDR(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().
+		DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{aws.String("subnet-other")}}).
+		Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-other"), CidrBlock: aws.String("10.1.0.0/24")}},
+		}, nil)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("did not expect err: %v", err)
+	}
+	scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+		NetworkSpec: v1alpha1.NetworkSpec{
+			Subnets: v1alpha1.Subnets{{ID: "subnet-1", CidrBlock: "10.0.0.0/24"}},
+		},
+	}
+
+	s := NewService(scope)
+	if cidr, err := s.subnetCIDR("subnet-1"); err != nil || cidr != "10.0.0.0/24" {
+		t.Fatalf("expected cluster subnet CIDR 10.0.0.0/24, got %q (%v)", cidr, err)
+	}
+	if cidr, err := s.subnetCIDR("subnet-other"); err != nil || cidr != "10.1.0.0/24" {
+		t.Fatalf("expected described subnet CIDR 10.1.0.0/24, got %q (%v)", cidr, err)
+	}
+}
+
+func TestDeleteDetachedNetworkInterfaces(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{},
+		AWSClients: actuators.AWSClients{
+			EC2: ec2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("did not expect err: %v", err)
+	}
+
+	ec2Mock.EXPECT().
+		DescribeNetworkInterfaces(gomock.AssignableToTypeOf(&ec2.DescribeNetworkInterfacesInput{})).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []*ec2.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-primary"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusInUse),
+					Attachment:         &ec2.NetworkInterfaceAttachment{DeleteOnTermination: aws.Bool(true)},
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-attached"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusInUse),
+					Attachment:         &ec2.NetworkInterfaceAttachment{DeleteOnTermination: aws.Bool(false)},
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-detached"),
+					Status:             aws.String(ec2.NetworkInterfaceStatusAvailable),
+				},
+			},
+		}, nil)
+	ec2Mock.EXPECT().
+		DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String("eni-detached")}).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+
+	s := NewService(scope)
+	remaining, err := s.DeleteDetachedNetworkInterfaces([]string{"eni-primary", "eni-attached", "eni-detached", "eni-gone"})
+	if err != nil {
+		t.Fatalf("did not expect err: %v", err)
+	}
+	if remaining != 1 {
+		t.Fatalf("expected 1 network interface left to delete, got %d", remaining)
+	}
+}
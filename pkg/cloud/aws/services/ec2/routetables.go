@@ -124,6 +124,31 @@ func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable,
 	return res, nil
 }
 
+// subnetRoutesToInternetGateway returns whether the route table of the subnet,
+// or the main route table of the VPC when the subnet isn't explicitly
+// associated with one, has an active route through an internet gateway.
+func (s *Service) subnetRoutesToInternetGateway(subnetID string) (bool, error) {
+	routeTables, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return false, err
+	}
+
+	rt := routeTables[subnetID]
+	if rt == nil {
+		rt = routeTables[mainRouteTableInVPCKey]
+	}
+	if rt == nil {
+		return false, nil
+	}
+
+	for _, route := range rt.Routes {
+		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw") && aws.StringValue(route.State) != ec2.RouteStateBlackhole {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (s *Service) deleteRouteTables() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.V(4).Info("Skipping routing tables deletion in unmanaged mode")
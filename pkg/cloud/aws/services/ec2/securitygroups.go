@@ -18,6 +18,7 @@ package ec2
 
 import (
 	"fmt"
+	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/filter"
@@ -189,6 +190,91 @@ func (s *Service) describeSecurityGroupsByName() (map[string]*v1alpha1.SecurityG
 	return res, nil
 }
 
+// ResolveSecurityGroupReferences returns the IDs of the security groups the given
+// references point to. Each reference is resolved from its ID, its ARN or its
+// filters, in that order. Filters are scoped to the cluster VPC and may match
+// more than one security group.
+func (s *Service) ResolveSecurityGroupReferences(refs []v1alpha1.AWSResourceReference) ([]string, error) {
+	seen := map[string]bool{}
+	res := []string{}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	for _, ref := range refs {
+		switch {
+		case ref.ID != nil:
+			add(*ref.ID)
+		case ref.ARN != nil:
+			id, err := securityGroupIDFromARN(*ref.ARN)
+			if err != nil {
+				return nil, err
+			}
+			add(id)
+		case len(ref.Filters) > 0:
+			ids, err := s.describeSecurityGroupIDsByFilters(ref.Filters)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				add(id)
+			}
+		default:
+			return nil, errors.New("security group reference must set an id, an arn or filters")
+		}
+	}
+
+	return res, nil
+}
+
+// securityGroupIDFromARN extracts the security group ID from an ARN of the form
+// arn:aws:ec2:<region>:<account>:security-group/<id>.
+func securityGroupIDFromARN(arn string) (string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "ec2" {
+		return "", errors.Errorf("invalid security group arn %q", arn)
+	}
+
+	id := strings.TrimPrefix(parts[5], "security-group/")
+	if id == parts[5] || id == "" {
+		return "", errors.Errorf("arn %q does not refer to a security group", arn)
+	}
+
+	return id, nil
+}
+
+func (s *Service) describeSecurityGroupIDsByFilters(filters []v1alpha1.Filter) ([]string, error) {
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+		},
+	}
+	for _, f := range filters {
+		input.Filters = append(input.Filters, &ec2.Filter{
+			Name:   aws.String(f.Name),
+			Values: aws.StringSlice(f.Values),
+		})
+	}
+
+	out, err := s.scope.EC2.DescribeSecurityGroups(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe security groups matching filters %v", filters)
+	}
+	if len(out.SecurityGroups) == 0 {
+		return nil, errors.Errorf("found no security groups matching filters %v", filters)
+	}
+
+	ids := make([]string, 0, len(out.SecurityGroups))
+	for _, sg := range out.SecurityGroups {
+		ids = append(ids, aws.StringValue(sg.GroupId))
+	}
+
+	return ids, nil
+}
+
 func (s *Service) createSecurityGroup(role v1alpha1.SecurityGroupRole, input *ec2.SecurityGroup) error {
 	out, err := s.scope.EC2.CreateSecurityGroup(&ec2.CreateSecurityGroupInput{
 		VpcId:       input.VpcId,
@@ -265,8 +351,8 @@ func (s *Service) getSecurityGroupIngressRules(role v1alpha1.SecurityGroupRole)
 			{
 				Description: "Kubernetes API",
 				Protocol:    v1alpha1.SecurityGroupProtocolTCP,
-				FromPort:    6443,
-				ToPort:      6443,
+				FromPort:    s.scope.APIServerPort(),
+				ToPort:      s.scope.APIServerPort(),
 				CidrBlocks:  []string{anyIPv4CidrBlock},
 			},
 			{
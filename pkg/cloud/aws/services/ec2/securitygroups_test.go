@@ -171,3 +171,153 @@ func TestReconcileSecurityGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSecurityGroupReferences(t *testing.T) {
+	testCases := []struct {
+		name      string
+		refs      []v1alpha1.AWSResourceReference
+		expect    func(m *mock_ec2iface.MockEC2APIMockRecorder)
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "no references",
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expected: []string{},
+		},
+		{
+			name: "id reference",
+			refs: []v1alpha1.AWSResourceReference{
+				{ID: aws.String("sg-1")},
+			},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expected: []string{"sg-1"},
+		},
+		{
+			name: "arn reference",
+			refs: []v1alpha1.AWSResourceReference{
+				{ARN: aws.String("arn:aws:ec2:us-east-1:123456789012:security-group/sg-2")},
+			},
+			expect:   func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expected: []string{"sg-2"},
+		},
+		{
+			name: "filter reference",
+			refs: []v1alpha1.AWSResourceReference{
+				{Filters: []v1alpha1.Filter{{Name: "tag:role", Values: []string{"monitoring"}}}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("vpc-id"),
+							Values: aws.StringSlice([]string{"vpc-test"}),
+						},
+						{
+							Name:   aws.String("tag:role"),
+							Values: aws.StringSlice([]string{"monitoring"}),
+						},
+					},
+				}).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{
+						{GroupId: aws.String("sg-3")},
+						{GroupId: aws.String("sg-4")},
+					},
+				}, nil)
+			},
+			expected: []string{"sg-3", "sg-4"},
+		},
+		{
+			name: "mixed references are deduplicated",
+			refs: []v1alpha1.AWSResourceReference{
+				{ID: aws.String("sg-1")},
+				{ARN: aws.String("arn:aws-us-gov:ec2:us-gov-west-1:123456789012:security-group/sg-1")},
+				{Filters: []v1alpha1.Filter{{Name: "group-name", Values: []string{"extra"}}}},
+				{ARN: aws.String("arn:aws:ec2:us-east-1:123456789012:security-group/sg-2")},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []*ec2.SecurityGroup{
+						{GroupId: aws.String("sg-2")},
+						{GroupId: aws.String("sg-5")},
+					},
+				}, nil)
+			},
+			expected: []string{"sg-1", "sg-2", "sg-5"},
+		},
+		{
+			name: "arn of another resource type",
+			refs: []v1alpha1.AWSResourceReference{
+				{ARN: aws.String("arn:aws:ec2:us-east-1:123456789012:subnet/subnet-1")},
+			},
+			expect:    func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "malformed arn",
+			refs: []v1alpha1.AWSResourceReference{
+				{ARN: aws.String("sg-1")},
+			},
+			expect:    func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "filters matching nothing",
+			refs: []v1alpha1.AWSResourceReference{
+				{ID: aws.String("sg-1")},
+				{Filters: []v1alpha1.Filter{{Name: "group-name", Values: []string{"missing"}}}},
+			},
+			expect: func(m *mock_ec2iface.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroups(gomock.Any()).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty reference",
+			refs: []v1alpha1.AWSResourceReference{
+				{},
+			},
+			expect:    func(m *mock_ec2iface.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			scope.ClusterConfig.NetworkSpec.VPC.ID = "vpc-test"
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			ids, err := s.ResolveSecurityGroupReferences(tc.refs)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect err: %v", err)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+				t.Fatalf("expected security groups %v, got %v", tc.expected, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// ProviderSpecHash returns a hash of the machine provider spec fields that can
+// only be applied by replacing the instance. Fields reconciled on the running
+// instance, fields that only affect how it is launched, and the kubeadm
+// configuration, which the actuator fills in at launch, are left out.
+func ProviderSpecHash(spec *v1alpha1.AWSMachineProviderSpec) (string, error) {
+	effective := spec.DeepCopy()
+	effective.TypeMeta = metav1.TypeMeta{}
+	effective.ObjectMeta = metav1.ObjectMeta{}
+	effective.AdditionalTags = nil
+	effective.ComplianceScope = nil
+	effective.CreditSpecification = nil
+	effective.Monitoring = nil
+	effective.SourceDestCheck = nil
+	effective.InstanceMetadataOptions = nil
+	effective.AdditionalSecurityGroups = nil
+	effective.TargetGroupARNs = nil
+	effective.LaunchTimeout = nil
+	effective.ResourceCreationTimeout = nil
+	effective.ValidateLaunch = nil
+	effective.StartStoppedInstance = nil
+	effective.LifecycleHook = nil
+	effective.EphemeralVolumes = nil
+	effective.KubeadmConfiguration = v1alpha1.KubeadmConfiguration{}
+
+	// Struct fields are encoded in declaration order and map keys sorted,
+	// so the encoding, and thus the hash, is stable.
+	data, err := json.Marshal(effective)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode machine provider spec")
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+func TestProviderSpecHash(t *testing.T) {
+	base := func() *v1alpha1.AWSMachineProviderSpec {
+		return &v1alpha1.AWSMachineProviderSpec{
+			AMI:          v1alpha1.AWSResourceReference{ID: aws.String("ami-1")},
+			InstanceType: "m5.large",
+			AdditionalTags: map[string]string{
+				"a": "1",
+				"b": "2",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		mutate      func(spec *v1alpha1.AWSMachineProviderSpec)
+		expectDrift bool
+	}{
+		{
+			name:   "identical spec",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {},
+		},
+		{
+			name: "additional tags changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.AdditionalTags = map[string]string{"c": "3"}
+			},
+		},
+		{
+			name: "additional security groups changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.AdditionalSecurityGroups = []v1alpha1.AWSResourceReference{{ID: aws.String("sg-1")}}
+			},
+		},
+		{
+			name: "monitoring changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.Monitoring = aws.Bool(true)
+			},
+		},
+		{
+			name: "ephemeral volumes changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.EphemeralVolumes = []v1alpha1.EphemeralVolume{{VirtualName: "ephemeral0", DeviceName: "/dev/sdb"}}
+			},
+		},
+		{
+			name: "kubeadm configuration filled in at launch",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.KubeadmConfiguration.Join.NodeRegistration.Name = hostnameLookup
+			},
+		},
+		{
+			name: "instance type changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.InstanceType = "m5.xlarge"
+			},
+			expectDrift: true,
+		},
+		{
+			name: "ami changed",
+			mutate: func(spec *v1alpha1.AWSMachineProviderSpec) {
+				spec.AMI.ID = aws.String("ami-2")
+			},
+			expectDrift: true,
+		},
+	}
+
+	launched, err := ProviderSpecHash(base())
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := base()
+			tc.mutate(spec)
+
+			current, err := ProviderSpecHash(spec)
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if drift := current != launched; drift != tc.expectDrift {
+				t.Fatalf("expected drift %t, got hashes %q and %q", tc.expectDrift, launched, current)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/converters"
+)
+
+// maxTagBatchResources is the number of resources a batch is flushed at
+// before its interval elapses, to keep the tagging requests small.
+const maxTagBatchResources = 100
+
+var (
+	tagBatcherMu sync.Mutex
+	tagBatcher   *TagBatcher
+)
+
+// SetTagBatcher makes resource tags be updated through the given batcher, nil
+// updates them one resource at a time. It is meant to be called once on
+// startup, before any resource is tagged.
+func SetTagBatcher(b *TagBatcher) {
+	tagBatcherMu.Lock()
+	defer tagBatcherMu.Unlock()
+	tagBatcher = b
+}
+
+func currentTagBatcher() *TagBatcher {
+	tagBatcherMu.Lock()
+	defer tagBatcherMu.Unlock()
+	return tagBatcher
+}
+
+type tagOperation string
+
+const (
+	tagOperationCreate = tagOperation("create")
+	tagOperationDelete = tagOperation("delete")
+)
+
+type tagBatchKey struct {
+	client    ec2iface.EC2API
+	operation tagOperation
+	tags      string
+}
+
+type tagBatch struct {
+	tags      map[string]string
+	resources []*string
+	done      chan struct{}
+
+	// err is the error of the batched call, errs the error of every
+	// resource when the batch had to be retried one resource at a time.
+	err  error
+	errs []error
+}
+
+// TagBatcher coalesces the tags created on and deleted from resources within
+// a flush interval into a single CreateTags or DeleteTags call per EC2 client
+// and tag set, which reduces the API calls made when many machines of a large
+// cluster are reconciled concurrently.
+type TagBatcher struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[tagBatchKey]*tagBatch
+}
+
+// NewTagBatcher returns a batcher flushing the pending tag updates after the
+// given interval.
+func NewTagBatcher(interval time.Duration) *TagBatcher {
+	return &TagBatcher{
+		interval: interval,
+		pending:  map[tagBatchKey]*tagBatch{},
+	}
+}
+
+// CreateTags creates the tags on the resource with the next flush of the
+// batcher, and returns once they've been created.
+func (b *TagBatcher) CreateTags(client ec2iface.EC2API, resourceID *string, tags map[string]string) error {
+	return b.add(client, tagOperationCreate, resourceID, tags)
+}
+
+// DeleteTags deletes the tags from the resource with the next flush of the
+// batcher, and returns once they've been deleted.
+func (b *TagBatcher) DeleteTags(client ec2iface.EC2API, resourceID *string, tags map[string]string) error {
+	return b.add(client, tagOperationDelete, resourceID, tags)
+}
+
+func (b *TagBatcher) add(client ec2iface.EC2API, operation tagOperation, resourceID *string, tags map[string]string) error {
+	key := tagBatchKey{client: client, operation: operation, tags: tagSetKey(tags)}
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &tagBatch{tags: tags, done: make(chan struct{})}
+		b.pending[key] = batch
+		time.AfterFunc(b.interval, func() { b.flush(key, batch) })
+	}
+	i := len(batch.resources)
+	batch.resources = append(batch.resources, resourceID)
+	full := len(batch.resources) >= maxTagBatchResources
+	b.mu.Unlock()
+
+	if full {
+		b.flush(key, batch)
+	}
+
+	<-batch.done
+	if batch.errs != nil {
+		return batch.errs[i]
+	}
+	return batch.err
+}
+
+// flush applies the batch if it's still pending, a batch flushed early for
+// being full is skipped when its interval elapses.
+func (b *TagBatcher) flush(key tagBatchKey, batch *tagBatch) {
+	b.mu.Lock()
+	if b.pending[key] != batch {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.pending, key)
+	b.mu.Unlock()
+
+	batch.err = applyTags(key.client, key.operation, batch.resources, batch.tags)
+	if batch.err != nil && len(batch.resources) > 1 {
+		// A single resource that went away in the meantime fails the whole
+		// call, retry the resources one at a time so that only the callers
+		// of the failing ones see an error.
+		errs := make([]error, len(batch.resources))
+		for i, resourceID := range batch.resources {
+			errs[i] = applyTags(key.client, key.operation, []*string{resourceID}, batch.tags)
+		}
+		batch.err = nil
+		batch.errs = errs
+	}
+	close(batch.done)
+}
+
+func applyTags(client ec2iface.EC2API, operation tagOperation, resources []*string, tags map[string]string) error {
+	if operation == tagOperationDelete {
+		_, err := client.DeleteTags(&ec2.DeleteTagsInput{
+			Resources: resources,
+			Tags:      converters.MapToTags(tags),
+		})
+		return err
+	}
+
+	_, err := client.CreateTags(&ec2.CreateTagsInput{
+		Resources: resources,
+		Tags:      converters.MapToTags(tags),
+	})
+	return err
+}
+
+// tagSetKey returns a key identifying equal tag sets.
+func tagSetKey(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte(0)
+		sb.WriteString(tags[k])
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+)
+
+// tagConcurrently tags every resource from its own goroutine, like the
+// reconciles of different machines would, and returns their errors by
+// resource.
+func tagConcurrently(b *TagBatcher, tag func(b *TagBatcher, resourceID *string) error, resourceIDs ...string) map[string]error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = map[string]error{}
+	)
+	for _, id := range resourceIDs {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			err := tag(b, aws.String(id))
+			mu.Lock()
+			defer mu.Unlock()
+			errs[id] = err
+		}(id)
+	}
+	wg.Wait()
+	return errs
+}
+
+func sortedResources(resources []*string) []string {
+	ids := aws.StringValueSlice(resources)
+	sort.Strings(ids)
+	return ids
+}
+
+func TestTagBatcherCreateTags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().
+		CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+		DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+			if ids := sortedResources(input.Resources); len(ids) != 3 || ids[0] != "i-1" || ids[1] != "i-2" || ids[2] != "i-3" {
+				t.Errorf("expected i-1, i-2 and i-3 to be tagged in a single call, got %v", ids)
+			}
+			if len(input.Tags) != 1 || aws.StringValue(input.Tags[0].Key) != "team" || aws.StringValue(input.Tags[0].Value) != "infra" {
+				t.Errorf("unexpected tags %v", input.Tags)
+			}
+			return &ec2.CreateTagsOutput{}, nil
+		})
+
+	b := NewTagBatcher(100 * time.Millisecond)
+	errs := tagConcurrently(b, func(b *TagBatcher, resourceID *string) error {
+		return b.CreateTags(ec2Mock, resourceID, map[string]string{"team": "infra"})
+	}, "i-1", "i-2", "i-3")
+
+	for id, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error tagging %s: %v", id, err)
+		}
+	}
+}
+
+func TestTagBatcherDeleteTags(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().
+		DeleteTags(gomock.AssignableToTypeOf(&ec2.DeleteTagsInput{})).
+		DoAndReturn(func(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+			if ids := sortedResources(input.Resources); len(ids) != 2 || ids[0] != "i-1" || ids[1] != "i-2" {
+				t.Errorf("expected i-1 and i-2 to be untagged in a single call, got %v", ids)
+			}
+			return &ec2.DeleteTagsOutput{}, nil
+		})
+
+	b := NewTagBatcher(100 * time.Millisecond)
+	errs := tagConcurrently(b, func(b *TagBatcher, resourceID *string) error {
+		return b.DeleteTags(ec2Mock, resourceID, map[string]string{"team": "infra"})
+	}, "i-1", "i-2")
+
+	for id, err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error untagging %s: %v", id, err)
+		}
+	}
+}
+
+func TestTagBatcherSeparatesTagSets(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().
+		CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+		DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+			if len(input.Resources) != 1 {
+				t.Errorf("expected resources with different tags to be tagged separately, got %v", aws.StringValueSlice(input.Resources))
+			}
+			return &ec2.CreateTagsOutput{}, nil
+		}).
+		Times(2)
+
+	b := NewTagBatcher(100 * time.Millisecond)
+	tagConcurrently(b, func(b *TagBatcher, resourceID *string) error {
+		return b.CreateTags(ec2Mock, resourceID, map[string]string{"Name": aws.StringValue(resourceID)})
+	}, "i-1", "i-2")
+}
+
+func TestTagBatcherRetriesFailedBatchPerResource(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	notFound := awserr.New("InvalidInstanceID.NotFound", "The instance ID 'i-2' does not exist", nil)
+
+	ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+	ec2Mock.EXPECT().
+		CreateTags(gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+		DoAndReturn(func(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+			for _, id := range input.Resources {
+				if aws.StringValue(id) == "i-2" {
+					return nil, notFound
+				}
+			}
+			return &ec2.CreateTagsOutput{}, nil
+		}).
+		Times(3)
+
+	b := NewTagBatcher(100 * time.Millisecond)
+	errs := tagConcurrently(b, func(b *TagBatcher, resourceID *string) error {
+		return b.CreateTags(ec2Mock, resourceID, map[string]string{"team": "infra"})
+	}, "i-1", "i-2")
+
+	if errs["i-1"] != nil {
+		t.Errorf("unexpected error tagging i-1: %v", errs["i-1"])
+	}
+	if errs["i-2"] != notFound {
+		t.Errorf("expected tagging i-2 to fail with %v, got %v", notFound, errs["i-2"])
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/converters"
+)
+
+// GetInstanceVolumes returns the EBS volumes attached to the instance.
+func (s *Service) GetInstanceVolumes(instanceID string) ([]*v1alpha1.Volume, error) {
+	input := &ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("attachment.instance-id"),
+				Values: []*string{aws.String(instanceID)},
+			},
+		},
+	}
+
+	var volumes []*v1alpha1.Volume
+	err := s.scope.EC2.DescribeVolumesPages(input, func(out *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		for _, v := range out.Volumes {
+			volumes = append(volumes, &v1alpha1.Volume{
+				ID:        aws.StringValue(v.VolumeId),
+				Encrypted: aws.BoolValue(v.Encrypted),
+				KMSKeyID:  v.KmsKeyId,
+				Tags:      converters.TagsToMap(v.Tags),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe volumes of instance %q", instanceID)
+	}
+
+	return volumes, nil
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// localZoneRootVolumeType is the root volume type of instances launched in a
+// local zone, where newer volume types an image may default to aren't offered.
+const localZoneRootVolumeType = ec2.VolumeTypeGp2
+
+// isLocalZone returns true if the zone is a local zone of the region. Local
+// zones are named after their region and location, e.g. "us-west-2-lax-1a",
+// while the availability zones of the region only add a letter, e.g. "us-west-2a".
+func isLocalZone(region, zone string) bool {
+	return region != "" && strings.HasPrefix(zone, region+"-")
+}
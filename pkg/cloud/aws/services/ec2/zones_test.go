@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"testing"
+)
+
+func TestIsLocalZone(t *testing.T) {
+	testCases := []struct {
+		name   string
+		region string
+		zone   string
+		expect bool
+	}{
+		{
+			name:   "availability zone",
+			region: "us-west-2",
+			zone:   "us-west-2a",
+		},
+		{
+			name:   "local zone",
+			region: "us-west-2",
+			zone:   "us-west-2-lax-1a",
+			expect: true,
+		},
+		{
+			name:   "local zone of another region",
+			region: "us-east-1",
+			zone:   "us-west-2-lax-1a",
+		},
+		{
+			name: "unknown region",
+			zone: "us-west-2-lax-1a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isLocalZone(tc.region, tc.zone); got != tc.expect {
+				t.Errorf("expected %v, got %v", tc.expect, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package elb
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -35,6 +36,10 @@ import (
 func (s *Service) ReconcileLoadbalancers() error {
 	s.scope.V(2).Info("Reconciling load balancers")
 
+	if err := validateLoadBalancerSpec(s.scope.ClusterConfig.ControlPlaneLoadBalancer); err != nil {
+		return err
+	}
+
 	// Get default api server spec.
 	spec := s.getAPIServerClassicELBSpec()
 
@@ -51,6 +56,16 @@ func (s *Service) ReconcileLoadbalancers() error {
 		return err
 	}
 
+	// Cross-zone load balancing is only enabled by default on new load
+	// balancers, existing ones keep their setting unless it's set explicitly.
+	if !crossZoneLoadBalancingSet(s.scope.ClusterConfig.ControlPlaneLoadBalancer) {
+		spec.Attributes.CrossZoneLoadBalancing = apiELB.Attributes.CrossZoneLoadBalancing
+	}
+
+	if !strings.EqualFold(string(spec.Scheme), string(apiELB.Scheme)) {
+		return errors.Errorf("classic load balancer %q has scheme %q, it cannot be changed to %q", apiELB.Name, apiELB.Scheme, spec.Scheme)
+	}
+
 	if !reflect.DeepEqual(spec.Attributes, apiELB.Attributes) {
 		err := s.configureAttributes(apiELB.Name, spec.Attributes)
 		if err != nil {
@@ -58,6 +73,16 @@ func (s *Service) ReconcileLoadbalancers() error {
 		}
 	}
 
+	if err := s.reconcileListeners(apiELB.Name, spec.Listeners, apiELB.Listeners); err != nil {
+		return err
+	}
+	apiELB.Listeners = spec.Listeners
+
+	if err := s.reconcileHealthCheck(apiELB.Name, spec.HealthCheck, apiELB.HealthCheck); err != nil {
+		return err
+	}
+	apiELB.HealthCheck = spec.HealthCheck
+
 	// TODO(vincepri): check if anything has changed and reconcile as necessary.
 	apiELB.DeepCopyInto(&s.scope.Network().APIServerELB)
 	s.scope.V(4).Info("Control plane load balancer", "api-server-elb", apiELB)
@@ -92,6 +117,17 @@ func (s *Service) DeleteLoadbalancers() error {
 		return err
 	}
 
+	// Deregister the control plane instances first, so deleting the load balancer
+	// doesn't fail on instances that are still registered.
+	instanceIDs, err := s.registeredInstances(elbName)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deregisterInstancesFromClassicELB(elbName, instanceIDs); err != nil {
+		return err
+	}
+
 	if err := s.deleteClassicELBAndWait(elbName); err != nil {
 		return err
 	}
@@ -130,25 +166,125 @@ func (s *Service) RegisterInstanceWithAPIServerELB(instanceID string) error {
 	return nil
 }
 
+// DeregisterInstancesFromAPIServerELB deregisters the instances from the API server ELB in a single call.
+func (s *Service) DeregisterInstancesFromAPIServerELB(instanceIDs []string) error {
+	return s.deregisterInstancesFromClassicELB(GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue), instanceIDs)
+}
+
+// InstancesRegisteredWithAPIServerELB returns the given instances the API
+// server ELB still lists, including the ones whose deregistration is in
+// progress while their connections drain.
+func (s *Service) InstancesRegisteredWithAPIServerELB(instanceIDs []string) ([]string, error) {
+	name := GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue)
+	out, err := s.scope.ELB.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+		LoadBalancerName: aws.String(name),
+	})
+	if code, _ := awserrors.Code(err); code == elb.ErrCodeAccessPointNotFoundException {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instance health of classic load balancer %q", name)
+	}
+
+	listed := map[string]bool{}
+	for _, state := range out.InstanceStates {
+		listed[aws.StringValue(state.InstanceId)] = true
+	}
+
+	var res []string
+	for _, id := range instanceIDs {
+		if listed[id] {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+// APIServerELBHasHealthyInstance returns true if at least one instance registered
+// with the API server ELB passes its health check.
+func (s *Service) APIServerELBHasHealthyInstance() (bool, error) {
+	name := GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue)
+	out, err := s.scope.ELB.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+		LoadBalancerName: aws.String(name),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe instance health of classic load balancer %q", name)
+	}
+
+	for _, state := range out.InstanceStates {
+		if aws.StringValue(state.State) == "InService" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // GenerateELBName generates a formatted ELB name
 func GenerateELBName(clusterName string, elbName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, elbName)
 }
 
+// ReconcileAPIServerListeners replaces the listeners of the API server load
+// balancer that differ from the desired ones, e.g. after the API server port
+// changed, without reconciling the rest of the load balancer. The machine
+// actuator calls it before registering control plane instances, so they are
+// reachable on the desired port. The load balancer is only described if the
+// listeners recorded in the cluster status differ from the desired ones.
+func (s *Service) ReconcileAPIServerListeners() error {
+	if err := validateLoadBalancerSpec(s.scope.ClusterConfig.ControlPlaneLoadBalancer); err != nil {
+		return err
+	}
+
+	listeners := s.getAPIServerListeners()
+	if listenersMatch(s.scope.Network().APIServerELB.Listeners, listeners) {
+		return nil
+	}
+
+	apiELB, err := s.describeClassicELB(GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue))
+	if err != nil {
+		return err
+	}
+
+	if err := s.reconcileListeners(apiELB.Name, listeners, apiELB.Listeners); err != nil {
+		return err
+	}
+	s.scope.Network().APIServerELB.Listeners = listeners
+
+	return nil
+}
+
+// getAPIServerListeners returns the desired listeners of the API server load
+// balancer, forwarding the API server port to the control plane instances.
+func (s *Service) getAPIServerListeners() []*v1alpha1.ClassicELBListener {
+	listener := &v1alpha1.ClassicELBListener{
+		Protocol:         v1alpha1.ClassicELBProtocolTCP,
+		Port:             s.scope.APIServerPort(),
+		InstanceProtocol: v1alpha1.ClassicELBProtocolTCP,
+		InstancePort:     s.scope.APIServerPort(),
+	}
+
+	if lb := s.scope.ClusterConfig.ControlPlaneLoadBalancer; lb != nil && lb.CertificateARN != nil {
+		protocol := v1alpha1.ClassicELBProtocolSSL
+		if lb.ListenerProtocol != nil {
+			protocol = *lb.ListenerProtocol
+		}
+		// The API servers only serve TLS, so the load balancer has to
+		// re-encrypt the traffic it forwards to them.
+		listener.Protocol = protocol
+		listener.InstanceProtocol = protocol
+		listener.SSLCertificateID = *lb.CertificateARN
+	}
+
+	return []*v1alpha1.ClassicELBListener{listener}
+}
+
 func (s *Service) getAPIServerClassicELBSpec() *v1alpha1.ClassicELB {
+	listeners := s.getAPIServerListeners()
 	res := &v1alpha1.ClassicELB{
-		Name:   GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue),
-		Scheme: v1alpha1.ClassicELBSchemeInternetFacing,
-		Listeners: []*v1alpha1.ClassicELBListener{
-			{
-				Protocol:         v1alpha1.ClassicELBProtocolTCP,
-				Port:             6443,
-				InstanceProtocol: v1alpha1.ClassicELBProtocolTCP,
-				InstancePort:     6443,
-			},
-		},
+		Name:      GenerateELBName(s.scope.Name(), v1alpha1.APIServerRoleTagValue),
+		Scheme:    v1alpha1.ClassicELBSchemeInternetFacing,
+		Listeners: listeners,
 		HealthCheck: &v1alpha1.ClassicELBHealthCheck{
-			Target:             fmt.Sprintf("%v:%d", v1alpha1.ClassicELBProtocolTCP, 6443),
+			Target:             healthCheckTarget(listeners[0]),
 			Interval:           10 * time.Second,
 			Timeout:            5 * time.Second,
 			HealthyThreshold:   5,
@@ -157,22 +293,75 @@ func (s *Service) getAPIServerClassicELBSpec() *v1alpha1.ClassicELB {
 		SecurityGroupIDs: []string{s.scope.SecurityGroups()[v1alpha1.SecurityGroupControlPlane].ID},
 		Attributes: v1alpha1.ClassicELBAttributes{
 			IdleTimeout: 10 * time.Minute,
+			ConnectionDraining: v1alpha1.ClassicELBConnectionDraining{
+				Enabled: true,
+				Timeout: 300 * time.Second,
+			},
 		},
 	}
 
+	if lb := s.scope.ClusterConfig.ControlPlaneLoadBalancer; lb != nil {
+		if lb.Scheme != nil {
+			res.Scheme = *lb.Scheme
+		}
+		if lb.HealthCheck != nil {
+			res.HealthCheck = lb.HealthCheck.DeepCopy()
+		}
+		if lb.CrossZoneLoadBalancing != nil {
+			res.Attributes.CrossZoneLoadBalancing = *lb.CrossZoneLoadBalancing
+		}
+		if lb.ConnectionDraining != nil {
+			res.Attributes.ConnectionDraining = *lb.ConnectionDraining
+			if !lb.ConnectionDraining.Enabled {
+				// The timeout is meaningless when draining is disabled.
+				res.Attributes.ConnectionDraining.Timeout = 0
+			}
+		}
+	}
+
 	res.Tags = v1alpha1.Build(v1alpha1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   v1alpha1.ResourceLifecycleOwned,
 		Role:        aws.String(v1alpha1.APIServerRoleTagValue),
 	})
 
-	for _, sn := range s.scope.Subnets().FilterPublic() {
+	subnets := s.scope.Subnets().FilterPublic()
+	if res.Scheme == v1alpha1.ClassicELBSchemeInternal {
+		subnets = s.scope.Subnets().FilterPrivate()
+	}
+	for _, sn := range subnets {
 		res.SubnetIDs = append(res.SubnetIDs, sn.ID)
 	}
 
 	return res
 }
 
+// crossZoneLoadBalancingSet returns true if the user provided load balancer
+// configuration sets cross-zone load balancing explicitly.
+func crossZoneLoadBalancingSet(lb *v1alpha1.AWSLoadBalancerSpec) bool {
+	return lb != nil && lb.CrossZoneLoadBalancing != nil
+}
+
+// validateLoadBalancerSpec checks the user provided load balancer configuration.
+func validateLoadBalancerSpec(lb *v1alpha1.AWSLoadBalancerSpec) error {
+	if lb == nil || lb.ListenerProtocol == nil {
+		return nil
+	}
+
+	switch *lb.ListenerProtocol {
+	case v1alpha1.ClassicELBProtocolSSL, v1alpha1.ClassicELBProtocolHTTPS:
+	default:
+		return errors.Errorf("invalid load balancer listener protocol %q, must be %q or %q",
+			*lb.ListenerProtocol, v1alpha1.ClassicELBProtocolSSL, v1alpha1.ClassicELBProtocolHTTPS)
+	}
+
+	if lb.CertificateARN == nil {
+		return errors.Errorf("load balancer listener protocol %q requires a certificate", *lb.ListenerProtocol)
+	}
+
+	return nil
+}
+
 func (s *Service) createClassicELB(spec *v1alpha1.ClassicELB) (*v1alpha1.ClassicELB, error) {
 	input := &elb.CreateLoadBalancerInput{
 		LoadBalancerName: aws.String(spec.Name),
@@ -183,12 +372,7 @@ func (s *Service) createClassicELB(spec *v1alpha1.ClassicELB) (*v1alpha1.Classic
 	}
 
 	for _, ln := range spec.Listeners {
-		input.Listeners = append(input.Listeners, &elb.Listener{
-			Protocol:         aws.String(string(ln.Protocol)),
-			LoadBalancerPort: aws.Int64(ln.Port),
-			InstanceProtocol: aws.String(string(ln.InstanceProtocol)),
-			InstancePort:     aws.Int64(ln.InstancePort),
-		})
+		input.Listeners = append(input.Listeners, toSDKListener(ln))
 	}
 
 	out, err := s.scope.ELB.CreateLoadBalancer(input)
@@ -197,29 +381,161 @@ func (s *Service) createClassicELB(spec *v1alpha1.ClassicELB) (*v1alpha1.Classic
 	}
 
 	if spec.HealthCheck != nil {
-		hc := &elb.ConfigureHealthCheckInput{
-			LoadBalancerName: aws.String(spec.Name),
-			HealthCheck: &elb.HealthCheck{
-				Target:             aws.String(spec.HealthCheck.Target),
-				Interval:           aws.Int64(int64(spec.HealthCheck.Interval.Seconds())),
-				Timeout:            aws.Int64(int64(spec.HealthCheck.Timeout.Seconds())),
-				HealthyThreshold:   aws.Int64(spec.HealthCheck.HealthyThreshold),
-				UnhealthyThreshold: aws.Int64(spec.HealthCheck.UnhealthyThreshold),
-			},
+		if err := s.configureHealthCheck(spec.Name, spec.HealthCheck); err != nil {
+			return nil, err
 		}
+	}
 
-		if _, err := s.scope.ELB.ConfigureHealthCheck(hc); err != nil {
-			return nil, errors.Wrapf(err, "failed to configure health check for classic load balancer: %v", spec)
-		}
+	// New load balancers balance across availability zones unless the user
+	// turned it off.
+	attributes := spec.Attributes
+	if !crossZoneLoadBalancingSet(s.scope.ClusterConfig.ControlPlaneLoadBalancer) {
+		attributes.CrossZoneLoadBalancing = true
+	}
+	if err := s.configureAttributes(spec.Name, attributes); err != nil {
+		return nil, err
 	}
 
 	s.scope.V(2).Info("Created classic load balancer", "dns-name", *out.DNSName)
 
 	res := spec.DeepCopy()
 	res.DNSName = *out.DNSName
+	res.Attributes = attributes
 	return res, nil
 }
 
+// healthCheckTarget returns the health check target of the instances behind
+// the listener, probing the health endpoint of the API server when the
+// listener forwards HTTPS.
+func healthCheckTarget(listener *v1alpha1.ClassicELBListener) string {
+	target := fmt.Sprintf("%v:%d", listener.InstanceProtocol, listener.InstancePort)
+	if listener.InstanceProtocol == v1alpha1.ClassicELBProtocolHTTPS {
+		target += "/healthz"
+	}
+	return target
+}
+
+// reconcileHealthCheck configures the health check of the classic load
+// balancer if it differs from the desired one, e.g. after the API server port
+// or protocol changed.
+func (s *Service) reconcileHealthCheck(name string, desired, current *v1alpha1.ClassicELBHealthCheck) error {
+	if desired == nil || reflect.DeepEqual(desired, current) {
+		return nil
+	}
+
+	s.scope.V(2).Info("Updating classic load balancer health check", "name", name, "target", desired.Target)
+	return s.configureHealthCheck(name, desired)
+}
+
+func (s *Service) configureHealthCheck(name string, hc *v1alpha1.ClassicELBHealthCheck) error {
+	input := &elb.ConfigureHealthCheckInput{
+		LoadBalancerName: aws.String(name),
+		HealthCheck: &elb.HealthCheck{
+			Target:             aws.String(hc.Target),
+			Interval:           aws.Int64(int64(hc.Interval.Seconds())),
+			Timeout:            aws.Int64(int64(hc.Timeout.Seconds())),
+			HealthyThreshold:   aws.Int64(hc.HealthyThreshold),
+			UnhealthyThreshold: aws.Int64(hc.UnhealthyThreshold),
+		},
+	}
+
+	if _, err := s.scope.ELB.ConfigureHealthCheck(input); err != nil {
+		return errors.Wrapf(err, "failed to configure health check for classic load balancer %q", name)
+	}
+	return nil
+}
+
+// reconcileListeners replaces the listeners of the classic load balancer that
+// differ from the desired ones, e.g. after the API server port changed. A
+// listener is identified by its load balancer port, so stale listeners are
+// deleted before the desired ones are created on their ports.
+func (s *Service) reconcileListeners(name string, desired, existing []*v1alpha1.ClassicELBListener) error {
+	existingByPort := map[int64]*v1alpha1.ClassicELBListener{}
+	for _, ln := range existing {
+		existingByPort[ln.Port] = ln
+	}
+
+	desiredByPort := map[int64]*v1alpha1.ClassicELBListener{}
+	var create []*elb.Listener
+	for _, ln := range desired {
+		desiredByPort[ln.Port] = ln
+		if current, ok := existingByPort[ln.Port]; !ok || !listenerMatches(current, ln) {
+			create = append(create, toSDKListener(ln))
+		}
+	}
+
+	var remove []*int64
+	for _, ln := range existing {
+		if want, ok := desiredByPort[ln.Port]; !ok || !listenerMatches(ln, want) {
+			remove = append(remove, aws.Int64(ln.Port))
+		}
+	}
+
+	if len(remove) > 0 {
+		s.scope.V(2).Info("Deleting stale classic load balancer listeners", "name", name, "ports", aws.Int64ValueSlice(remove))
+		if _, err := s.scope.ELB.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+			LoadBalancerName:  aws.String(name),
+			LoadBalancerPorts: remove,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to delete listeners of classic load balancer %q", name)
+		}
+	}
+
+	if len(create) > 0 {
+		s.scope.V(2).Info("Creating classic load balancer listeners", "name", name)
+		if _, err := s.scope.ELB.CreateLoadBalancerListeners(&elb.CreateLoadBalancerListenersInput{
+			LoadBalancerName: aws.String(name),
+			Listeners:        create,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to create listeners of classic load balancer %q", name)
+		}
+	}
+
+	return nil
+}
+
+// listenerMatches returns true if the listeners are equal, ignoring the case
+// of their protocols.
+func listenerMatches(a, b *v1alpha1.ClassicELBListener) bool {
+	return a.Port == b.Port &&
+		a.InstancePort == b.InstancePort &&
+		strings.EqualFold(string(a.Protocol), string(b.Protocol)) &&
+		strings.EqualFold(string(a.InstanceProtocol), string(b.InstanceProtocol)) &&
+		a.SSLCertificateID == b.SSLCertificateID
+}
+
+// listenersMatch returns true if both sets of listeners match one another.
+func listenersMatch(a, b []*v1alpha1.ClassicELBListener) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	byPort := map[int64]*v1alpha1.ClassicELBListener{}
+	for _, ln := range a {
+		byPort[ln.Port] = ln
+	}
+	for _, ln := range b {
+		if current, ok := byPort[ln.Port]; !ok || !listenerMatches(current, ln) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toSDKListener(ln *v1alpha1.ClassicELBListener) *elb.Listener {
+	listener := &elb.Listener{
+		Protocol:         aws.String(string(ln.Protocol)),
+		LoadBalancerPort: aws.Int64(ln.Port),
+		InstanceProtocol: aws.String(string(ln.InstanceProtocol)),
+		InstancePort:     aws.Int64(ln.InstancePort),
+	}
+	if ln.SSLCertificateID != "" {
+		listener.SSLCertificateId = aws.String(ln.SSLCertificateID)
+	}
+	return listener
+}
+
 func (s *Service) configureAttributes(name string, attributes v1alpha1.ClassicELBAttributes) error {
 	attrs := &elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerName:       aws.String(name),
@@ -232,6 +548,17 @@ func (s *Service) configureAttributes(name string, attributes v1alpha1.ClassicEL
 		}
 	}
 
+	attrs.LoadBalancerAttributes.CrossZoneLoadBalancing = &elb.CrossZoneLoadBalancing{
+		Enabled: aws.Bool(attributes.CrossZoneLoadBalancing),
+	}
+
+	attrs.LoadBalancerAttributes.ConnectionDraining = &elb.ConnectionDraining{
+		Enabled: aws.Bool(attributes.ConnectionDraining.Enabled),
+	}
+	if attributes.ConnectionDraining.Enabled && attributes.ConnectionDraining.Timeout > 0 {
+		attrs.LoadBalancerAttributes.ConnectionDraining.Timeout = aws.Int64(int64(attributes.ConnectionDraining.Timeout.Seconds()))
+	}
+
 	if _, err := s.scope.ELB.ModifyLoadBalancerAttributes(attrs); err != nil {
 		return errors.Wrapf(err, "failed to configure attributes for classic load balancer: %v", name)
 	}
@@ -286,6 +613,44 @@ func (s *Service) deleteClassicELBAndWait(name string) error {
 	return nil
 }
 
+func (s *Service) deregisterInstancesFromClassicELB(name string, instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	input := &elb.DeregisterInstancesFromLoadBalancerInput{
+		LoadBalancerName: aws.String(name),
+	}
+	for _, id := range instanceIDs {
+		input.Instances = append(input.Instances, &elb.Instance{InstanceId: aws.String(id)})
+	}
+
+	if _, err := s.scope.ELB.DeregisterInstancesFromLoadBalancer(input); err != nil {
+		return errors.Wrapf(err, "failed to deregister instances %v from classic load balancer %q", instanceIDs, name)
+	}
+
+	s.scope.V(2).Info("Deregistered instances from classic load balancer", "load-balancer", name, "instance-ids", instanceIDs)
+	return nil
+}
+
+// registeredInstances returns the IDs of the instances registered with the classic ELB.
+func (s *Service) registeredInstances(name string) ([]string, error) {
+	out, err := s.scope.ELB.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{
+		LoadBalancerNames: aws.StringSlice([]string{name}),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe classic load balancer %q", name)
+	}
+
+	var ids []string
+	for _, lb := range out.LoadBalancerDescriptions {
+		for _, instance := range lb.Instances {
+			ids = append(ids, aws.StringValue(instance.InstanceId))
+		}
+	}
+	return ids, nil
+}
+
 func (s *Service) describeClassicELB(name string) (*v1alpha1.ClassicELB, error) {
 	input := &elb.DescribeLoadBalancersInput{
 		LoadBalancerNames: aws.StringSlice([]string{name}),
@@ -330,16 +695,51 @@ func (s *Service) describeClassicELB(name string) (*v1alpha1.ClassicELB, error)
 
 func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBalancerAttributes) *v1alpha1.ClassicELB {
 	res := &v1alpha1.ClassicELB{
-		Name:             aws.StringValue(v.LoadBalancerName),
-		Scheme:           v1alpha1.ClassicELBScheme(*v.Scheme),
-		SubnetIDs:        aws.StringValueSlice(v.Subnets),
-		SecurityGroupIDs: aws.StringValueSlice(v.SecurityGroups),
-		DNSName:          aws.StringValue(v.DNSName),
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                v1alpha1.ClassicELBScheme(*v.Scheme),
+		SubnetIDs:             aws.StringValueSlice(v.Subnets),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		DNSName:               aws.StringValue(v.DNSName),
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneNameID),
+	}
+
+	for _, ld := range v.ListenerDescriptions {
+		if ld.Listener == nil {
+			continue
+		}
+		res.Listeners = append(res.Listeners, &v1alpha1.ClassicELBListener{
+			Protocol:         v1alpha1.ClassicELBProtocol(aws.StringValue(ld.Listener.Protocol)),
+			Port:             aws.Int64Value(ld.Listener.LoadBalancerPort),
+			InstanceProtocol: v1alpha1.ClassicELBProtocol(aws.StringValue(ld.Listener.InstanceProtocol)),
+			InstancePort:     aws.Int64Value(ld.Listener.InstancePort),
+			SSLCertificateID: aws.StringValue(ld.Listener.SSLCertificateId),
+		})
+	}
+
+	if hc := v.HealthCheck; hc != nil {
+		res.HealthCheck = &v1alpha1.ClassicELBHealthCheck{
+			Target:             aws.StringValue(hc.Target),
+			Interval:           time.Duration(aws.Int64Value(hc.Interval)) * time.Second,
+			Timeout:            time.Duration(aws.Int64Value(hc.Timeout)) * time.Second,
+			HealthyThreshold:   aws.Int64Value(hc.HealthyThreshold),
+			UnhealthyThreshold: aws.Int64Value(hc.UnhealthyThreshold),
+		}
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
 		res.Attributes.IdleTimeout = time.Duration(*attrs.ConnectionSettings.IdleTimeout) * time.Second
 	}
 
+	if attrs.CrossZoneLoadBalancing != nil {
+		res.Attributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
+	}
+
+	if attrs.ConnectionDraining != nil && aws.BoolValue(attrs.ConnectionDraining.Enabled) {
+		res.Attributes.ConnectionDraining = v1alpha1.ClassicELBConnectionDraining{
+			Enabled: true,
+			Timeout: time.Duration(aws.Int64Value(attrs.ConnectionDraining.Timeout)) * time.Second,
+		}
+	}
+
 	return res
 }
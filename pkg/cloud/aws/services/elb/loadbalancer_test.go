@@ -17,9 +17,12 @@ limitations under the License.
 package elb
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/golang/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +33,18 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
 )
 
+// defaultHealthCheck returns the default health check of the API server load
+// balancer with the given target.
+func defaultHealthCheck(target string) *elb.HealthCheck {
+	return &elb.HealthCheck{
+		Target:             aws.String(target),
+		Interval:           aws.Int64(10),
+		Timeout:            aws.Int64(5),
+		HealthyThreshold:   aws.Int64(5),
+		UnhealthyThreshold: aws.Int64(3),
+	}
+}
+
 func TestDeleteLoadBalancers(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -55,10 +70,65 @@ func TestDeleteLoadBalancers(t *testing.T) {
 					LoadBalancerAttributes: &elb.LoadBalancerAttributes{},
 				}, nil)
 
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+						{
+							LoadBalancerName: aws.String("test-cluster-apiserver"),
+						},
+					},
+				}, nil)
+
 				m.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{
 					LoadBalancerName: aws.String("test-cluster-apiserver"),
 				})
 
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
+			},
+		},
+		{
+			name: "elb has instances registered, it deregisters them before deleting it",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+						{
+							LoadBalancerName: aws.String("test-cluster-apiserver"),
+							VPCId:            aws.String("test-vpc"),
+							Scheme:           aws.String(string(v1alpha1.ClassicELBSchemeInternetFacing)),
+						},
+					},
+				}, nil)
+
+				m.DescribeLoadBalancerAttributes(gomock.Any()).Return(&elb.DescribeLoadBalancerAttributesOutput{
+					LoadBalancerAttributes: &elb.LoadBalancerAttributes{},
+				}, nil)
+
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+						{
+							LoadBalancerName: aws.String("test-cluster-apiserver"),
+							Instances: []*elb.Instance{
+								{InstanceId: aws.String("i-1")},
+								{InstanceId: aws.String("i-2")},
+								{InstanceId: aws.String("i-3")},
+							},
+						},
+					},
+				}, nil)
+
+				gomock.InOrder(
+					m.DeregisterInstancesFromLoadBalancer(&elb.DeregisterInstancesFromLoadBalancerInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Instances: []*elb.Instance{
+							{InstanceId: aws.String("i-1")},
+							{InstanceId: aws.String("i-2")},
+							{InstanceId: aws.String("i-3")},
+						},
+					}).Return(&elb.DeregisterInstancesFromLoadBalancerOutput{}, nil),
+					m.DeleteLoadBalancer(&elb.DeleteLoadBalancerInput{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+					}),
+				)
+
 				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
 			},
 		},
@@ -98,3 +168,1097 @@ func TestDeleteLoadBalancers(t *testing.T) {
 		})
 	}
 }
+
+func TestDeregisterInstancesFromAPIServerELB(t *testing.T) {
+	testCases := []struct {
+		name        string
+		instanceIDs []string
+		expect      func(m *mock_elbiface.MockELBAPIMockRecorder)
+	}{
+		{
+			name:        "multiple instances are deregistered in a single call",
+			instanceIDs: []string{"i-1", "i-2", "i-3"},
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {
+				m.DeregisterInstancesFromLoadBalancer(&elb.DeregisterInstancesFromLoadBalancerInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					Instances: []*elb.Instance{
+						{InstanceId: aws.String("i-1")},
+						{InstanceId: aws.String("i-2")},
+						{InstanceId: aws.String("i-3")},
+					},
+				}).Return(&elb.DeregisterInstancesFromLoadBalancerOutput{}, nil)
+			},
+		},
+		{
+			name:   "no instances, nothing to do",
+			expect: func(m *mock_elbiface.MockELBAPIMockRecorder) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(elbMock.EXPECT())
+			s := NewService(scope)
+			if err := s.DeregisterInstancesFromAPIServerELB(tc.instanceIDs); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIServerELBHasHealthyInstance(t *testing.T) {
+	testCases := []struct {
+		name          string
+		states        []*elb.InstanceState
+		expectHealthy bool
+	}{
+		{
+			name: "no registered instances",
+		},
+		{
+			name: "no healthy instances",
+			states: []*elb.InstanceState{
+				{InstanceId: aws.String("i-1"), State: aws.String("OutOfService")},
+				{InstanceId: aws.String("i-2"), State: aws.String("Unknown")},
+			},
+		},
+		{
+			name: "one healthy instance",
+			states: []*elb.InstanceState{
+				{InstanceId: aws.String("i-1"), State: aws.String("OutOfService")},
+				{InstanceId: aws.String("i-2"), State: aws.String("InService")},
+			},
+			expectHealthy: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			elbMock.EXPECT().
+				DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+				}).
+				Return(&elb.DescribeInstanceHealthOutput{InstanceStates: tc.states}, nil)
+
+			healthy, err := NewService(scope).APIServerELBHasHealthyInstance()
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if healthy != tc.expectHealthy {
+				t.Fatalf("expected healthy to be %v, got %v", tc.expectHealthy, healthy)
+			}
+		})
+	}
+}
+
+func TestInstancesRegisteredWithAPIServerELB(t *testing.T) {
+	testCases := []struct {
+		name             string
+		states           []*elb.InstanceState
+		err              error
+		expectRegistered []string
+	}{
+		{
+			name: "no registered instances",
+		},
+		{
+			name: "instance deregistration in progress",
+			states: []*elb.InstanceState{
+				{InstanceId: aws.String("i-1"), State: aws.String("OutOfService"), Description: aws.String("Instance deregistration currently in progress.")},
+				{InstanceId: aws.String("i-other"), State: aws.String("InService")},
+			},
+			expectRegistered: []string{"i-1"},
+		},
+		{
+			name: "load balancer no longer exists",
+			err:  awserr.New(elb.ErrCodeAccessPointNotFoundException, "not found", nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			elbMock.EXPECT().
+				DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+				}).
+				Return(&elb.DescribeInstanceHealthOutput{InstanceStates: tc.states}, tc.err)
+
+			registered, err := NewService(scope).InstancesRegisteredWithAPIServerELB([]string{"i-1", "i-2"})
+			if err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(registered, tc.expectRegistered) {
+				t.Fatalf("expected registered instances %v, got %v", tc.expectRegistered, registered)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersHealthCheck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name                string
+		loadBalancer        *v1alpha1.AWSLoadBalancerSpec
+		expectedHealthCheck *elb.HealthCheck
+	}{
+		{
+			name: "default health check",
+			expectedHealthCheck: &elb.HealthCheck{
+				Target:             aws.String("TCP:6443"),
+				Interval:           aws.Int64(10),
+				Timeout:            aws.Int64(5),
+				HealthyThreshold:   aws.Int64(5),
+				UnhealthyThreshold: aws.Int64(3),
+			},
+		},
+		{
+			name: "configured health check",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				HealthCheck: &v1alpha1.ClassicELBHealthCheck{
+					Target:             "SSL:6443",
+					Interval:           30 * time.Second,
+					Timeout:            10 * time.Second,
+					HealthyThreshold:   2,
+					UnhealthyThreshold: 10,
+				},
+			},
+			expectedHealthCheck: &elb.HealthCheck{
+				Target:             aws.String("SSL:6443"),
+				Interval:           aws.Int64(30),
+				Timeout:            aws.Int64(10),
+				HealthyThreshold:   aws.Int64(2),
+				UnhealthyThreshold: aws.Int64(10),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					VPC: v1alpha1.VPCSpec{
+						ID: "test-vpc",
+					},
+				},
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			m := elbMock.EXPECT()
+			m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
+			m.CreateLoadBalancer(gomock.Any()).Return(&elb.CreateLoadBalancerOutput{
+				DNSName: aws.String("test-cluster-apiserver.elb.amazonaws.com"),
+			}, nil)
+			m.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+				LoadBalancerName: aws.String("test-cluster-apiserver"),
+				HealthCheck:      tc.expectedHealthCheck,
+			}).Return(&elb.ConfigureHealthCheckOutput{}, nil)
+			m.ModifyLoadBalancerAttributes(gomock.Any()).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+
+			s := NewService(scope)
+			if err := s.ReconcileLoadbalancers(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersAttributes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	defaultAttributes := func() *elb.LoadBalancerAttributes {
+		return &elb.LoadBalancerAttributes{
+			ConnectionSettings: &elb.ConnectionSettings{
+				IdleTimeout: aws.Int64(600),
+			},
+			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
+				Enabled: aws.Bool(true),
+			},
+			ConnectionDraining: &elb.ConnectionDraining{
+				Enabled: aws.Bool(true),
+				Timeout: aws.Int64(300),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		loadBalancer   *v1alpha1.AWSLoadBalancerSpec
+		existing       func(attrs *elb.LoadBalancerAttributes)
+		expectedModify func(attrs *elb.LoadBalancerAttributes)
+	}{
+		{
+			name: "attributes match defaults, nothing to do",
+		},
+		{
+			name: "cross-zone disabled, left as is by default",
+			existing: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.CrossZoneLoadBalancing.Enabled = aws.Bool(false)
+			},
+		},
+		{
+			name: "cross-zone disabled, turned on, enables it",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CrossZoneLoadBalancing: aws.Bool(true),
+			},
+			existing: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.CrossZoneLoadBalancing.Enabled = aws.Bool(false)
+			},
+			expectedModify: func(attrs *elb.LoadBalancerAttributes) {},
+		},
+		{
+			name: "cross-zone turned off, disables it",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CrossZoneLoadBalancing: aws.Bool(false),
+			},
+			expectedModify: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.CrossZoneLoadBalancing.Enabled = aws.Bool(false)
+			},
+		},
+		{
+			name: "connection draining disabled, enables it by default",
+			existing: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.ConnectionDraining.Enabled = aws.Bool(false)
+			},
+			expectedModify: func(attrs *elb.LoadBalancerAttributes) {},
+		},
+		{
+			name: "connection draining timeout changed",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				ConnectionDraining: &v1alpha1.ClassicELBConnectionDraining{
+					Enabled: true,
+					Timeout: 2 * time.Minute,
+				},
+			},
+			expectedModify: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.ConnectionDraining.Timeout = aws.Int64(120)
+			},
+		},
+		{
+			name: "connection draining turned off, disables it",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				ConnectionDraining: &v1alpha1.ClassicELBConnectionDraining{
+					Enabled: false,
+					Timeout: 2 * time.Minute,
+				},
+			},
+			expectedModify: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.ConnectionDraining = &elb.ConnectionDraining{
+					Enabled: aws.Bool(false),
+				}
+			},
+		},
+		{
+			name: "connection draining already off, ignores the reported timeout",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				ConnectionDraining: &v1alpha1.ClassicELBConnectionDraining{
+					Enabled: false,
+				},
+			},
+			existing: func(attrs *elb.LoadBalancerAttributes) {
+				attrs.ConnectionDraining.Enabled = aws.Bool(false)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					VPC: v1alpha1.VPCSpec{
+						ID: "test-vpc",
+					},
+				},
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			existing := defaultAttributes()
+			if tc.existing != nil {
+				tc.existing(existing)
+			}
+
+			m := elbMock.EXPECT()
+			m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+				LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+					{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						VPCId:            aws.String("test-vpc"),
+						Scheme:           aws.String(string(v1alpha1.ClassicELBSchemeInternetFacing)),
+						ListenerDescriptions: []*elb.ListenerDescription{
+							{
+								Listener: &elb.Listener{
+									Protocol:         aws.String("TCP"),
+									LoadBalancerPort: aws.Int64(6443),
+									InstanceProtocol: aws.String("TCP"),
+									InstancePort:     aws.Int64(6443),
+								},
+							},
+						},
+						HealthCheck: defaultHealthCheck("TCP:6443"),
+					},
+				},
+			}, nil)
+			m.DescribeLoadBalancerAttributes(gomock.Any()).Return(&elb.DescribeLoadBalancerAttributesOutput{
+				LoadBalancerAttributes: existing,
+			}, nil)
+			if tc.expectedModify != nil {
+				expected := defaultAttributes()
+				tc.expectedModify(expected)
+				m.ModifyLoadBalancerAttributes(&elb.ModifyLoadBalancerAttributesInput{
+					LoadBalancerName:       aws.String("test-cluster-apiserver"),
+					LoadBalancerAttributes: expected,
+				}).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+			}
+
+			s := NewService(scope)
+			if err := s.ReconcileLoadbalancers(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersCreateAttributes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name              string
+		loadBalancer      *v1alpha1.AWSLoadBalancerSpec
+		expectedCrossZone bool
+	}{
+		{
+			name:              "cross-zone enabled by default",
+			expectedCrossZone: true,
+		},
+		{
+			name: "cross-zone turned off",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CrossZoneLoadBalancing: aws.Bool(false),
+			},
+			expectedCrossZone: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			m := elbMock.EXPECT()
+			m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
+			m.CreateLoadBalancer(gomock.Any()).Return(&elb.CreateLoadBalancerOutput{
+				DNSName: aws.String("test-cluster-apiserver.elb.amazonaws.com"),
+			}, nil)
+			m.ConfigureHealthCheck(gomock.Any()).Return(&elb.ConfigureHealthCheckOutput{}, nil)
+			m.ModifyLoadBalancerAttributes(&elb.ModifyLoadBalancerAttributesInput{
+				LoadBalancerName: aws.String("test-cluster-apiserver"),
+				LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+					ConnectionSettings: &elb.ConnectionSettings{
+						IdleTimeout: aws.Int64(600),
+					},
+					CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
+						Enabled: aws.Bool(tc.expectedCrossZone),
+					},
+					ConnectionDraining: &elb.ConnectionDraining{
+						Enabled: aws.Bool(true),
+						Timeout: aws.Int64(300),
+					},
+				},
+			}).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+
+			s := NewService(scope)
+			if err := s.ReconcileLoadbalancers(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			if actual := scope.Network().APIServerELB.Attributes.CrossZoneLoadBalancing; actual != tc.expectedCrossZone {
+				t.Fatalf("expected cross-zone load balancing %v in the network status, got %v", tc.expectedCrossZone, actual)
+			}
+		})
+	}
+}
+
+func TestGetAPIServerClassicELBSpecScheme(t *testing.T) {
+	internal := v1alpha1.ClassicELBSchemeInternal
+
+	testCases := []struct {
+		name            string
+		loadBalancer    *v1alpha1.AWSLoadBalancerSpec
+		expectedScheme  v1alpha1.ClassicELBScheme
+		expectedSubnets []string
+	}{
+		{
+			name:            "defaults to internet-facing in public subnets",
+			expectedScheme:  v1alpha1.ClassicELBSchemeInternetFacing,
+			expectedSubnets: []string{"subnet-public"},
+		},
+		{
+			name: "internal in private subnets",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				Scheme: &internal,
+			},
+			expectedScheme:  v1alpha1.ClassicELBSchemeInternal,
+			expectedSubnets: []string{"subnet-private-1", "subnet-private-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					Subnets: v1alpha1.Subnets{
+						{ID: "subnet-private-1"},
+						{ID: "subnet-public", IsPublic: true},
+						{ID: "subnet-private-2"},
+					},
+				},
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			spec := NewService(scope).getAPIServerClassicELBSpec()
+			if spec.Scheme != tc.expectedScheme {
+				t.Fatalf("expected scheme %q, got %q", tc.expectedScheme, spec.Scheme)
+			}
+			if !reflect.DeepEqual(spec.SubnetIDs, tc.expectedSubnets) {
+				t.Fatalf("expected subnets %v, got %v", tc.expectedSubnets, spec.SubnetIDs)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersListener(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	https := v1alpha1.ClassicELBProtocolHTTPS
+	http := v1alpha1.ClassicELBProtocolHTTP
+
+	testCases := []struct {
+		name             string
+		loadBalancer     *v1alpha1.AWSLoadBalancerSpec
+		expectedListener *elb.Listener
+		expectErr        bool
+	}{
+		{
+			name: "tcp passthrough by default",
+			expectedListener: &elb.Listener{
+				Protocol:         aws.String("TCP"),
+				LoadBalancerPort: aws.Int64(6443),
+				InstanceProtocol: aws.String("TCP"),
+				InstancePort:     aws.Int64(6443),
+			},
+		},
+		{
+			name: "ssl listener with a certificate",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CertificateARN: aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abcd"),
+			},
+			expectedListener: &elb.Listener{
+				Protocol:         aws.String("SSL"),
+				LoadBalancerPort: aws.Int64(6443),
+				InstanceProtocol: aws.String("SSL"),
+				InstancePort:     aws.Int64(6443),
+				SSLCertificateId: aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abcd"),
+			},
+		},
+		{
+			name: "https listener with a certificate",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CertificateARN:   aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abcd"),
+				ListenerProtocol: &https,
+			},
+			expectedListener: &elb.Listener{
+				Protocol:         aws.String("HTTPS"),
+				LoadBalancerPort: aws.Int64(6443),
+				InstanceProtocol: aws.String("HTTPS"),
+				InstancePort:     aws.Int64(6443),
+				SSLCertificateId: aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abcd"),
+			},
+		},
+		{
+			name: "https listener without a certificate",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				ListenerProtocol: &https,
+			},
+			expectErr: true,
+		},
+		{
+			name: "plaintext listener protocol",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CertificateARN:   aws.String("arn:aws:acm:us-east-1:123456789012:certificate/abcd"),
+				ListenerProtocol: &http,
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			if !tc.expectErr {
+				m := elbMock.EXPECT()
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{}, nil)
+				m.CreateLoadBalancer(gomock.AssignableToTypeOf(&elb.CreateLoadBalancerInput{})).
+					Do(func(input *elb.CreateLoadBalancerInput) {
+						if len(input.Listeners) != 1 || !reflect.DeepEqual(input.Listeners[0], tc.expectedListener) {
+							t.Fatalf("expected listener %v, got %v", tc.expectedListener, input.Listeners)
+						}
+					}).
+					Return(&elb.CreateLoadBalancerOutput{
+						DNSName: aws.String("test-cluster-apiserver.elb.amazonaws.com"),
+					}, nil)
+				m.ConfigureHealthCheck(gomock.Any()).Return(&elb.ConfigureHealthCheckOutput{}, nil)
+				m.ModifyLoadBalancerAttributes(gomock.Any()).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+			}
+
+			s := NewService(scope)
+			err = s.ReconcileLoadbalancers()
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersListenerUpdate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cert := "arn:aws:acm:us-east-1:123456789012:certificate/new"
+	tcp := func(port int64) *elb.Listener {
+		return &elb.Listener{
+			Protocol:         aws.String("TCP"),
+			LoadBalancerPort: aws.Int64(port),
+			InstanceProtocol: aws.String("TCP"),
+			InstancePort:     aws.Int64(6443),
+		}
+	}
+	ssl := func(certificate string) *elb.Listener {
+		return &elb.Listener{
+			Protocol:         aws.String("SSL"),
+			LoadBalancerPort: aws.Int64(6443),
+			InstanceProtocol: aws.String("SSL"),
+			InstancePort:     aws.Int64(6443),
+			SSLCertificateId: aws.String(certificate),
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		loadBalancer      *v1alpha1.AWSLoadBalancerSpec
+		existing          []*elb.Listener
+		healthCheckTarget string
+		expectDeleted     []int64
+		expectCreated     []*elb.Listener
+	}{
+		{
+			name:     "listener is up to date",
+			existing: []*elb.Listener{tcp(6443)},
+		},
+		{
+			name:          "listener port changed",
+			existing:      []*elb.Listener{tcp(443)},
+			expectDeleted: []int64{443},
+			expectCreated: []*elb.Listener{tcp(6443)},
+		},
+		{
+			name:          "stale listener is removed",
+			existing:      []*elb.Listener{tcp(6443), tcp(80)},
+			expectDeleted: []int64{80},
+		},
+		{
+			name:          "listener is missing",
+			expectCreated: []*elb.Listener{tcp(6443)},
+		},
+		{
+			name: "listener certificate changed",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				CertificateARN: aws.String(cert),
+			},
+			existing:          []*elb.Listener{ssl("arn:aws:acm:us-east-1:123456789012:certificate/old")},
+			healthCheckTarget: "SSL:6443",
+			expectDeleted:     []int64{6443},
+			expectCreated:     []*elb.Listener{ssl(cert)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mock_ec2iface.NewMockEC2API(mockCtrl)
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					EC2: ec2Mock,
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				NetworkSpec: v1alpha1.NetworkSpec{
+					VPC: v1alpha1.VPCSpec{
+						ID: "test-vpc",
+					},
+				},
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			var descriptions []*elb.ListenerDescription
+			for _, ln := range tc.existing {
+				descriptions = append(descriptions, &elb.ListenerDescription{Listener: ln})
+			}
+			healthCheckTarget := tc.healthCheckTarget
+			if healthCheckTarget == "" {
+				healthCheckTarget = "TCP:6443"
+			}
+
+			m := elbMock.EXPECT()
+			m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+				LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+					{
+						LoadBalancerName:     aws.String("test-cluster-apiserver"),
+						VPCId:                aws.String("test-vpc"),
+						Scheme:               aws.String(string(v1alpha1.ClassicELBSchemeInternetFacing)),
+						ListenerDescriptions: descriptions,
+						HealthCheck:          defaultHealthCheck(healthCheckTarget),
+					},
+				},
+			}, nil)
+			m.DescribeLoadBalancerAttributes(gomock.Any()).Return(&elb.DescribeLoadBalancerAttributesOutput{
+				LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+					ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
+					CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+					ConnectionDraining:     &elb.ConnectionDraining{Enabled: aws.Bool(true), Timeout: aws.Int64(300)},
+				},
+			}, nil)
+
+			deleted := m.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+				LoadBalancerName:  aws.String("test-cluster-apiserver"),
+				LoadBalancerPorts: aws.Int64Slice(tc.expectDeleted),
+			}).Return(&elb.DeleteLoadBalancerListenersOutput{}, nil)
+			if tc.expectDeleted == nil {
+				deleted.Times(0)
+			}
+			created := m.CreateLoadBalancerListeners(&elb.CreateLoadBalancerListenersInput{
+				LoadBalancerName: aws.String("test-cluster-apiserver"),
+				Listeners:        tc.expectCreated,
+			}).Return(&elb.CreateLoadBalancerListenersOutput{}, nil)
+			if tc.expectCreated == nil {
+				created.Times(0)
+			} else if tc.expectDeleted != nil {
+				created.After(deleted)
+			}
+
+			s := NewService(scope)
+			if err := s.ReconcileLoadbalancers(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			listeners := scope.Network().APIServerELB.Listeners
+			if len(listeners) != 1 || listeners[0].Port != 6443 {
+				t.Fatalf("expected the desired listener in the network status, got %v", listeners)
+			}
+		})
+	}
+}
+
+func TestReconcileAPIServerListeners(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tcp := func(port int64) *elb.Listener {
+		return &elb.Listener{
+			Protocol:         aws.String("TCP"),
+			LoadBalancerPort: aws.Int64(port),
+			InstanceProtocol: aws.String("TCP"),
+			InstancePort:     aws.Int64(port),
+		}
+	}
+	recordedTCP := func(port int64) *v1alpha1.ClassicELBListener {
+		return &v1alpha1.ClassicELBListener{
+			Protocol:         v1alpha1.ClassicELBProtocolTCP,
+			Port:             port,
+			InstanceProtocol: v1alpha1.ClassicELBProtocolTCP,
+			InstancePort:     port,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		apiServerPort  int64
+		recorded       []*v1alpha1.ClassicELBListener
+		existing       []*elb.Listener
+		expectDescribe bool
+		expectDeleted  []int64
+		expectCreated  []*elb.Listener
+		expectPort     int64
+	}{
+		{
+			name:       "recorded listener is up to date",
+			recorded:   []*v1alpha1.ClassicELBListener{recordedTCP(6443)},
+			expectPort: 6443,
+		},
+		{
+			name:           "listener is up to date",
+			existing:       []*elb.Listener{tcp(6443)},
+			expectDescribe: true,
+			expectPort:     6443,
+		},
+		{
+			name:           "api server port changed",
+			apiServerPort:  8443,
+			recorded:       []*v1alpha1.ClassicELBListener{recordedTCP(6443)},
+			existing:       []*elb.Listener{tcp(6443)},
+			expectDescribe: true,
+			expectDeleted:  []int64{6443},
+			expectCreated:  []*elb.Listener{tcp(8443)},
+			expectPort:     8443,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			scope.ClusterConfig.APIServerPort = tc.apiServerPort
+			scope.Network().APIServerELB.Listeners = tc.recorded
+
+			var descriptions []*elb.ListenerDescription
+			for _, ln := range tc.existing {
+				descriptions = append(descriptions, &elb.ListenerDescription{Listener: ln})
+			}
+
+			m := elbMock.EXPECT()
+			if tc.expectDescribe {
+				m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+						{
+							LoadBalancerName:     aws.String("test-cluster-apiserver"),
+							Scheme:               aws.String(string(v1alpha1.ClassicELBSchemeInternetFacing)),
+							ListenerDescriptions: descriptions,
+						},
+					},
+				}, nil)
+				m.DescribeLoadBalancerAttributes(gomock.Any()).Return(&elb.DescribeLoadBalancerAttributesOutput{
+					LoadBalancerAttributes: &elb.LoadBalancerAttributes{},
+				}, nil)
+			}
+
+			deleted := m.DeleteLoadBalancerListeners(&elb.DeleteLoadBalancerListenersInput{
+				LoadBalancerName:  aws.String("test-cluster-apiserver"),
+				LoadBalancerPorts: aws.Int64Slice(tc.expectDeleted),
+			}).Return(&elb.DeleteLoadBalancerListenersOutput{}, nil)
+			if tc.expectDeleted == nil {
+				deleted.Times(0)
+			}
+			created := m.CreateLoadBalancerListeners(&elb.CreateLoadBalancerListenersInput{
+				LoadBalancerName: aws.String("test-cluster-apiserver"),
+				Listeners:        tc.expectCreated,
+			}).Return(&elb.CreateLoadBalancerListenersOutput{}, nil)
+			if tc.expectCreated == nil {
+				created.Times(0)
+			} else if tc.expectDeleted != nil {
+				created.After(deleted)
+			}
+
+			s := NewService(scope)
+			if err := s.ReconcileAPIServerListeners(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			listeners := scope.Network().APIServerELB.Listeners
+			if len(listeners) != 1 || listeners[0].Port != tc.expectPort || listeners[0].InstancePort != tc.expectPort {
+				t.Fatalf("expected the desired listener in the network status, got %v", listeners)
+			}
+		})
+	}
+}
+
+func TestReconcileLoadbalancersHealthCheckUpdate(t *testing.T) {
+	cert := aws.String("arn:aws:acm:us-east-1:123456789012:certificate/api")
+	https := v1alpha1.ClassicELBProtocolHTTPS
+
+	testCases := []struct {
+		name         string
+		loadBalancer *v1alpha1.AWSLoadBalancerSpec
+		existing     *elb.HealthCheck
+		expected     *elb.HealthCheck
+	}{
+		{
+			name:     "health check is up to date",
+			existing: defaultHealthCheck("TCP:6443"),
+		},
+		{
+			name:     "health check is missing",
+			expected: defaultHealthCheck("TCP:6443"),
+		},
+		{
+			name:         "listener protocol changed to SSL",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{CertificateARN: cert},
+			existing:     defaultHealthCheck("TCP:6443"),
+			expected:     defaultHealthCheck("SSL:6443"),
+		},
+		{
+			name:         "listener protocol changed to HTTPS",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{CertificateARN: cert, ListenerProtocol: &https},
+			existing:     defaultHealthCheck("SSL:6443"),
+			expected:     defaultHealthCheck("HTTPS:6443/healthz"),
+		},
+		{
+			name: "health check configuration changed",
+			loadBalancer: &v1alpha1.AWSLoadBalancerSpec{
+				HealthCheck: &v1alpha1.ClassicELBHealthCheck{
+					Target:             "TCP:6443",
+					Interval:           30 * time.Second,
+					Timeout:            5 * time.Second,
+					HealthyThreshold:   5,
+					UnhealthyThreshold: 3,
+				},
+			},
+			existing: defaultHealthCheck("TCP:6443"),
+			expected: &elb.HealthCheck{
+				Target:             aws.String("TCP:6443"),
+				Interval:           aws.Int64(30),
+				Timeout:            aws.Int64(5),
+				HealthyThreshold:   aws.Int64(5),
+				UnhealthyThreshold: aws.Int64(3),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbMock := mock_elbiface.NewMockELBAPI(mockCtrl)
+
+			scope, err := actuators.NewScope(actuators.ScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSClients: actuators.AWSClients{
+					ELB: elbMock,
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			scope.ClusterConfig = &v1alpha1.AWSClusterProviderSpec{
+				ControlPlaneLoadBalancer: tc.loadBalancer,
+			}
+			scope.ClusterStatus.Network.SecurityGroups = map[v1alpha1.SecurityGroupRole]*v1alpha1.SecurityGroup{
+				v1alpha1.SecurityGroupControlPlane: {ID: "sg-controlplane"},
+			}
+
+			// The listeners are up to date, only the health check may differ.
+			listener := NewService(scope).getAPIServerListeners()[0]
+			m := elbMock.EXPECT()
+			m.DescribeLoadBalancers(gomock.Any()).Return(&elb.DescribeLoadBalancersOutput{
+				LoadBalancerDescriptions: []*elb.LoadBalancerDescription{
+					{
+						LoadBalancerName: aws.String("test-cluster-apiserver"),
+						Scheme:           aws.String(string(v1alpha1.ClassicELBSchemeInternetFacing)),
+						ListenerDescriptions: []*elb.ListenerDescription{
+							{Listener: toSDKListener(listener)},
+						},
+						HealthCheck: tc.existing,
+					},
+				},
+			}, nil)
+			m.DescribeLoadBalancerAttributes(gomock.Any()).Return(&elb.DescribeLoadBalancerAttributesOutput{
+				LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+					ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
+					CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+					ConnectionDraining:     &elb.ConnectionDraining{Enabled: aws.Bool(true), Timeout: aws.Int64(300)},
+				},
+			}, nil)
+			if tc.expected != nil {
+				m.ConfigureHealthCheck(&elb.ConfigureHealthCheckInput{
+					LoadBalancerName: aws.String("test-cluster-apiserver"),
+					HealthCheck:      tc.expected,
+				}).Return(&elb.ConfigureHealthCheckOutput{}, nil)
+			}
+
+			s := NewService(scope)
+			if err := s.ReconcileLoadbalancers(); err != nil {
+				t.Fatalf("got an unexpected error: %v", err)
+			}
+
+			expectedTarget := "TCP:6443"
+			if tc.expected != nil {
+				expectedTarget = aws.StringValue(tc.expected.Target)
+			}
+			if target := scope.Network().APIServerELB.HealthCheck.Target; target != expectedTarget {
+				t.Fatalf("expected health check target %q in the network status, got %q", expectedTarget, target)
+			}
+		})
+	}
+}
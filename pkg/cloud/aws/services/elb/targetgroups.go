@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/awserrors"
+)
+
+// RegisterInstanceWithTargetGroups registers an instance with the given
+// network or application load balancer target groups, on their port.
+func (s *Service) RegisterInstanceWithTargetGroups(instanceID string, targetGroupARNs []string) error {
+	for _, arn := range targetGroupARNs {
+		input := &elbv2.RegisterTargetsInput{
+			TargetGroupArn: aws.String(arn),
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(instanceID)}},
+		}
+
+		if _, err := s.scope.ELBV2.RegisterTargets(input); err != nil {
+			return errors.Wrapf(err, "failed to register instance %q with target group %q", instanceID, arn)
+		}
+	}
+
+	return nil
+}
+
+// DeregisterInstanceFromTargetGroups deregisters an instance from the given
+// target groups. Target groups that no longer exist are skipped.
+func (s *Service) DeregisterInstanceFromTargetGroups(instanceID string, targetGroupARNs []string) error {
+	for _, arn := range targetGroupARNs {
+		input := &elbv2.DeregisterTargetsInput{
+			TargetGroupArn: aws.String(arn),
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String(instanceID)}},
+		}
+
+		if _, err := s.scope.ELBV2.DeregisterTargets(input); err != nil {
+			if code, _ := awserrors.Code(err); code == elbv2.ErrCodeTargetGroupNotFoundException {
+				s.scope.V(2).Info("Target group to deregister instance from not found", "instance-id", instanceID, "target-group-arn", arn)
+				continue
+			}
+			return errors.Wrapf(err, "failed to deregister instance %q from target group %q", instanceID, arn)
+		}
+	}
+
+	return nil
+}
+
+// InstancesRegisteredWithTargetGroups returns the given instances any of the
+// target groups still lists, including the ones draining after their
+// deregistration. Target groups that no longer exist are skipped.
+func (s *Service) InstancesRegisteredWithTargetGroups(instanceIDs []string, targetGroupARNs []string) ([]string, error) {
+	listed := map[string]bool{}
+	for _, arn := range targetGroupARNs {
+		out, err := s.scope.ELBV2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(arn),
+		})
+		if err != nil {
+			if code, _ := awserrors.Code(err); code == elbv2.ErrCodeTargetGroupNotFoundException {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to describe target health of target group %q", arn)
+		}
+
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.Target != nil {
+				listed[aws.StringValue(desc.Target.Id)] = true
+			}
+		}
+	}
+
+	var res []string
+	for _, id := range instanceIDs {
+		if listed[id] {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
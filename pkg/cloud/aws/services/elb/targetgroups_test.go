@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/ec2/mock_ec2iface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/elb/mock_elbiface"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/elb/mock_elbv2iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	ingressTargetGroup = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/ingress/0123456789abcdef"
+	metricsTargetGroup = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/metrics/0123456789abcdef"
+)
+
+func newTargetGroupsTestService(t *testing.T, mockCtrl *gomock.Controller, elbv2Mock *mock_elbv2iface.MockELBV2API) *Service {
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSClients: actuators.AWSClients{
+			EC2:   mock_ec2iface.NewMockEC2API(mockCtrl),
+			ELB:   mock_elbiface.NewMockELBAPI(mockCtrl),
+			ELBV2: elbv2Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+	return NewService(scope)
+}
+
+func TestRegisterInstanceWithTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+	for _, arn := range []string{ingressTargetGroup, metricsTargetGroup} {
+		elbv2Mock.EXPECT().
+			RegisterTargets(&elbv2.RegisterTargetsInput{
+				TargetGroupArn: aws.String(arn),
+				Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1")}},
+			}).
+			Return(&elbv2.RegisterTargetsOutput{}, nil)
+	}
+
+	s := newTargetGroupsTestService(t, mockCtrl, elbv2Mock)
+	if err := s.RegisterInstanceWithTargetGroups("i-1", []string{ingressTargetGroup, metricsTargetGroup}); err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+}
+
+func TestDeregisterInstanceFromTargetGroups(t *testing.T) {
+	testCases := []struct {
+		name        string
+		err         error
+		expectError bool
+	}{
+		{
+			name: "instance is deregistered",
+		},
+		{
+			name: "target group no longer exists",
+			err:  awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil),
+		},
+		{
+			name:        "deregistration fails",
+			err:         awserr.New(elbv2.ErrCodeInvalidTargetException, "invalid target", nil),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+			elbv2Mock.EXPECT().
+				DeregisterTargets(&elbv2.DeregisterTargetsInput{
+					TargetGroupArn: aws.String(ingressTargetGroup),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1")}},
+				}).
+				Return(&elbv2.DeregisterTargetsOutput{}, tc.err)
+
+			s := newTargetGroupsTestService(t, mockCtrl, elbv2Mock)
+			err := s.DeregisterInstanceFromTargetGroups("i-1", []string{ingressTargetGroup})
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestInstancesRegisteredWithTargetGroups(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	elbv2Mock := mock_elbv2iface.NewMockELBV2API(mockCtrl)
+	elbv2Mock.EXPECT().
+		DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(ingressTargetGroup)}).
+		Return(&elbv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+				{
+					Target:       &elbv2.TargetDescription{Id: aws.String("i-1")},
+					TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumDraining)},
+				},
+				{
+					Target:       &elbv2.TargetDescription{Id: aws.String("i-other")},
+					TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+				},
+			},
+		}, nil)
+	elbv2Mock.EXPECT().
+		DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(metricsTargetGroup)}).
+		Return(nil, awserr.New(elbv2.ErrCodeTargetGroupNotFoundException, "not found", nil))
+
+	s := newTargetGroupsTestService(t, mockCtrl, elbv2Mock)
+	registered, err := s.InstancesRegisteredWithTargetGroups([]string{"i-1", "i-2"}, []string{ingressTargetGroup, metricsTargetGroup})
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+	if !reflect.DeepEqual(registered, []string{"i-1"}) {
+		t.Fatalf("expected [i-1] to be registered, got %v", registered)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package services
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws/session"
 	providerv1 "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
@@ -63,18 +65,54 @@ type EC2ClusterInterface interface {
 // actuator
 type EC2MachineInterface interface {
 	InstanceIfExists(id *string) (*providerv1.Instance, error)
+	StoppedInstanceIfExists(id *string) (*providerv1.Instance, error)
+	AnyInstanceIfExists(id *string) (*providerv1.Instance, error)
+	UnterminatedInstanceByTags(machine *actuators.MachineScope) ([]*providerv1.Instance, error)
+	ListInstancesByCluster(clusterName string) ([]*providerv1.Instance, error)
 	TerminateInstance(id string) error
 	GetCoreSecurityGroups(machine *actuators.MachineScope) ([]string, error)
-	GetInstanceSecurityGroups(id string) (map[string][]string, error)
+	GetInstanceSecurityGroups(id string) (string, []string, error)
+	ResolveSecurityGroupReferences(refs []providerv1.AWSResourceReference) ([]string, error)
 	CreateOrGetMachine(machine *actuators.MachineScope, token string) (*providerv1.Instance, error)
-	UpdateInstanceSecurityGroups(id string, securityGroups []string) error
+	WaitForInstanceRunning(instanceID string, timeout time.Duration) error
+	UpdateNetworkInterfaceSecurityGroups(eniID string, securityGroups []string) error
+	UpdateInstanceMonitoring(id string, enabled bool) (string, error)
+	UpdateInstanceSourceDestCheck(id string, enabled bool) error
+	UpdateInstanceMetadataOptions(id string, httpTokens providerv1.HTTPTokensState) error
+	HibernateInstance(id string) error
+	ResumeInstance(id string) error
+	GetInstanceCreditSpecification(id string) (string, error)
+	UpdateInstanceCreditSpecification(id string, cpuCredits string) error
 	UpdateResourceTags(resourceID *string, create map[string]string, remove map[string]string) error
+	GetInstanceVolumes(id string) ([]*providerv1.Volume, error)
+	GetConsoleOutput(instanceID string) (string, error)
+	KeyPairExists(name string) (bool, error)
+	CreateKeyPair(name string) (string, error)
+	DeleteKeyPair(name string) error
 }
 
 // ELBInterface encapsulates the methods exposed by the elb service.
 type ELBInterface interface {
 	ReconcileLoadbalancers() error
 	DeleteLoadbalancers() error
+	ReconcileAPIServerListeners() error
 	RegisterInstanceWithAPIServerELB(instanceID string) error
+	DeregisterInstancesFromAPIServerELB(instanceIDs []string) error
+	InstancesRegisteredWithAPIServerELB(instanceIDs []string) ([]string, error)
+	APIServerELBHasHealthyInstance() (bool, error)
 	GetAPIServerDNSName() (string, error)
+	RegisterInstanceWithTargetGroups(instanceID string, targetGroupARNs []string) error
+	DeregisterInstanceFromTargetGroups(instanceID string, targetGroupARNs []string) error
+	InstancesRegisteredWithTargetGroups(instanceIDs []string, targetGroupARNs []string) ([]string, error)
+}
+
+// Route53Interface encapsulates the methods exposed by the route53 service.
+type Route53Interface interface {
+	ReconcileAPIServerRecord() error
+	DeleteAPIServerRecord() error
+}
+
+// AutoScalingInterface encapsulates the methods exposed by the autoscaling service.
+type AutoScalingInterface interface {
+	CompleteLifecycleAction(instanceID string, hook *providerv1.LifecycleHook) error
 }
@@ -39,6 +39,13 @@ func WithNodeRegistrationOptions(nro kubeadmv1beta1.NodeRegistrationOptions) Ini
 	}
 }
 
+// WithLocalAPIEndpointPort sets the port the kube-apiserver of the first control plane machine binds to.
+func WithLocalAPIEndpointPort(port int) InitConfigurationOption {
+	return func(c *kubeadmv1beta1.InitConfiguration) {
+		c.LocalAPIEndpoint.BindPort = int32(port)
+	}
+}
+
 // ClusterConfigurationOption is a type of function that sets options on ak kubeadm ClusterConfiguration.
 type ClusterConfigurationOption func(*kubeadmv1beta1.ClusterConfiguration)
 
@@ -43,6 +43,17 @@ func TestInitConfiguration(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "with local api endpoint port",
+			expected: &kubeadmv1beta1.InitConfiguration{
+				LocalAPIEndpoint: kubeadmv1beta1.APIEndpoint{
+					BindPort: int32(1234),
+				},
+			},
+			options: []kubeadm.InitConfigurationOption{
+				kubeadm.WithLocalAPIEndpointPort(1234),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
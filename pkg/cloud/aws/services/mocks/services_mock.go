@@ -15,7 +15,7 @@ limitations under the License.
 */
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services (interfaces: EC2Interface,ELBInterface)
+// Source: sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services (interfaces: EC2Interface,ELBInterface,AutoScalingInterface,Route53Interface)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -25,6 +25,7 @@ import (
 	reflect "reflect"
 	v1alpha1 "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
 	actuators "sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	time "time"
 )
 
 // MockEC2Interface is a mock of EC2Interface interface
@@ -50,6 +51,36 @@ func (m *MockEC2Interface) EXPECT() *MockEC2InterfaceMockRecorder {
 	return m.recorder
 }
 
+// AnyInstanceIfExists mocks base method
+func (m *MockEC2Interface) AnyInstanceIfExists(arg0 *string) (*v1alpha1.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnyInstanceIfExists", arg0)
+	ret0, _ := ret[0].(*v1alpha1.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnyInstanceIfExists indicates an expected call of AnyInstanceIfExists
+func (mr *MockEC2InterfaceMockRecorder) AnyInstanceIfExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnyInstanceIfExists", reflect.TypeOf((*MockEC2Interface)(nil).AnyInstanceIfExists), arg0)
+}
+
+// CreateKeyPair mocks base method
+func (m *MockEC2Interface) CreateKeyPair(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKeyPair", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKeyPair indicates an expected call of CreateKeyPair
+func (mr *MockEC2InterfaceMockRecorder) CreateKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKeyPair", reflect.TypeOf((*MockEC2Interface)(nil).CreateKeyPair), arg0)
+}
+
 // CreateOrGetMachine mocks base method
 func (m *MockEC2Interface) CreateOrGetMachine(arg0 *actuators.MachineScope, arg1 string) (*v1alpha1.Instance, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +110,20 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteBastion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBastion", reflect.TypeOf((*MockEC2Interface)(nil).DeleteBastion))
 }
 
+// DeleteKeyPair mocks base method
+func (m *MockEC2Interface) DeleteKeyPair(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKeyPair", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKeyPair indicates an expected call of DeleteKeyPair
+func (mr *MockEC2InterfaceMockRecorder) DeleteKeyPair(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKeyPair", reflect.TypeOf((*MockEC2Interface)(nil).DeleteKeyPair), arg0)
+}
+
 // DeleteNetwork mocks base method
 func (m *MockEC2Interface) DeleteNetwork() error {
 	m.ctrl.T.Helper()
@@ -93,6 +138,21 @@ func (mr *MockEC2InterfaceMockRecorder) DeleteNetwork() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockEC2Interface)(nil).DeleteNetwork))
 }
 
+// GetConsoleOutput mocks base method
+func (m *MockEC2Interface) GetConsoleOutput(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsoleOutput", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsoleOutput indicates an expected call of GetConsoleOutput
+func (mr *MockEC2InterfaceMockRecorder) GetConsoleOutput(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsoleOutput", reflect.TypeOf((*MockEC2Interface)(nil).GetConsoleOutput), arg0)
+}
+
 // GetCoreSecurityGroups mocks base method
 func (m *MockEC2Interface) GetCoreSecurityGroups(arg0 *actuators.MachineScope) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -108,21 +168,66 @@ func (mr *MockEC2InterfaceMockRecorder) GetCoreSecurityGroups(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoreSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).GetCoreSecurityGroups), arg0)
 }
 
-// GetInstanceSecurityGroups mocks base method
-func (m *MockEC2Interface) GetInstanceSecurityGroups(arg0 string) (map[string][]string, error) {
+// GetInstanceCreditSpecification mocks base method
+func (m *MockEC2Interface) GetInstanceCreditSpecification(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInstanceSecurityGroups", arg0)
-	ret0, _ := ret[0].(map[string][]string)
+	ret := m.ctrl.Call(m, "GetInstanceCreditSpecification", arg0)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetInstanceCreditSpecification indicates an expected call of GetInstanceCreditSpecification
+func (mr *MockEC2InterfaceMockRecorder) GetInstanceCreditSpecification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceCreditSpecification", reflect.TypeOf((*MockEC2Interface)(nil).GetInstanceCreditSpecification), arg0)
+}
+
+// GetInstanceSecurityGroups mocks base method
+func (m *MockEC2Interface) GetInstanceSecurityGroups(arg0 string) (string, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceSecurityGroups", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // GetInstanceSecurityGroups indicates an expected call of GetInstanceSecurityGroups
 func (mr *MockEC2InterfaceMockRecorder) GetInstanceSecurityGroups(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).GetInstanceSecurityGroups), arg0)
 }
 
+// GetInstanceVolumes mocks base method
+func (m *MockEC2Interface) GetInstanceVolumes(arg0 string) ([]*v1alpha1.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceVolumes", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstanceVolumes indicates an expected call of GetInstanceVolumes
+func (mr *MockEC2InterfaceMockRecorder) GetInstanceVolumes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceVolumes", reflect.TypeOf((*MockEC2Interface)(nil).GetInstanceVolumes), arg0)
+}
+
+// HibernateInstance mocks base method
+func (m *MockEC2Interface) HibernateInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HibernateInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HibernateInstance indicates an expected call of HibernateInstance
+func (mr *MockEC2InterfaceMockRecorder) HibernateInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HibernateInstance", reflect.TypeOf((*MockEC2Interface)(nil).HibernateInstance), arg0)
+}
+
 // InstanceIfExists mocks base method
 func (m *MockEC2Interface) InstanceIfExists(arg0 *string) (*v1alpha1.Instance, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +243,36 @@ func (mr *MockEC2InterfaceMockRecorder) InstanceIfExists(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceIfExists", reflect.TypeOf((*MockEC2Interface)(nil).InstanceIfExists), arg0)
 }
 
+// KeyPairExists mocks base method
+func (m *MockEC2Interface) KeyPairExists(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeyPairExists", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeyPairExists indicates an expected call of KeyPairExists
+func (mr *MockEC2InterfaceMockRecorder) KeyPairExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeyPairExists", reflect.TypeOf((*MockEC2Interface)(nil).KeyPairExists), arg0)
+}
+
+// ListInstancesByCluster mocks base method
+func (m *MockEC2Interface) ListInstancesByCluster(arg0 string) ([]*v1alpha1.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInstancesByCluster", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInstancesByCluster indicates an expected call of ListInstancesByCluster
+func (mr *MockEC2InterfaceMockRecorder) ListInstancesByCluster(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstancesByCluster", reflect.TypeOf((*MockEC2Interface)(nil).ListInstancesByCluster), arg0)
+}
+
 // ReconcileBastion mocks base method
 func (m *MockEC2Interface) ReconcileBastion() error {
 	m.ctrl.T.Helper()
@@ -166,6 +301,50 @@ func (mr *MockEC2InterfaceMockRecorder) ReconcileNetwork() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileNetwork", reflect.TypeOf((*MockEC2Interface)(nil).ReconcileNetwork))
 }
 
+// ResolveSecurityGroupReferences mocks base method
+func (m *MockEC2Interface) ResolveSecurityGroupReferences(arg0 []v1alpha1.AWSResourceReference) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveSecurityGroupReferences", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveSecurityGroupReferences indicates an expected call of ResolveSecurityGroupReferences
+func (mr *MockEC2InterfaceMockRecorder) ResolveSecurityGroupReferences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveSecurityGroupReferences", reflect.TypeOf((*MockEC2Interface)(nil).ResolveSecurityGroupReferences), arg0)
+}
+
+// ResumeInstance mocks base method
+func (m *MockEC2Interface) ResumeInstance(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeInstance", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeInstance indicates an expected call of ResumeInstance
+func (mr *MockEC2InterfaceMockRecorder) ResumeInstance(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeInstance", reflect.TypeOf((*MockEC2Interface)(nil).ResumeInstance), arg0)
+}
+
+// StoppedInstanceIfExists mocks base method
+func (m *MockEC2Interface) StoppedInstanceIfExists(arg0 *string) (*v1alpha1.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoppedInstanceIfExists", arg0)
+	ret0, _ := ret[0].(*v1alpha1.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoppedInstanceIfExists indicates an expected call of StoppedInstanceIfExists
+func (mr *MockEC2InterfaceMockRecorder) StoppedInstanceIfExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoppedInstanceIfExists", reflect.TypeOf((*MockEC2Interface)(nil).StoppedInstanceIfExists), arg0)
+}
+
 // TerminateInstance mocks base method
 func (m *MockEC2Interface) TerminateInstance(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -180,18 +359,90 @@ func (mr *MockEC2InterfaceMockRecorder) TerminateInstance(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateInstance", reflect.TypeOf((*MockEC2Interface)(nil).TerminateInstance), arg0)
 }
 
-// UpdateInstanceSecurityGroups mocks base method
-func (m *MockEC2Interface) UpdateInstanceSecurityGroups(arg0 string, arg1 []string) error {
+// UnterminatedInstanceByTags mocks base method
+func (m *MockEC2Interface) UnterminatedInstanceByTags(arg0 *actuators.MachineScope) ([]*v1alpha1.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnterminatedInstanceByTags", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnterminatedInstanceByTags indicates an expected call of UnterminatedInstanceByTags
+func (mr *MockEC2InterfaceMockRecorder) UnterminatedInstanceByTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnterminatedInstanceByTags", reflect.TypeOf((*MockEC2Interface)(nil).UnterminatedInstanceByTags), arg0)
+}
+
+// UpdateInstanceCreditSpecification mocks base method
+func (m *MockEC2Interface) UpdateInstanceCreditSpecification(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceCreditSpecification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstanceCreditSpecification indicates an expected call of UpdateInstanceCreditSpecification
+func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceCreditSpecification(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceCreditSpecification", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceCreditSpecification), arg0, arg1)
+}
+
+// UpdateInstanceMetadataOptions mocks base method
+func (m *MockEC2Interface) UpdateInstanceMetadataOptions(arg0 string, arg1 v1alpha1.HTTPTokensState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceMetadataOptions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstanceMetadataOptions indicates an expected call of UpdateInstanceMetadataOptions
+func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceMetadataOptions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceMetadataOptions", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceMetadataOptions), arg0, arg1)
+}
+
+// UpdateInstanceMonitoring mocks base method
+func (m *MockEC2Interface) UpdateInstanceMonitoring(arg0 string, arg1 bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstanceMonitoring", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInstanceMonitoring indicates an expected call of UpdateInstanceMonitoring
+func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceMonitoring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceMonitoring", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceMonitoring), arg0, arg1)
+}
+
+// UpdateInstanceSourceDestCheck mocks base method
+func (m *MockEC2Interface) UpdateInstanceSourceDestCheck(arg0 string, arg1 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInstanceSecurityGroups", arg0, arg1)
+	ret := m.ctrl.Call(m, "UpdateInstanceSourceDestCheck", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateInstanceSecurityGroups indicates an expected call of UpdateInstanceSecurityGroups
-func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceSecurityGroups(arg0, arg1 interface{}) *gomock.Call {
+// UpdateInstanceSourceDestCheck indicates an expected call of UpdateInstanceSourceDestCheck
+func (mr *MockEC2InterfaceMockRecorder) UpdateInstanceSourceDestCheck(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceSecurityGroups), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstanceSourceDestCheck", reflect.TypeOf((*MockEC2Interface)(nil).UpdateInstanceSourceDestCheck), arg0, arg1)
+}
+
+// UpdateNetworkInterfaceSecurityGroups mocks base method
+func (m *MockEC2Interface) UpdateNetworkInterfaceSecurityGroups(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNetworkInterfaceSecurityGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNetworkInterfaceSecurityGroups indicates an expected call of UpdateNetworkInterfaceSecurityGroups
+func (mr *MockEC2InterfaceMockRecorder) UpdateNetworkInterfaceSecurityGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNetworkInterfaceSecurityGroups", reflect.TypeOf((*MockEC2Interface)(nil).UpdateNetworkInterfaceSecurityGroups), arg0, arg1)
 }
 
 // UpdateResourceTags mocks base method
@@ -208,6 +459,20 @@ func (mr *MockEC2InterfaceMockRecorder) UpdateResourceTags(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResourceTags", reflect.TypeOf((*MockEC2Interface)(nil).UpdateResourceTags), arg0, arg1, arg2)
 }
 
+// WaitForInstanceRunning mocks base method
+func (m *MockEC2Interface) WaitForInstanceRunning(arg0 string, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForInstanceRunning", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForInstanceRunning indicates an expected call of WaitForInstanceRunning
+func (mr *MockEC2InterfaceMockRecorder) WaitForInstanceRunning(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForInstanceRunning", reflect.TypeOf((*MockEC2Interface)(nil).WaitForInstanceRunning), arg0, arg1)
+}
+
 // MockELBInterface is a mock of ELBInterface interface
 type MockELBInterface struct {
 	ctrl     *gomock.Controller
@@ -231,6 +496,21 @@ func (m *MockELBInterface) EXPECT() *MockELBInterfaceMockRecorder {
 	return m.recorder
 }
 
+// APIServerELBHasHealthyInstance mocks base method
+func (m *MockELBInterface) APIServerELBHasHealthyInstance() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIServerELBHasHealthyInstance")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIServerELBHasHealthyInstance indicates an expected call of APIServerELBHasHealthyInstance
+func (mr *MockELBInterfaceMockRecorder) APIServerELBHasHealthyInstance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerELBHasHealthyInstance", reflect.TypeOf((*MockELBInterface)(nil).APIServerELBHasHealthyInstance))
+}
+
 // DeleteLoadbalancers mocks base method
 func (m *MockELBInterface) DeleteLoadbalancers() error {
 	m.ctrl.T.Helper()
@@ -245,6 +525,34 @@ func (mr *MockELBInterfaceMockRecorder) DeleteLoadbalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadbalancers", reflect.TypeOf((*MockELBInterface)(nil).DeleteLoadbalancers))
 }
 
+// DeregisterInstanceFromTargetGroups mocks base method
+func (m *MockELBInterface) DeregisterInstanceFromTargetGroups(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterInstanceFromTargetGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterInstanceFromTargetGroups indicates an expected call of DeregisterInstanceFromTargetGroups
+func (mr *MockELBInterfaceMockRecorder) DeregisterInstanceFromTargetGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterInstanceFromTargetGroups", reflect.TypeOf((*MockELBInterface)(nil).DeregisterInstanceFromTargetGroups), arg0, arg1)
+}
+
+// DeregisterInstancesFromAPIServerELB mocks base method
+func (m *MockELBInterface) DeregisterInstancesFromAPIServerELB(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterInstancesFromAPIServerELB", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterInstancesFromAPIServerELB indicates an expected call of DeregisterInstancesFromAPIServerELB
+func (mr *MockELBInterfaceMockRecorder) DeregisterInstancesFromAPIServerELB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterInstancesFromAPIServerELB", reflect.TypeOf((*MockELBInterface)(nil).DeregisterInstancesFromAPIServerELB), arg0)
+}
+
 // GetAPIServerDNSName mocks base method
 func (m *MockELBInterface) GetAPIServerDNSName() (string, error) {
 	m.ctrl.T.Helper()
@@ -260,6 +568,50 @@ func (mr *MockELBInterfaceMockRecorder) GetAPIServerDNSName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIServerDNSName", reflect.TypeOf((*MockELBInterface)(nil).GetAPIServerDNSName))
 }
 
+// InstancesRegisteredWithAPIServerELB mocks base method
+func (m *MockELBInterface) InstancesRegisteredWithAPIServerELB(arg0 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstancesRegisteredWithAPIServerELB", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstancesRegisteredWithAPIServerELB indicates an expected call of InstancesRegisteredWithAPIServerELB
+func (mr *MockELBInterfaceMockRecorder) InstancesRegisteredWithAPIServerELB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstancesRegisteredWithAPIServerELB", reflect.TypeOf((*MockELBInterface)(nil).InstancesRegisteredWithAPIServerELB), arg0)
+}
+
+// InstancesRegisteredWithTargetGroups mocks base method
+func (m *MockELBInterface) InstancesRegisteredWithTargetGroups(arg0, arg1 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstancesRegisteredWithTargetGroups", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstancesRegisteredWithTargetGroups indicates an expected call of InstancesRegisteredWithTargetGroups
+func (mr *MockELBInterfaceMockRecorder) InstancesRegisteredWithTargetGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstancesRegisteredWithTargetGroups", reflect.TypeOf((*MockELBInterface)(nil).InstancesRegisteredWithTargetGroups), arg0, arg1)
+}
+
+// ReconcileAPIServerListeners mocks base method
+func (m *MockELBInterface) ReconcileAPIServerListeners() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAPIServerListeners")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileAPIServerListeners indicates an expected call of ReconcileAPIServerListeners
+func (mr *MockELBInterfaceMockRecorder) ReconcileAPIServerListeners() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAPIServerListeners", reflect.TypeOf((*MockELBInterface)(nil).ReconcileAPIServerListeners))
+}
+
 // ReconcileLoadbalancers mocks base method
 func (m *MockELBInterface) ReconcileLoadbalancers() error {
 	m.ctrl.T.Helper()
@@ -287,3 +639,105 @@ func (mr *MockELBInterfaceMockRecorder) RegisterInstanceWithAPIServerELB(arg0 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterInstanceWithAPIServerELB", reflect.TypeOf((*MockELBInterface)(nil).RegisterInstanceWithAPIServerELB), arg0)
 }
+
+// RegisterInstanceWithTargetGroups mocks base method
+func (m *MockELBInterface) RegisterInstanceWithTargetGroups(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterInstanceWithTargetGroups", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterInstanceWithTargetGroups indicates an expected call of RegisterInstanceWithTargetGroups
+func (mr *MockELBInterfaceMockRecorder) RegisterInstanceWithTargetGroups(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterInstanceWithTargetGroups", reflect.TypeOf((*MockELBInterface)(nil).RegisterInstanceWithTargetGroups), arg0, arg1)
+}
+
+// MockAutoScalingInterface is a mock of AutoScalingInterface interface
+type MockAutoScalingInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAutoScalingInterfaceMockRecorder
+}
+
+// MockAutoScalingInterfaceMockRecorder is the mock recorder for MockAutoScalingInterface
+type MockAutoScalingInterfaceMockRecorder struct {
+	mock *MockAutoScalingInterface
+}
+
+// NewMockAutoScalingInterface creates a new mock instance
+func NewMockAutoScalingInterface(ctrl *gomock.Controller) *MockAutoScalingInterface {
+	mock := &MockAutoScalingInterface{ctrl: ctrl}
+	mock.recorder = &MockAutoScalingInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAutoScalingInterface) EXPECT() *MockAutoScalingInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CompleteLifecycleAction mocks base method
+func (m *MockAutoScalingInterface) CompleteLifecycleAction(arg0 string, arg1 *v1alpha1.LifecycleHook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLifecycleAction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteLifecycleAction indicates an expected call of CompleteLifecycleAction
+func (mr *MockAutoScalingInterfaceMockRecorder) CompleteLifecycleAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLifecycleAction", reflect.TypeOf((*MockAutoScalingInterface)(nil).CompleteLifecycleAction), arg0, arg1)
+}
+
+// MockRoute53Interface is a mock of Route53Interface interface
+type MockRoute53Interface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoute53InterfaceMockRecorder
+}
+
+// MockRoute53InterfaceMockRecorder is the mock recorder for MockRoute53Interface
+type MockRoute53InterfaceMockRecorder struct {
+	mock *MockRoute53Interface
+}
+
+// NewMockRoute53Interface creates a new mock instance
+func NewMockRoute53Interface(ctrl *gomock.Controller) *MockRoute53Interface {
+	mock := &MockRoute53Interface{ctrl: ctrl}
+	mock.recorder = &MockRoute53InterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRoute53Interface) EXPECT() *MockRoute53InterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAPIServerRecord mocks base method
+func (m *MockRoute53Interface) DeleteAPIServerRecord() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIServerRecord")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIServerRecord indicates an expected call of DeleteAPIServerRecord
+func (mr *MockRoute53InterfaceMockRecorder) DeleteAPIServerRecord() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIServerRecord", reflect.TypeOf((*MockRoute53Interface)(nil).DeleteAPIServerRecord))
+}
+
+// ReconcileAPIServerRecord mocks base method
+func (m *MockRoute53Interface) ReconcileAPIServerRecord() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileAPIServerRecord")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileAPIServerRecord indicates an expected call of ReconcileAPIServerRecord
+func (mr *MockRoute53InterfaceMockRecorder) ReconcileAPIServerRecord() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileAPIServerRecord", reflect.TypeOf((*MockRoute53Interface)(nil).ReconcileAPIServerRecord))
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+)
+
+// ReconcileAPIServerRecord points the API server record of the cluster, if
+// configured, at the API server load balancer. The record is an alias record,
+// which resolves like a CNAME record but also works at the apex of a zone.
+func (s *Service) ReconcileAPIServerRecord() error {
+	spec := s.scope.ClusterConfig.APIServerDNS
+	if spec == nil {
+		return nil
+	}
+	if err := validateAPIServerDNSSpec(spec); err != nil {
+		return err
+	}
+
+	lb := s.scope.Network().APIServerELB
+	if lb.DNSName == "" || lb.CanonicalHostedZoneID == "" {
+		return errors.Errorf("API server load balancer of cluster %q is not known yet, record %q can't point at it", s.scope.Name(), spec.RecordName)
+	}
+
+	existing, err := s.describeAPIServerRecord(spec)
+	if err != nil {
+		return err
+	}
+	if existing != nil && aliasTargetEqual(existing.AliasTarget, &lb) {
+		return nil
+	}
+
+	record := &route53.ResourceRecordSet{
+		Name: aws.String(spec.RecordName),
+		Type: aws.String(route53.RRTypeA),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(lb.DNSName),
+			HostedZoneId:         aws.String(lb.CanonicalHostedZoneID),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	}
+	if err := s.changeRecord(spec, route53.ChangeActionUpsert, record); err != nil {
+		return err
+	}
+
+	s.scope.V(2).Info("Pointed API server record at load balancer", "record-name", spec.RecordName, "dns-name", lb.DNSName)
+	return nil
+}
+
+// DeleteAPIServerRecord deletes the API server record of the cluster, if
+// configured. A record that doesn't exist is not an error.
+func (s *Service) DeleteAPIServerRecord() error {
+	spec := s.scope.ClusterConfig.APIServerDNS
+	if spec == nil {
+		return nil
+	}
+	if err := validateAPIServerDNSSpec(spec); err != nil {
+		return err
+	}
+
+	existing, err := s.describeAPIServerRecord(spec)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	// Deleting a record requires its current values.
+	if err := s.changeRecord(spec, route53.ChangeActionDelete, existing); err != nil {
+		return err
+	}
+
+	s.scope.V(2).Info("Deleted API server record", "record-name", spec.RecordName)
+	return nil
+}
+
+// describeAPIServerRecord returns the API server record of the cluster, or nil
+// if it doesn't exist.
+func (s *Service) describeAPIServerRecord(spec *v1alpha1.APIServerDNSSpec) (*route53.ResourceRecordSet, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(spec.HostedZoneID),
+		StartRecordName: aws.String(spec.RecordName),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	}
+
+	out, err := s.scope.Route53.ListResourceRecordSets(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe record %q in hosted zone %q", spec.RecordName, spec.HostedZoneID)
+	}
+
+	// Records are listed from the given name and type on, so the first one
+	// may be a different record if ours doesn't exist.
+	for _, record := range out.ResourceRecordSets {
+		if recordNameEqual(aws.StringValue(record.Name), spec.RecordName) && aws.StringValue(record.Type) == route53.RRTypeA {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) changeRecord(spec *v1alpha1.APIServerDNSSpec, action string, record *route53.ResourceRecordSet) error {
+	input := &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(spec.HostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("API server of cluster " + s.scope.Name()),
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(action),
+					ResourceRecordSet: record,
+				},
+			},
+		},
+	}
+
+	if _, err := s.scope.Route53.ChangeResourceRecordSets(input); err != nil {
+		return errors.Wrapf(err, "failed to %s record %q in hosted zone %q", strings.ToLower(action), spec.RecordName, spec.HostedZoneID)
+	}
+	return nil
+}
+
+func validateAPIServerDNSSpec(spec *v1alpha1.APIServerDNSSpec) error {
+	if spec.HostedZoneID == "" {
+		return errors.New("API server DNS requires a hosted zone ID")
+	}
+	if spec.RecordName == "" {
+		return errors.New("API server DNS requires a record name")
+	}
+	return nil
+}
+
+// aliasTargetEqual returns true if the alias target points at the load balancer.
+func aliasTargetEqual(target *route53.AliasTarget, lb *v1alpha1.ClassicELB) bool {
+	if target == nil {
+		return false
+	}
+	return recordNameEqual(aws.StringValue(target.DNSName), lb.DNSName) &&
+		aws.StringValue(target.HostedZoneId) == lb.CanonicalHostedZoneID
+}
+
+// recordNameEqual compares DNS names the way Route53 does, which returns them
+// fully qualified with a trailing dot.
+func recordNameEqual(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1alpha1"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/actuators"
+	"sigs.k8s.io/cluster-api-provider-aws/pkg/cloud/aws/services/route53/mock_route53iface"
+	clusterv1 "sigs.k8s.io/cluster-api/pkg/apis/cluster/v1alpha1"
+)
+
+const (
+	testHostedZoneID = "Z0000000000001"
+	testRecordName   = "api.test-cluster.example.com"
+	testELBDNSName   = "test-cluster-apiserver-1.us-east-1.elb.amazonaws.com"
+	testELBZoneID    = "Z35SXDOTRQ7X7K"
+)
+
+func newTestScope(t *testing.T, route53Mock *mock_route53iface.MockRoute53API, dns *v1alpha1.APIServerDNSSpec, lb v1alpha1.ClassicELB) *actuators.Scope {
+	scope, err := actuators.NewScope(actuators.ScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSClients: actuators.AWSClients{
+			Route53: route53Mock,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	scope.ClusterConfig.APIServerDNS = dns
+	scope.Network().APIServerELB = lb
+	return scope
+}
+
+func aliasRecord(name, dnsName, zoneID string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name: aws.String(name),
+		Type: aws.String(route53.RRTypeA),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			HostedZoneId:         aws.String(zoneID),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	}
+}
+
+func changeInput(action string, record *route53.ResourceRecordSet) *route53.ChangeResourceRecordSetsInput {
+	return &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(testHostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("API server of cluster test-cluster"),
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(action),
+					ResourceRecordSet: record,
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileAPIServerRecord(t *testing.T) {
+	dns := &v1alpha1.APIServerDNSSpec{HostedZoneID: testHostedZoneID, RecordName: testRecordName}
+	lb := v1alpha1.ClassicELB{DNSName: testELBDNSName, CanonicalHostedZoneID: testELBZoneID}
+	listInput := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(testHostedZoneID),
+		StartRecordName: aws.String(testRecordName),
+		StartRecordType: aws.String(route53.RRTypeA),
+		MaxItems:        aws.String("1"),
+	}
+
+	testCases := []struct {
+		name        string
+		dns         *v1alpha1.APIServerDNSSpec
+		lb          v1alpha1.ClassicELB
+		expect      func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		expectError bool
+	}{
+		{
+			name:   "not configured",
+			lb:     lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name:        "load balancer not known yet",
+			dns:         dns,
+			lb:          v1alpha1.ClassicELB{DNSName: testELBDNSName},
+			expect:      func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+			expectError: true,
+		},
+		{
+			name:        "record name missing",
+			dns:         &v1alpha1.APIServerDNSSpec{HostedZoneID: testHostedZoneID},
+			lb:          lb,
+			expect:      func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+			expectError: true,
+		},
+		{
+			name: "record is created",
+			dns:  dns,
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(listInput).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						aliasRecord("web.test-cluster.example.com.", "web.example.com.", "Z1"),
+					},
+				}, nil)
+				m.ChangeResourceRecordSets(changeInput(route53.ChangeActionUpsert, aliasRecord(testRecordName, testELBDNSName, testELBZoneID))).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name: "record is up to date",
+			dns:  dns,
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(listInput).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						aliasRecord(testRecordName+".", testELBDNSName+".", testELBZoneID),
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "record points at another load balancer",
+			dns:  dns,
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(listInput).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						aliasRecord(testRecordName+".", "test-cluster-apiserver-0.us-east-1.elb.amazonaws.com.", testELBZoneID),
+					},
+				}, nil)
+				m.ChangeResourceRecordSets(changeInput(route53.ChangeActionUpsert, aliasRecord(testRecordName, testELBDNSName, testELBZoneID))).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			tc.expect(route53Mock.EXPECT())
+
+			s := NewService(newTestScope(t, route53Mock, tc.dns, tc.lb))
+			err := s.ReconcileAPIServerRecord()
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDeleteAPIServerRecord(t *testing.T) {
+	dns := &v1alpha1.APIServerDNSSpec{HostedZoneID: testHostedZoneID, RecordName: testRecordName}
+	existing := aliasRecord(testRecordName+".", testELBDNSName+".", testELBZoneID)
+
+	testCases := []struct {
+		name   string
+		dns    *v1alpha1.APIServerDNSSpec
+		expect func(m *mock_route53iface.MockRoute53APIMockRecorder)
+	}{
+		{
+			name:   "not configured",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {},
+		},
+		{
+			name: "record is deleted",
+			dns:  dns,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{existing},
+				}, nil)
+				m.ChangeResourceRecordSets(changeInput(route53.ChangeActionDelete, existing)).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name: "record doesn't exist",
+			dns:  dns,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			tc.expect(route53Mock.EXPECT())
+
+			s := NewService(newTestScope(t, route53Mock, tc.dns, v1alpha1.ClassicELB{}))
+			if err := s.DeleteAPIServerRecord(); err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+		})
+	}
+}
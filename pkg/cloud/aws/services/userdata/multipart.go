@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Part defines a part of a cloud-init MIME multipart user data document.
+type Part struct {
+	// ContentType is the MIME type of the part, e.g. "text/x-shellscript" or
+	// "text/cloud-config". Defaults to the type cloud-init infers from the
+	// first line of the content.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// Filename is the name cloud-init gives the part.
+	// +optional
+	Filename string `json:"filename,omitempty"`
+
+	// Content is the actual content of the part.
+	Content string `json:"content"`
+}
+
+// contentTypePrefixes maps the first line of user data to the MIME type
+// cloud-init handles it as, see
+// https://cloudinit.readthedocs.io/en/latest/topics/format.html
+var contentTypePrefixes = []struct {
+	prefix      string
+	contentType string
+}{
+	{"## template: jinja", "text/jinja2"},
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#part-handler", "text/part-handler"},
+	{"#include", "text/x-include-url"},
+	{"#upstart-job", "text/upstart-job"},
+	{"#!", "text/x-shellscript"},
+}
+
+// DetectContentType returns the MIME type of the given user data, based on its
+// first line, or "text/plain" if it isn't recognized.
+func DetectContentType(content string) string {
+	for _, p := range contentTypePrefixes {
+		if strings.HasPrefix(content, p.prefix) {
+			return p.contentType
+		}
+	}
+	return "text/plain"
+}
+
+// NewMultipart assembles the given parts, in order, into a MIME multipart
+// document cloud-init processes part by part.
+func NewMultipart(parts []Part) (string, error) {
+	if len(parts) == 0 {
+		return "", errors.New("failed to assemble multipart user data without parts")
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	for i, part := range parts {
+		contentType := part.ContentType
+		if contentType == "" {
+			contentType = DetectContentType(part.Content)
+		}
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return "", errors.Wrapf(err, "invalid content type %q for user data part %d", contentType, i)
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", contentType))
+		header.Set("MIME-Version", "1.0")
+		if part.Filename != "" {
+			header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": part.Filename}))
+		}
+
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create user data part %d", i)
+		}
+		if _, err := pw.Write([]byte(part.Content)); err != nil {
+			return "", errors.Wrapf(err, "failed to write user data part %d", i)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to assemble multipart user data")
+	}
+
+	var doc bytes.Buffer
+	fmt.Fprintf(&doc, "Content-Type: multipart/mixed; boundary=%q\r\n", w.Boundary())
+	doc.WriteString("MIME-Version: 1.0\r\n\r\n")
+	doc.Write(body.Bytes())
+	return doc.String(), nil
+}
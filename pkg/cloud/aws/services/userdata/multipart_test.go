@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestDetectContentType(t *testing.T) {
+	testCases := []struct {
+		content string
+		expect  string
+	}{
+		{content: cloudConfigHeader, expect: "text/jinja2"},
+		{content: "#cloud-config\npackages: []\n", expect: "text/cloud-config"},
+		{content: defaultHeader, expect: "text/x-shellscript"},
+		{content: "#include\nhttps://example.com/user-data\n", expect: "text/x-include-url"},
+		{content: "#cloud-boothook\necho boot\n", expect: "text/cloud-boothook"},
+		{content: "hello", expect: "text/plain"},
+	}
+
+	for _, tc := range testCases {
+		if got := DetectContentType(tc.content); got != tc.expect {
+			t.Errorf("expected content type %q for %q, got %q", tc.expect, tc.content, got)
+		}
+	}
+}
+
+func TestNewMultipart(t *testing.T) {
+	parts := []Part{
+		{Filename: "bootstrap", Content: "#cloud-config\nruncmd: [kubeadm]\n"},
+		{Content: "#!/bin/bash\necho first\n"},
+		{ContentType: "text/x-shellscript", Filename: "second.sh", Content: "echo second\n"},
+	}
+
+	doc, err := NewMultipart(parts)
+	if err != nil {
+		t.Fatalf("did not expect error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("failed to parse document: %v", err)
+	}
+	if v := msg.Header.Get("MIME-Version"); v != "1.0" {
+		t.Fatalf("expected MIME version 1.0, got %q", v)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse content type: %v", err)
+	}
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("expected multipart/mixed, got %q", mediaType)
+	}
+
+	expected := []struct {
+		contentType string
+		filename    string
+		content     string
+	}{
+		{contentType: "text/cloud-config", filename: "bootstrap", content: parts[0].Content},
+		{contentType: "text/x-shellscript", content: parts[1].Content},
+		{contentType: "text/x-shellscript", filename: "second.sh", content: parts[2].Content},
+	}
+
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for i, e := range expected {
+		p, err := r.NextPart()
+		if err != nil {
+			t.Fatalf("failed to read part %d: %v", i, err)
+		}
+
+		ct, _, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("failed to parse content type of part %d: %v", i, err)
+		}
+		if ct != e.contentType {
+			t.Errorf("expected part %d to be %q, got %q", i, e.contentType, ct)
+		}
+		if p.FileName() != e.filename {
+			t.Errorf("expected part %d to be named %q, got %q", i, e.filename, p.FileName())
+		}
+
+		content, err := ioutil.ReadAll(p)
+		if err != nil {
+			t.Fatalf("failed to read content of part %d: %v", i, err)
+		}
+		if string(content) != e.content {
+			t.Errorf("expected part %d content %q, got %q", i, e.content, content)
+		}
+	}
+
+	if _, err := r.NextPart(); err == nil {
+		t.Fatal("expected no more parts")
+	}
+}
+
+func TestNewMultipartErrors(t *testing.T) {
+	if _, err := NewMultipart(nil); err == nil {
+		t.Error("expected error without parts")
+	}
+
+	if _, err := NewMultipart([]Part{{ContentType: "not a type;", Content: "echo"}}); err == nil {
+		t.Error("expected error with an invalid content type")
+	}
+}
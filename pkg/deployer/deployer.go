@@ -88,7 +88,7 @@ func (d *Deployer) GetKubeConfig(cluster *clusterv1.Cluster, _ *clusterv1.Machin
 		return "", errors.Wrap(err, "failed to get DNS address")
 	}
 
-	server := fmt.Sprintf("https://%s:6443", dnsName)
+	server := fmt.Sprintf("https://%s:%d", dnsName, actuators.APIServerPort(config))
 
 	cfg, err := certificates.NewKubeconfig(cluster.Name, server, cert, key)
 	if err != nil {